@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/Jac0bDeal/goNES/internal/asm"
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+)
+
+// assemble runs the asm subcommand, which assembles a source file into an
+// iNES image or a raw binary.
+func assemble(args []string) error {
+	flags := flag.NewFlagSet("asm", flag.ExitOnError)
+	output := flags.String("o", "goNES.nes", "path of the file to write, an iNES image if it ends in .nes and a raw binary otherwise")
+	variant := flags.String("cpu", "2a03", "CPU to assemble for: 2a03, 6502, 65c02 or r65c02")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: goNES asm [flags] source.s")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("asm: expected a single source file")
+	}
+
+	v, err := parseVariant(*variant)
+	if err != nil {
+		return err
+	}
+	p, err := asm.New(v).AssembleFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(*output), ".nes") {
+		if data, err = p.INES(); err != nil {
+			return err
+		}
+	} else {
+		_, data = p.Bytes(0xff)
+	}
+	return ioutil.WriteFile(*output, data, 0644)
+}
+
+// parseVariant returns the CPU variant named on the command line.
+func parseVariant(name string) (cpu.Variant, error) {
+	switch strings.ToLower(name) {
+	case "2a03":
+		return cpu.RP2A03, nil
+	case "6502":
+		return cpu.NMOS6502, nil
+	case "65c02":
+		return cpu.WDC65C02, nil
+	case "r65c02":
+		return cpu.R65C02, nil
+	}
+	return 0, fmt.Errorf("unknown CPU %q, expected 2a03, 6502, 65c02 or r65c02", name)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/console"
+	"github.com/Jac0bDeal/goNES/internal/debugger"
+)
+
+// debug runs the debug subcommand, which steps a ROM under the control of
+// commands read from the terminal. Interrupting stops a run at the next
+// instruction rather than ending the session.
+func debug(args []string) error {
+	flags := flag.NewFlagSet("debug", flag.ExitOnError)
+	syntax := flags.String("syntax", "ca65", "assembly syntax of the disassembly: legacy, ca65 or nesasm")
+	symbolFiles := flags.String("symbols", "", "comma separated symbol files naming addresses: ca65 .dbg, FCEUX .nl or Mesen .mlb")
+	script := flags.String("x", "", "path of a script of commands to execute before reading from the terminal")
+	region := flags.String("region", "auto", "region of the console: auto, ntsc, pal or dendy")
+	port1, port2 := portFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: goNES debug [flags] rom.nes")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("debug: expected a single ROM")
+	}
+
+	formatter, err := newFormatter(*syntax)
+	if err != nil {
+		return err
+	}
+	cart, err := cartridge.LoadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	r, err := parseRegion(*region, cart.Header.Timing)
+	if err != nil {
+		return err
+	}
+	n, err := console.New(cart, r)
+	if err != nil {
+		return err
+	}
+	if err := connectPeripherals(n, *port1, *port2); err != nil {
+		return err
+	}
+	// run the power on reset sequence, stopping at the first instruction
+	n.StepInstruction()
+
+	d := debugger.New(n, os.Stdout, formatter)
+	if *symbolFiles != "" {
+		table, err := loadSymbols(*symbolFiles)
+		if err != nil {
+			return err
+		}
+		d.SetSymbols(table.Resolver(cart))
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	go func() {
+		for range interrupts {
+			d.Interrupt()
+		}
+	}()
+
+	if *script != "" {
+		err := d.Source(*script)
+		if err == debugger.ErrQuit {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return d.Run(os.Stdin)
+}
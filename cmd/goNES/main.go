@@ -1,46 +1,191 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/Jac0bDeal/goNES/internal/asm"
 	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/console"
 	"github.com/Jac0bDeal/goNES/internal/cpu"
+	"github.com/Jac0bDeal/goNES/internal/symbols"
 )
 
+// disassemblyLength is the number of bytes disassembled from the reset vector
+// of a loaded ROM.
+const disassemblyLength = 64
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "record" {
+		if err := record(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "asm" {
+		if err := assemble(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "debug" {
+		if err := debug(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	syntax := flag.String("syntax", "legacy", "assembly syntax of the disassembly: legacy, ca65 or nesasm")
+	follow := flag.Bool("follow", false, "disassemble by following the flow of control from the vectors, rather than from the reset vector on")
+	symbolFiles := flag.String("symbols", "", "comma separated symbol files naming addresses in the disassembly: ca65 .dbg, FCEUX .nl or Mesen .mlb")
+	flag.Parse()
+
+	formatter, err := newFormatter(*syntax)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	var (
-		programStart  uint16 = 0x8000
-		programEnd    uint16
-		programOffset = programStart
+		b            *bus.Bus
+		prg          symbols.PRGMapper
+		programStart uint16
+		programEnd   uint16
 	)
+	if flag.NArg() > 0 {
+		cart, err := cartridge.LoadFile(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		h := cart.Header
+		fmt.Printf("%s: %s, mapper %d, %dKB PRG-ROM, %dKB CHR-ROM, %s mirroring, %s\n",
+			flag.Arg(0), h.Format, h.Mapper, h.PRGROMSize/1024, h.CHRROMSize/1024, h.Mirroring, h.Timing)
 
-	// load program
-	program := []byte{0xA2, 0x0A, 0x8E, 0x00, 0x00, 0xA2, 0x03, 0x8E, 0x01, 0x00, 0xAC, 0x00, 0x00, 0xA9, 0x00, 0x18, 0x6D, 0x01, 0x00, 0x88, 0xD0, 0xFA, 0x8D, 0x02, 0x00, 0xEA, 0xEA, 0xEA}
-	r := bus.RAM{}
-	for _, b := range program {
-		r[programOffset] = b
-		programOffset++
+		n, err := console.New(cart, console.RegionOf(h.Timing))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		b = n.Bus()
+		prg = cart
+		programStart = uint16(b.Peek(0xfffd))<<8 | uint16(b.Peek(0xfffc))
+		programEnd = programStart + disassemblyLength
+		if programEnd < programStart {
+			programEnd = 0xffff
+		}
+		if *follow {
+			// follow the code across the whole of PRG-ROM, with the end
+			// wrapping around to the end of memory
+			programStart, programEnd = 0x8000, 0x0000
+		}
+	} else {
+		b, programStart, programEnd = loadTestProgram()
 	}
-	programEnd = programOffset
-
-	// set reset pointer
-	r[0xFFFC] = byte(programStart & 0x00ff)
-	r[0xFFFD] = byte((programStart & 0xff00) >> 8)
 
-	// initialize bus and cpu
-	b := bus.NewBus(r)
-	c := cpu.NewMos6502()
-	c.ConnectBus(b)
+	// load symbols
+	d := cpu.NewDisassembler(b, cpu.RP2A03)
+	var resolver *symbols.Resolver
+	if *symbolFiles != "" {
+		table, err := loadSymbols(*symbolFiles)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		resolver = table.Resolver(prg)
+		d.SetLabeler(resolver)
+	}
 
 	// disassemble program and print
-	asmMap := c.Disassemble(programStart, programEnd)
-	for a := programStart; a < programEnd; a++ {
-		asmLine, exists := asmMap[a]
-		if exists {
-			fmt.Println(asmLine)
+	if *follow {
+		for _, line := range d.Follow(programStart, programEnd-1).Format(formatter) {
+			fmt.Println(line)
+		}
+	} else {
+		for _, in := range d.Disassemble(programStart, programEnd) {
+			if resolver != nil {
+				if label, offset, ok := resolver.Label(in.Address); ok && offset == 0 {
+					fmt.Println(label + ":")
+				}
+			}
+			if in.Comment != "" {
+				for _, comment := range strings.Split(in.Comment, "\n") {
+					fmt.Println("; " + comment)
+				}
+			}
+			fmt.Printf("$%X: %s\n", in.Address, formatter.Format(in))
 		}
 	}
+}
 
-	// reset cpu
-	c.Reset()
+// loadSymbols loads a comma separated list of symbol files into a Table.
+func loadSymbols(files string) (*symbols.Table, error) {
+	table := symbols.NewTable()
+	for _, path := range strings.Split(files, ",") {
+		if err := table.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+// newFormatter returns the formatter of an assembly syntax by name.
+func newFormatter(syntax string) (cpu.Formatter, error) {
+	switch syntax {
+	case "legacy":
+		return cpu.LegacyFormatter{}, nil
+	case "ca65":
+		return cpu.CA65Formatter{}, nil
+	case "nesasm":
+		return cpu.NESASMFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown assembly syntax %q, expected legacy, ca65 or nesasm", syntax)
+	}
+}
+
+// testProgram multiplies 10 by 3 into $0002.
+const testProgram = `
+	.org $8000
+start:
+	ldx #10
+	stx a:$0000
+	ldx #3
+	stx a:$0001
+	ldy a:$0000
+	lda #0
+	clc
+@loop:
+	adc a:$0001
+	dey
+	bne @loop
+	sta a:$0002
+	nop
+	nop
+	nop
+end:
+
+	.org $fffc
+	.word start
+`
+
+// loadTestProgram builds a Bus holding the assembled test program and returns
+// it along with the program's address range.
+func loadTestProgram() (*bus.Bus, uint16, uint16) {
+	p, err := asm.New(cpu.RP2A03).Assemble("test program", []byte(testProgram))
+	if err != nil {
+		panic(err)
+	}
+
+	r := bus.RAM{}
+	for _, s := range p.Segments {
+		copy(r[s.Origin:], s.Data)
+	}
+	return bus.NewBus(r), uint16(p.Symbols["start"]), uint16(p.Symbols["end"])
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/Jac0bDeal/goNES/internal/console"
+	"github.com/Jac0bDeal/goNES/internal/controller"
+)
+
+// peripheralNames lists the peripherals that can be plugged into a controller
+// port from the command line.
+const peripheralNames = "joypad, fourscore, zapper, powerpad, vaus or none"
+
+// portFlags defines the flags selecting what is plugged into each controller
+// port, returning the names they are set to.
+func portFlags(flags *flag.FlagSet) (port1 *string, port2 *string) {
+	port1 = flags.String("port1", "joypad", "peripheral plugged into controller port 1: "+peripheralNames)
+	port2 = flags.String("port2", "joypad", "peripheral plugged into controller port 2: "+peripheralNames)
+	return port1, port2
+}
+
+// connectPeripherals plugs the named peripherals into the console's controller
+// ports. The Four Score plugs into both ports, so it must be named for both.
+func connectPeripherals(n *console.NES, port1 string, port2 string) error {
+	if port1 == "fourscore" || port2 == "fourscore" {
+		if port1 != port2 {
+			return errors.New("the Four Score plugs into both controller ports")
+		}
+		f := controller.NewFourScore([4]controller.InputProvider{})
+		n.Ports().Connect(controller.Port1, f.Port(controller.Port1))
+		n.Ports().Connect(controller.Port2, f.Port(controller.Port2))
+		return nil
+	}
+
+	for i, name := range []string{port1, port2} {
+		d, err := newPeripheral(n, name)
+		if err != nil {
+			return err
+		}
+		n.Ports().Connect(controller.Port(i), d)
+	}
+	return nil
+}
+
+// newPeripheral returns a new peripheral by name, or nil for none. Nothing
+// drives the peripherals' inputs yet, so they are left idle.
+func newPeripheral(n *console.NES, name string) (controller.Device, error) {
+	switch name {
+	case "joypad":
+		return controller.NewJoypad(nil), nil
+	case "zapper":
+		return controller.NewZapper(nil, n.PPU()), nil
+	case "powerpad":
+		return controller.NewPowerPad(nil), nil
+	case "vaus":
+		return controller.NewVaus(nil), nil
+	case "none":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown peripheral %q, expected %s", name, peripheralNames)
+}
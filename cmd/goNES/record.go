@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/Jac0bDeal/goNES/internal/audio"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/console"
+)
+
+// record runs the record subcommand, which runs a ROM without a display for a
+// number of seconds and writes the audio it produces to a WAV file.
+func record(args []string) error {
+	flags := flag.NewFlagSet("record", flag.ExitOnError)
+	output := flags.String("o", "goNES.wav", "path of the WAV file to write")
+	seconds := flags.Float64("seconds", 10, "number of seconds of audio to record")
+	rate := flags.Int("rate", 44100, "sample rate of the WAV file in Hz")
+	region := flags.String("region", "auto", "region of the console: auto, ntsc, pal or dendy")
+	port1, port2 := portFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: goNES record [flags] rom.nes")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("record: expected a single ROM")
+	}
+	if *seconds <= 0 || *rate <= 0 {
+		return errors.New("record: seconds and rate must be positive")
+	}
+
+	cart, err := cartridge.LoadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	r, err := parseRegion(*region, cart.Header.Timing)
+	if err != nil {
+		return err
+	}
+	n, err := console.New(cart, r)
+	if err != nil {
+		return err
+	}
+	if err := connectPeripherals(n, *port1, *port2); err != nil {
+		return err
+	}
+
+	resampler := audio.NewResampler(r.CPUClockRate(), float64(*rate))
+	n.SetAudioOutput(resampler.Add)
+	length := int(*seconds * float64(*rate))
+	samples := make([]float32, 0, length)
+	frames := int(math.Ceil(*seconds * r.FrameRate()))
+	for i := 0; i < frames; i++ {
+		n.StepFrame()
+		samples = append(samples, resampler.Samples()...)
+	}
+	if len(samples) > length {
+		samples = samples[:length]
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := audio.WriteWAV(w, *rate, samples); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// parseRegion returns the console region named on the command line, where auto
+// picks the region the cartridge targets.
+func parseRegion(name string, timing cartridge.Timing) (console.Region, error) {
+	switch name {
+	case "auto":
+		return console.RegionOf(timing), nil
+	case "ntsc":
+		return console.NTSC, nil
+	case "pal":
+		return console.PAL, nil
+	case "dendy":
+		return console.Dendy, nil
+	}
+	return 0, fmt.Errorf("unknown region %q, expected auto, ntsc, pal or dendy", name)
+}
@@ -0,0 +1,358 @@
+package apu
+
+import "github.com/Jac0bDeal/goNES/internal/bus"
+
+// Register addresses. The channel registers occupy $4000-$4013, and the status
+// register is at $4015. The frame counter at $4017 shares its address with the
+// second controller port, which reads go to, so the APU is attached to the bus
+// at the channels and status register only and the controller ports pass
+// writes to $4017 on to it.
+const (
+	ChannelsStart uint16 = 0x4000
+	ChannelsEnd   uint16 = 0x4013
+	Status        uint16 = 0x4015
+	FrameCounter  uint16 = 0x4017
+)
+
+// frameSteps are the steps of the frame counter, in CPU cycles since it was
+// reset.
+type frameSteps struct {
+	quarter1     int
+	half1        int
+	quarter3     int
+	fourStepIRQ  int
+	fourStepHalf int
+	fourStepEnd  int
+	fiveStepHalf int
+	fiveStepEnd  int
+}
+
+// Timing is the timing of an APU variant in CPU cycles: the steps of its frame
+// counter, which pace the envelopes, sweeps and length counters and raise the
+// frame IRQ, and the periods of the noise and DMC timers, which set their
+// pitch.
+type Timing struct {
+	frameSteps   frameSteps
+	noisePeriods [16]uint16
+	dmcRates     [16]uint16
+}
+
+// Timings of the APU variants.
+var (
+	// NTSCTiming is the timing of the 2A03 in NTSC consoles, which Dendy
+	// Famiclones share.
+	NTSCTiming = Timing{
+		frameSteps: frameSteps{
+			quarter1:     7457,
+			half1:        14913,
+			quarter3:     22371,
+			fourStepIRQ:  29828,
+			fourStepHalf: 29829,
+			fourStepEnd:  29830,
+			fiveStepHalf: 37281,
+			fiveStepEnd:  37282,
+		},
+		noisePeriods: [16]uint16{
+			4, 8, 16, 32, 64, 96, 128, 160, 202, 254, 380, 508, 762, 1016, 2034, 4068,
+		},
+		dmcRates: [16]uint16{
+			428, 380, 340, 320, 286, 254, 226, 214, 190, 160, 142, 128, 106, 84, 72, 54,
+		},
+	}
+	// PALTiming is the timing of the 2A07 in PAL consoles, whose frame counter
+	// steps less often to keep the same pace on its slower clock, and whose
+	// timer periods are shorter to keep the same pitch.
+	PALTiming = Timing{
+		frameSteps: frameSteps{
+			quarter1:     8313,
+			half1:        16627,
+			quarter3:     24939,
+			fourStepIRQ:  33252,
+			fourStepHalf: 33253,
+			fourStepEnd:  33254,
+			fiveStepHalf: 41565,
+			fiveStepEnd:  41566,
+		},
+		noisePeriods: [16]uint16{
+			4, 8, 14, 30, 60, 88, 118, 148, 188, 236, 354, 472, 708, 944, 1890, 3778,
+		},
+		dmcRates: [16]uint16{
+			398, 354, 316, 298, 276, 236, 210, 198, 176, 148, 132, 118, 98, 78, 66, 50,
+		},
+	}
+)
+
+// $4015 status bits.
+const (
+	statusPulse1   uint8 = 0x01
+	statusPulse2   uint8 = 0x02
+	statusTriangle uint8 = 0x04
+	statusNoise    uint8 = 0x08
+	statusDMC      uint8 = 0x10
+	statusFrameIRQ uint8 = 0x40
+	statusDMCIRQ   uint8 = 0x80
+)
+
+// $4017 frame counter bits.
+const (
+	frameIRQInhibit uint8 = 0x40
+	frameFiveStep   uint8 = 0x80
+)
+
+// DMA is the part of the DMA unit used by the DMC to fetch sample bytes, which
+// halts the CPU while it reads them.
+type DMA interface {
+	RequestDMC(address uint16, loader bus.SampleLoader)
+}
+
+// APU represents the audio processing unit of the Ricoh 2A03. It is attached
+// to the CPU bus at $4000-$4013 and $4015, receives writes to $4017 through the
+// controller ports, and is clocked once per CPU cycle. Its five channels are
+// combined by the nonlinear mixer into a single sample, read with Sample.
+type APU struct {
+	// Channels
+	pulse1   pulse
+	pulse2   pulse
+	triangle triangle
+	noise    noise
+	dmc      dmc
+
+	// Frame counter
+	steps      frameSteps
+	frameMode  uint8 // last value written to $4017
+	frameIRQ   bool
+	frameCycle int
+	frameReset int // CPU cycles until a $4017 write resets the frame counter
+
+	cycle uint64
+}
+
+// New constructs and returns a pointer to an APU with the Timing of a variant,
+// whose DMC fetches samples through a DMA unit.
+func New(dma DMA, t Timing) *APU {
+	return &APU{
+		pulse1: pulse{onesComplement: true},
+		noise:  noise{shift: 1, periods: t.noisePeriods, period: t.noisePeriods[0]},
+		steps:  t.frameSteps,
+		dmc: dmc{
+			dma:           dma,
+			rates:         t.dmcRates,
+			rate:          t.dmcRates[0],
+			sampleAddress: 0xc000,
+			sampleLength:  1,
+			bitsRemaining: 8,
+			silence:       true,
+		},
+	}
+}
+
+// Clock advances the APU by one CPU cycle.
+func (a *APU) Clock() {
+	a.clockFrameCounter()
+
+	a.triangle.clockTimer()
+	// the pulse channels are clocked every other CPU cycle
+	if a.cycle%2 == 1 {
+		a.pulse1.clockTimer()
+		a.pulse2.clockTimer()
+	}
+	a.noise.clockTimer()
+	a.dmc.clockTimer()
+
+	a.cycle++
+}
+
+// clockFrameCounter advances the frame counter, which generates the quarter
+// and half frame signals that clock the channels' envelopes, sweeps, length
+// counters and linear counter, and in 4-step mode raises the frame IRQ.
+func (a *APU) clockFrameCounter() {
+	quarter, half := false, false
+	fiveStep := a.frameMode&frameFiveStep > 0
+
+	if a.frameReset > 0 {
+		a.frameReset--
+		if a.frameReset == 0 {
+			a.frameCycle = 0
+			// switching to 5-step mode clocks the units immediately
+			quarter, half = fiveStep, fiveStep
+		}
+	}
+
+	a.frameCycle++
+	switch a.frameCycle {
+	case a.steps.quarter1, a.steps.quarter3:
+		quarter = true
+	case a.steps.half1:
+		quarter, half = true, true
+	case a.steps.fourStepIRQ:
+		if !fiveStep {
+			a.raiseFrameIRQ()
+		}
+	case a.steps.fourStepHalf:
+		if !fiveStep {
+			quarter, half = true, true
+			a.raiseFrameIRQ()
+		}
+	case a.steps.fourStepEnd:
+		if !fiveStep {
+			a.raiseFrameIRQ()
+			a.frameCycle = 0
+		}
+	case a.steps.fiveStepHalf:
+		quarter, half = true, true
+	case a.steps.fiveStepEnd:
+		a.frameCycle = 0
+	}
+
+	if quarter {
+		a.pulse1.envelope.clock()
+		a.pulse2.envelope.clock()
+		a.triangle.clockLinear()
+		a.noise.envelope.clock()
+	}
+	if half {
+		a.pulse1.length.clock()
+		a.pulse1.clockSweep()
+		a.pulse2.length.clock()
+		a.pulse2.clockSweep()
+		a.triangle.length.clock()
+		a.noise.length.clock()
+	}
+}
+
+// raiseFrameIRQ sets the frame IRQ flag unless it is inhibited.
+func (a *APU) raiseFrameIRQ() {
+	if a.frameMode&frameIRQInhibit == 0 {
+		a.frameIRQ = true
+	}
+}
+
+// Reset signals the APU that the console's reset button was pressed, which
+// silences every channel and restarts the frame counter in the mode it was in.
+func (a *APU) Reset() {
+	a.writeStatus(0)
+	a.dmc.irq = false
+	a.writeFrameCounter(a.frameMode)
+	a.frameIRQ = false
+}
+
+// FrameIRQ returns whether the frame counter is asserting the CPU's IRQ line.
+func (a *APU) FrameIRQ() bool {
+	return a.frameIRQ
+}
+
+// DMCIRQ returns whether the DMC is asserting the CPU's IRQ line.
+func (a *APU) DMCIRQ() bool {
+	return a.dmc.irq
+}
+
+// Sample returns the current output of the APU, mixing the channels with the
+// nonlinear mixer. It ranges from 0 to about 1.
+func (a *APU) Sample() float32 {
+	return mix(a.pulse1.output(), a.pulse2.output(), a.triangle.output(), a.noise.output(), a.dmc.output)
+}
+
+// Read reads one of the APU's registers. Only the status register at $4015 can
+// be read, and reading it acknowledges the frame IRQ.
+func (a *APU) Read(address uint16) uint8 {
+	if address != Status {
+		return 0
+	}
+	status := a.Peek(address)
+	a.frameIRQ = false
+	return status
+}
+
+// Peek reads one of the APU's registers without side effects.
+func (a *APU) Peek(address uint16) uint8 {
+	if address != Status {
+		return 0
+	}
+
+	var status uint8
+	if a.pulse1.length.count > 0 {
+		status |= statusPulse1
+	}
+	if a.pulse2.length.count > 0 {
+		status |= statusPulse2
+	}
+	if a.triangle.length.count > 0 {
+		status |= statusTriangle
+	}
+	if a.noise.length.count > 0 {
+		status |= statusNoise
+	}
+	if a.dmc.bytesRemaining > 0 {
+		status |= statusDMC
+	}
+	if a.frameIRQ {
+		status |= statusFrameIRQ
+	}
+	if a.dmc.irq {
+		status |= statusDMCIRQ
+	}
+	return status
+}
+
+// DrivenBits returns the bits driven when reading an address. Bit 5 of the
+// status register is open bus, and every other register is write-only.
+func (a *APU) DrivenBits(address uint16) uint8 {
+	if address != Status {
+		return 0
+	}
+	return 0xdf
+}
+
+// Internal returns whether reading an address reads a register inside the
+// 2A03, which the status register is, so the read is not seen on the external
+// data bus.
+func (a *APU) Internal(address uint16) bool {
+	return address == Status
+}
+
+// Write writes one of the APU's registers.
+func (a *APU) Write(address uint16, data uint8) {
+	switch {
+	case address <= 0x4003:
+		a.pulse1.write(address&0x03, data)
+	case address <= 0x4007:
+		a.pulse2.write(address&0x03, data)
+	case address <= 0x400b:
+		a.triangle.write(address&0x03, data)
+	case address <= 0x400f:
+		a.noise.write(address&0x03, data)
+	case address <= ChannelsEnd:
+		a.dmc.write(address&0x03, data)
+	case address == Status:
+		a.writeStatus(data)
+	case address == FrameCounter:
+		a.writeFrameCounter(data)
+	}
+}
+
+// writeStatus enables or disables each channel. Disabling a channel silences
+// it by clearing its length counter, and enabling the DMC restarts its sample
+// if it had finished. Writing also acknowledges the DMC IRQ.
+func (a *APU) writeStatus(data uint8) {
+	a.pulse1.length.setEnabled(data&statusPulse1 > 0)
+	a.pulse2.length.setEnabled(data&statusPulse2 > 0)
+	a.triangle.length.setEnabled(data&statusTriangle > 0)
+	a.noise.length.setEnabled(data&statusNoise > 0)
+	a.dmc.setEnabled(data&statusDMC > 0)
+	a.dmc.irq = false
+}
+
+// writeFrameCounter sets the frame counter mode, which takes effect 3 or 4 CPU
+// cycles later depending on whether the write lands on an APU cycle. Setting
+// the IRQ inhibit flag acknowledges the frame IRQ.
+func (a *APU) writeFrameCounter(data uint8) {
+	a.frameMode = data
+	if data&frameIRQInhibit > 0 {
+		a.frameIRQ = false
+	}
+	if a.cycle%2 == 0 {
+		a.frameReset = 3
+	} else {
+		a.frameReset = 4
+	}
+}
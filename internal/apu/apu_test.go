@@ -0,0 +1,325 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPU_FrameCounter(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mode             uint8
+		clocks           int
+		expectedQuarters []int
+		expectedHalves   []int
+	}{
+		{
+			name:             "4-step mode",
+			mode:             0x00,
+			clocks:           2*29830 + 3,
+			expectedQuarters: []int{7459, 14915, 22373, 29831, 37289, 44745, 52203, 59661},
+			expectedHalves:   []int{14915, 29831, 44745, 59661},
+		},
+		{
+			name:             "5-step mode clocks the units when written",
+			mode:             0x80,
+			clocks:           2*37282 + 3,
+			expectedQuarters: []int{3, 7459, 14915, 22373, 37283, 44741, 52197, 59655, 74565},
+			expectedHalves:   []int{3, 14915, 37283, 52197, 74565},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := New(nil, NTSCTiming)
+			a.Write(Status, statusPulse1|statusTriangle)
+			a.Write(0x4003, 0x08)
+			a.Write(0x4008, 0x7f)
+			a.Write(0x400b, 0x08)
+			a.Write(FrameCounter, tc.mode)
+
+			// the triangle's linear counter changes on every quarter frame, and
+			// the pulse's length counter on every half frame
+			var quarters, halves []int
+			linear, length := a.triangle.linear, a.pulse1.length.count
+			for clock := 1; clock <= tc.clocks; clock++ {
+				a.Clock()
+				if a.triangle.linear != linear {
+					quarters = append(quarters, clock)
+					linear = a.triangle.linear
+				}
+				if a.pulse1.length.count != length {
+					halves = append(halves, clock)
+					length = a.pulse1.length.count
+				}
+			}
+
+			assert.Equal(t, tc.expectedQuarters, quarters)
+			assert.Equal(t, tc.expectedHalves, halves)
+		})
+	}
+}
+
+func TestAPU_FrameCounterDelay(t *testing.T) {
+	testCases := []struct {
+		name          string
+		cycle         int
+		expectedDelay int
+	}{
+		{
+			name:          "written on an APU cycle",
+			cycle:         10,
+			expectedDelay: 3,
+		},
+		{
+			name:          "written between APU cycles",
+			cycle:         11,
+			expectedDelay: 4,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := New(nil, NTSCTiming)
+			a.Write(Status, statusPulse1)
+			a.Write(0x4003, 0x08)
+			for i := 0; i < tc.cycle; i++ {
+				a.Clock()
+			}
+			a.Write(FrameCounter, frameFiveStep)
+
+			delay := 0
+			for a.pulse1.length.count == 254 {
+				a.Clock()
+				delay++
+			}
+
+			assert.Equal(t, tc.expectedDelay, delay)
+		})
+	}
+}
+
+func TestAPU_Timing(t *testing.T) {
+	testCases := []struct {
+		name                string
+		timing              Timing
+		expectedNoisePeriod uint16
+		expectedDMCRate     uint16
+		expectedIRQCycle    int
+	}{
+		{
+			name:                "NTSC",
+			timing:              NTSCTiming,
+			expectedNoisePeriod: 4068,
+			expectedDMCRate:     428,
+			expectedIRQCycle:    29828,
+		},
+		{
+			name:                "PAL",
+			timing:              PALTiming,
+			expectedNoisePeriod: 3778,
+			expectedDMCRate:     398,
+			expectedIRQCycle:    33252,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := New(nil, tc.timing)
+			a.Write(0x400e, 0x0f)
+			a.Write(0x4010, 0x00)
+
+			cycles := 0
+			for !a.FrameIRQ() {
+				a.Clock()
+				cycles++
+			}
+
+			assert.Equal(t, tc.expectedNoisePeriod, a.noise.period)
+			assert.Equal(t, tc.expectedDMCRate, a.dmc.rate)
+			assert.Equal(t, tc.expectedIRQCycle, cycles)
+		})
+	}
+}
+
+func TestAPU_FrameIRQ(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mode        uint8
+		expectedIRQ bool
+	}{
+		{
+			name:        "raised at the end of 4-step mode",
+			mode:        0x00,
+			expectedIRQ: true,
+		},
+		{
+			name: "inhibited",
+			mode: frameIRQInhibit,
+		},
+		{
+			name: "never raised in 5-step mode",
+			mode: frameFiveStep,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := New(nil, NTSCTiming)
+			a.Write(FrameCounter, tc.mode)
+
+			for i := 0; i < 29829; i++ {
+				a.Clock()
+				assert.False(t, a.FrameIRQ(), "raised too early")
+			}
+			for i := 0; i < 37282; i++ {
+				a.Clock()
+			}
+
+			assert.Equal(t, tc.expectedIRQ, a.FrameIRQ())
+		})
+	}
+
+	t.Run("acknowledged by reading $4015", func(t *testing.T) {
+		a := New(nil, NTSCTiming)
+		a.frameIRQ = true
+
+		assert.Equal(t, statusFrameIRQ, a.Read(Status))
+		assert.False(t, a.FrameIRQ())
+	})
+
+	t.Run("acknowledged by inhibiting it", func(t *testing.T) {
+		a := New(nil, NTSCTiming)
+		a.frameIRQ = true
+		a.Write(FrameCounter, frameIRQInhibit)
+
+		assert.False(t, a.FrameIRQ())
+	})
+
+	t.Run("not acknowledged by peeking $4015", func(t *testing.T) {
+		a := New(nil, NTSCTiming)
+		a.frameIRQ = true
+
+		assert.Equal(t, statusFrameIRQ, a.Peek(Status))
+		assert.True(t, a.FrameIRQ())
+	})
+}
+
+func TestAPU_Status(t *testing.T) {
+	testCases := []struct {
+		name     string
+		enable   uint8
+		setup    func(a *APU)
+		expected uint8
+	}{
+		{
+			name:   "length counters",
+			enable: 0x1f,
+			setup: func(a *APU) {
+				a.Write(0x4003, 0x08)
+				a.Write(0x400b, 0x08)
+			},
+			expected: statusPulse1 | statusTriangle,
+		},
+		{
+			name:   "disabled channels are not loaded",
+			enable: 0x00,
+			setup: func(a *APU) {
+				a.Write(0x4007, 0x08)
+				a.Write(0x400f, 0x08)
+			},
+			expected: 0x00,
+		},
+		{
+			name:   "all channels",
+			enable: 0x0f,
+			setup: func(a *APU) {
+				a.Write(0x4003, 0x08)
+				a.Write(0x4007, 0x08)
+				a.Write(0x400b, 0x08)
+				a.Write(0x400f, 0x08)
+				a.Write(0x4013, 0x01)
+				a.Write(Status, 0x1f)
+				a.dmc.irq = true
+			},
+			expected: 0x9f,
+		},
+		{
+			name:   "disabling clears length counters",
+			enable: 0x1f,
+			setup: func(a *APU) {
+				a.Write(0x4003, 0x08)
+				a.Write(0x4007, 0x08)
+				a.Write(Status, statusPulse2)
+			},
+			expected: statusPulse2,
+		},
+		{
+			name:   "writing acknowledges the DMC IRQ",
+			enable: 0x00,
+			setup: func(a *APU) {
+				a.dmc.irq = true
+				a.Write(Status, 0x00)
+			},
+			expected: 0x00,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := New(newTestDMA(), NTSCTiming)
+			a.Write(Status, tc.enable)
+			tc.setup(a)
+
+			assert.Equal(t, tc.expected, a.Peek(Status))
+		})
+	}
+}
+
+func TestAPU_Reset(t *testing.T) {
+	a := New(newTestDMA(), NTSCTiming)
+	a.Write(Status, 0x0f)
+	a.Write(0x4003, 0x08)
+	a.Write(FrameCounter, frameFiveStep)
+	a.frameIRQ = true
+	a.dmc.irq = true
+
+	a.Reset()
+
+	assert.Equal(t, uint8(0), a.Peek(Status))
+	assert.Equal(t, frameFiveStep, a.frameMode)
+}
+
+func TestAPU_Sample(t *testing.T) {
+	// the triangle holds its output while silent, so starts at 15
+	a := New(nil, NTSCTiming)
+	assert.InDelta(t, 0.24641, a.Sample(), 0.00001)
+
+	a.Write(Status, statusPulse1)
+	a.Write(0x4000, 0xbf)
+	a.Write(0x4002, 0x08)
+	a.Write(0x4003, 0x08)
+	for a.pulse1.step != 1 {
+		a.Clock()
+	}
+	assert.InDelta(t, 0.39579, a.Sample(), 0.00001)
+}
+
+func TestAPU_AttachedToBus(t *testing.T) {
+	b := bus.NewNESBus()
+	a := New(b.DMA(), NTSCTiming)
+	require.NoError(t, b.Attach(a, ChannelsStart, ChannelsEnd))
+	require.NoError(t, b.Attach(a, Status, Status))
+
+	b.Write(Status, statusNoise)
+	b.Write(0x400f, 0x08)
+	a.frameIRQ = true
+
+	b.Write(0x0000, 0x20)
+	b.Read(0x0000)
+	assert.Equal(t, uint8(0x20)|statusFrameIRQ|statusNoise, b.Read(Status), "bit 5 is open bus")
+	assert.False(t, a.FrameIRQ())
+
+	b.Write(0x0000, 0x42)
+	b.Read(0x0000)
+	assert.Equal(t, uint8(0x42), b.Read(0x4000), "write-only registers are open bus")
+	assert.Equal(t, uint8(0x42), b.Read(FrameCounter))
+}
@@ -0,0 +1,130 @@
+package apu
+
+// dmc is the delta modulation channel at $4010-$4013. It plays 1-bit delta
+// encoded samples fetched from CPU memory by DMA, each bit moving a 7-bit
+// output level up or down by 2.
+type dmc struct {
+	dma DMA
+
+	irqEnabled bool
+	irq        bool
+	loop       bool
+	rates      [16]uint16 // rates are the timer periods selected by $4010, from the Timing.
+	rate       uint16
+	timer      uint16
+
+	// Memory reader
+	sampleAddress  uint16
+	sampleLength   uint16
+	address        uint16 // address of the next sample byte
+	bytesRemaining uint16
+	fetching       bool // a DMA transfer has been requested and not finished
+	buffer         uint8
+	bufferFull     bool
+
+	// Output unit
+	shift         uint8
+	bitsRemaining uint8
+	silence       bool
+	output        uint8
+}
+
+// write writes one of the channel's four registers.
+func (d *dmc) write(register uint16, data uint8) {
+	switch register {
+	case 0:
+		d.irqEnabled = data&0x80 > 0
+		if !d.irqEnabled {
+			d.irq = false
+		}
+		d.loop = data&0x40 > 0
+		d.rate = d.rates[data&0x0f]
+	case 1:
+		d.output = data & 0x7f
+	case 2:
+		d.sampleAddress = 0xc000 | uint16(data)<<6
+	case 3:
+		d.sampleLength = uint16(data)<<4 | 0x0001
+	}
+}
+
+// setEnabled enables or disables the channel through $4015. Disabling it stops
+// the sample, after any bits already fetched have played, and enabling it
+// restarts the sample if it had finished.
+func (d *dmc) setEnabled(enabled bool) {
+	if !enabled {
+		d.bytesRemaining = 0
+		return
+	}
+	if d.bytesRemaining == 0 {
+		d.restart()
+		d.fetch()
+	}
+}
+
+// restart starts playing the sample from its beginning.
+func (d *dmc) restart() {
+	d.address = d.sampleAddress
+	d.bytesRemaining = d.sampleLength
+}
+
+// fetch requests the next sample byte from the DMA unit when the sample buffer
+// is empty.
+func (d *dmc) fetch() {
+	if d.bufferFull || d.fetching || d.bytesRemaining == 0 {
+		return
+	}
+	d.fetching = true
+	d.dma.RequestDMC(d.address, d)
+}
+
+// LoadSample fills the sample buffer with a byte fetched by the DMA unit, then
+// moves on to the next, looping or raising an IRQ at the end of the sample.
+func (d *dmc) LoadSample(data uint8) {
+	d.fetching = false
+	d.buffer = data
+	d.bufferFull = true
+
+	// the address wraps from $FFFF to $8000
+	d.address = (d.address + 1) | 0x8000
+	d.bytesRemaining--
+	if d.bytesRemaining == 0 {
+		if d.loop {
+			d.restart()
+		} else if d.irqEnabled {
+			d.irq = true
+		}
+	}
+}
+
+// clockTimer advances the channel by one CPU cycle, playing the next bit of
+// the sample each time the timer expires.
+func (d *dmc) clockTimer() {
+	if d.timer > 0 {
+		d.timer--
+		return
+	}
+	d.timer = d.rate - 1
+
+	if !d.silence {
+		if d.shift&0x01 > 0 {
+			if d.output <= 125 {
+				d.output += 2
+			}
+		} else if d.output >= 2 {
+			d.output -= 2
+		}
+	}
+	d.shift >>= 1
+
+	d.bitsRemaining--
+	if d.bitsRemaining == 0 {
+		d.bitsRemaining = 8
+		d.silence = !d.bufferFull
+		if d.bufferFull {
+			d.shift = d.buffer
+			d.bufferFull = false
+			d.fetch()
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDMA is a DMA unit that completes transfers as soon as they are requested,
+// reading from a map of memory and recording the addresses read.
+type testDMA struct {
+	memory map[uint16]uint8
+	reads  []uint16
+}
+
+func newTestDMA() *testDMA {
+	return &testDMA{memory: make(map[uint16]uint8)}
+}
+
+func (d *testDMA) RequestDMC(address uint16, loader bus.SampleLoader) {
+	d.reads = append(d.reads, address)
+	loader.LoadSample(d.memory[address])
+}
+
+// newTestDMC creates a DMC at the fastest rate, with its sample at $C000.
+func newTestDMC(dma DMA) *dmc {
+	d := &dmc{dma: dma, rates: NTSCTiming.dmcRates, bitsRemaining: 8, silence: true}
+	d.write(0, 0x0f)
+	d.write(2, 0x00)
+	return d
+}
+
+// clockBits clocks the DMC through n bits of output at its fastest rate,
+// returning the output level after each.
+func clockBits(d *dmc, n int) []uint8 {
+	var levels []uint8
+	for i := 0; i < n; i++ {
+		for j := 0; j < 54; j++ {
+			d.clockTimer()
+		}
+		levels = append(levels, d.output)
+	}
+	return levels
+}
+
+func TestDMC_Output(t *testing.T) {
+	dma := newTestDMA()
+	dma.memory[0xc000] = 0b00001111
+	d := newTestDMC(dma)
+	d.write(1, 0x40)
+	d.write(3, 0x00)
+	d.setEnabled(true)
+
+	assert.Equal(t, []uint16{0xc000}, dma.reads)
+	// the first byte plays once the output unit finishes its current,
+	// silent, byte
+	assert.Equal(t, []uint8{
+		0x40, 0x40, 0x40, 0x40, 0x40, 0x40, 0x40, 0x40,
+		0x42, 0x44, 0x46, 0x48, 0x46, 0x44, 0x42, 0x40,
+	}, clockBits(d, 16))
+}
+
+func TestDMC_OutputLimits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    uint8
+		sample   uint8
+		expected uint8
+	}{
+		{
+			name:     "stops at 127",
+			level:    0x7c,
+			sample:   0xff,
+			expected: 0x7e,
+		},
+		{
+			name:     "stops at 0",
+			level:    0x03,
+			sample:   0x00,
+			expected: 0x01,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dma := newTestDMA()
+			dma.memory[0xc000] = tc.sample
+			d := newTestDMC(dma)
+			d.write(1, tc.level)
+			d.write(3, 0x00)
+			d.setEnabled(true)
+			levels := clockBits(d, 16)
+
+			assert.Equal(t, tc.expected, levels[15])
+		})
+	}
+}
+
+func TestDMC_Sample(t *testing.T) {
+	testCases := []struct {
+		name          string
+		flags         uint8
+		address       uint8
+		length        uint8
+		expectedReads []uint16
+		expectedIRQ   bool
+	}{
+		{
+			name:          "plays length*16+1 bytes",
+			length:        0x01,
+			expectedReads: []uint16{0xc000, 0xc001, 0xc002, 0xc003, 0xc004, 0xc005, 0xc006, 0xc007, 0xc008, 0xc009, 0xc00a, 0xc00b, 0xc00c, 0xc00d, 0xc00e, 0xc00f, 0xc010},
+		},
+		{
+			name:          "starts at $C000 + address*64",
+			address:       0x02,
+			length:        0x00,
+			expectedReads: []uint16{0xc080},
+		},
+		{
+			name:          "raises an IRQ at the end",
+			flags:         0x80,
+			address:       0x02,
+			length:        0x00,
+			expectedReads: []uint16{0xc080},
+			expectedIRQ:   true,
+		},
+		{
+			name:          "loops",
+			flags:         0xc0,
+			length:        0x00,
+			expectedReads: []uint16{0xc000, 0xc000, 0xc000, 0xc000},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dma := newTestDMA()
+			d := newTestDMC(dma)
+			d.write(0, tc.flags|0x0f)
+			d.write(2, tc.address)
+			d.write(3, tc.length)
+			d.setEnabled(true)
+			clockBits(d, 8*len(tc.expectedReads)+8)
+
+			assert.Equal(t, tc.expectedReads, dma.reads[:len(tc.expectedReads)])
+			assert.Equal(t, tc.expectedIRQ, d.irq)
+		})
+	}
+
+	t.Run("address wraps past $FFFF", func(t *testing.T) {
+		dma := newTestDMA()
+		d := newTestDMC(dma)
+		d.write(2, 0xff)
+		d.write(3, 0x04)
+		d.setEnabled(true)
+		clockBits(d, 8*0x41+8)
+
+		assert.Equal(t, uint16(0xffff), dma.reads[0x3f])
+		assert.Equal(t, uint16(0x8000), dma.reads[0x40])
+	})
+
+	t.Run("disabling the IRQ acknowledges it", func(t *testing.T) {
+		d := newTestDMC(newTestDMA())
+		d.irq = true
+		d.write(0, 0x0f)
+
+		assert.False(t, d.irq)
+	})
+}
+
+func TestDMC_DMA(t *testing.T) {
+	b := bus.NewNESBus()
+	prg := &bus.RAM{}
+	prg[0xc040] = 0xff
+	a := New(b.DMA(), NTSCTiming)
+	require.NoError(t, b.Attach(prg, bus.CartridgeStart, bus.CartridgeEnd))
+	require.NoError(t, b.Attach(a, ChannelsStart, ChannelsEnd))
+	require.NoError(t, b.Attach(a, Status, Status))
+	b.Write(0x4010, 0x0f)
+	b.Write(0x4012, 0x01)
+	b.Write(0x4013, 0x00)
+	b.Write(Status, statusDMC)
+
+	assert.True(t, b.DMA().Active())
+	halted := 0
+	for cycle := uint32(0); b.DMA().Clock(cycle); cycle++ {
+		halted++
+	}
+	assert.Equal(t, 4, halted, "incorrect CPU stall")
+	assert.True(t, a.dmc.bufferFull)
+	assert.Equal(t, uint8(0xff), a.dmc.buffer)
+	assert.Equal(t, uint16(0), a.dmc.bytesRemaining)
+}
@@ -0,0 +1,15 @@
+package apu
+
+// mix combines the outputs of the channels the way the 2A03's nonlinear
+// mixer does. The pulse channels share one resistor network and the triangle,
+// noise and DMC another, so each channel's contribution depends on the others.
+func mix(pulse1, pulse2, triangle, noise, dmc uint8) float32 {
+	var pulseOut, tndOut float64
+	if pulse := pulse1 + pulse2; pulse > 0 {
+		pulseOut = 95.88 / (8128/float64(pulse) + 100)
+	}
+	if tnd := float64(triangle)/8227 + float64(noise)/12241 + float64(dmc)/22638; tnd > 0 {
+		tndOut = 159.79 / (1/tnd + 100)
+	}
+	return float32(pulseOut + tndOut)
+}
@@ -0,0 +1,77 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMix(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pulse1   uint8
+		pulse2   uint8
+		triangle uint8
+		noise    uint8
+		dmc      uint8
+		expected float32
+	}{
+		{
+			name:     "silence",
+			expected: 0,
+		},
+		{
+			name:     "one pulse channel",
+			pulse1:   15,
+			expected: 0.14938,
+		},
+		{
+			name:     "pulse channels are interchangeable",
+			pulse2:   15,
+			expected: 0.14938,
+		},
+		{
+			name:     "two pulse channels are less than twice as loud as one",
+			pulse1:   15,
+			pulse2:   15,
+			expected: 0.25848,
+		},
+		{
+			name:     "triangle",
+			triangle: 15,
+			expected: 0.24641,
+		},
+		{
+			name:     "noise",
+			noise:    15,
+			expected: 0.17443,
+		},
+		{
+			name:     "DMC",
+			dmc:      127,
+			expected: 0.57426,
+		},
+		{
+			name:     "pulse and triangle groups add linearly",
+			pulse1:   15,
+			triangle: 15,
+			expected: 0.39579,
+		},
+		{
+			name:     "every channel at full volume",
+			pulse1:   15,
+			pulse2:   15,
+			triangle: 15,
+			noise:    15,
+			dmc:      127,
+			expected: 1.0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mix(tc.pulse1, tc.pulse2, tc.triangle, tc.noise, tc.dmc)
+
+			assert.InDelta(t, tc.expected, actual, 0.00001)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package apu
+
+// noise is the pseudo-random noise channel at $400C-$400F, driven by a 15-bit
+// linear feedback shift register.
+type noise struct {
+	envelope envelope
+	length   lengthCounter
+
+	mode    bool // short mode, feeding back bit 6 rather than bit 1
+	shift   uint16
+	periods [16]uint16 // periods are the timer periods selected by $400E, from the Timing.
+	period  uint16
+	timer   uint16
+}
+
+// write writes one of the channel's registers. $400D is unused.
+func (n *noise) write(register uint16, data uint8) {
+	switch register {
+	case 0:
+		n.length.halt = data&0x20 > 0
+		n.envelope.write(data)
+	case 2:
+		n.mode = data&0x80 > 0
+		n.period = n.periods[data&0x0f]
+	case 3:
+		n.length.load(data >> 3)
+		n.envelope.start = true
+	}
+}
+
+// clockTimer advances the channel by one CPU cycle, shifting the shift
+// register each time the timer expires.
+func (n *noise) clockTimer() {
+	if n.timer > 0 {
+		n.timer--
+		return
+	}
+	n.timer = n.period - 1
+
+	tap := uint16(1)
+	if n.mode {
+		tap = 6
+	}
+	feedback := (n.shift ^ n.shift>>tap) & 0x01
+	n.shift = n.shift>>1 | feedback<<14
+}
+
+// output returns the channel's current output, 0-15.
+func (n *noise) output() uint8 {
+	if n.length.count == 0 || n.shift&0x01 > 0 {
+		return 0
+	}
+	return n.envelope.output()
+}
@@ -0,0 +1,92 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoise_ShiftRegister(t *testing.T) {
+	testCases := []struct {
+		name           string
+		mode           uint8
+		expectedFirst  []uint16
+		expectedPeriod int
+	}{
+		{
+			name:           "long mode",
+			mode:           0x00,
+			expectedFirst:  []uint16{0x4000, 0x2000, 0x1000, 0x0800},
+			expectedPeriod: 32767,
+		},
+		{
+			name:           "short mode",
+			mode:           0x80,
+			expectedFirst:  []uint16{0x4000, 0x2000, 0x1000, 0x0800},
+			expectedPeriod: 93,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &noise{shift: 1, periods: NTSCTiming.noisePeriods}
+			n.write(2, tc.mode)
+
+			// the timer is at 0, so the shift register shifts on the first
+			// clock and every 4 clocks after
+			var first []uint16
+			period := 0
+			for {
+				n.clockTimer()
+				n.clockTimer()
+				n.clockTimer()
+				n.clockTimer()
+				period++
+				if len(first) < len(tc.expectedFirst) {
+					first = append(first, n.shift)
+				}
+				if n.shift == 1 {
+					break
+				}
+			}
+
+			assert.Equal(t, tc.expectedFirst, first)
+			assert.Equal(t, tc.expectedPeriod, period)
+		})
+	}
+}
+
+func TestNoise_Output(t *testing.T) {
+	testCases := []struct {
+		name     string
+		enabled  bool
+		shift    uint16
+		expected uint8
+	}{
+		{
+			name:     "plays while bit 0 is clear",
+			enabled:  true,
+			shift:    0x0002,
+			expected: 9,
+		},
+		{
+			name:    "silent while bit 0 is set",
+			enabled: true,
+			shift:   0x0001,
+		},
+		{
+			name:  "silenced by the length counter",
+			shift: 0x0002,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &noise{periods: NTSCTiming.noisePeriods}
+			n.length.setEnabled(tc.enabled)
+			n.write(0, 0x19)
+			n.write(3, 0x08)
+			n.shift = tc.shift
+
+			assert.Equal(t, tc.expected, n.output())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package apu
+
+// dutySequences are the waveforms of the four pulse duty cycles: 12.5%, 25%,
+// 50% and 25% negated.
+var dutySequences = [4][8]uint8{
+	{0, 1, 0, 0, 0, 0, 0, 0},
+	{0, 1, 1, 0, 0, 0, 0, 0},
+	{0, 1, 1, 1, 1, 0, 0, 0},
+	{1, 0, 0, 1, 1, 1, 1, 1},
+}
+
+// pulse is one of the two square wave channels, at $4000-$4003 and
+// $4004-$4007.
+type pulse struct {
+	// onesComplement is set for pulse 1, whose sweep unit negates the change
+	// in period with one's complement, subtracting one more than pulse 2's.
+	onesComplement bool
+
+	envelope envelope
+	length   lengthCounter
+
+	duty   uint8
+	step   uint8
+	period uint16
+	timer  uint16
+
+	sweepEnabled bool
+	sweepPeriod  uint8
+	sweepNegate  bool
+	sweepShift   uint8
+	sweepDivider uint8
+	sweepReload  bool
+}
+
+// write writes one of the channel's four registers.
+func (p *pulse) write(register uint16, data uint8) {
+	switch register {
+	case 0:
+		p.duty = data >> 6
+		p.length.halt = data&0x20 > 0
+		p.envelope.write(data)
+	case 1:
+		p.sweepEnabled = data&0x80 > 0
+		p.sweepPeriod = (data >> 4) & 0x07
+		p.sweepNegate = data&0x08 > 0
+		p.sweepShift = data & 0x07
+		p.sweepReload = true
+	case 2:
+		p.period = p.period&0x0700 | uint16(data)
+	case 3:
+		p.period = p.period&0x00ff | uint16(data&0x07)<<8
+		p.length.load(data >> 3)
+		p.step = 0
+		p.envelope.start = true
+	}
+}
+
+// clockTimer advances the channel by one APU cycle, stepping through the duty
+// sequence each time the timer reaches 0.
+func (p *pulse) clockTimer() {
+	if p.timer > 0 {
+		p.timer--
+		return
+	}
+	p.timer = p.period
+	p.step = (p.step + 1) & 0x07
+}
+
+// sweepTarget returns the period the sweep unit would change the channel to.
+func (p *pulse) sweepTarget() int {
+	change := int(p.period >> p.sweepShift)
+	if !p.sweepNegate {
+		return int(p.period) + change
+	}
+	if p.onesComplement {
+		change++
+	}
+	if target := int(p.period) - change; target > 0 {
+		return target
+	}
+	return 0
+}
+
+// muted returns whether the sweep unit is silencing the channel, which it does
+// when the period is too low or the target period overflows, even while the
+// sweep is disabled.
+func (p *pulse) muted() bool {
+	return p.period < 8 || p.sweepTarget() > 0x07ff
+}
+
+// clockSweep advances the sweep unit on half frames, periodically changing the
+// channel's period.
+func (p *pulse) clockSweep() {
+	if p.sweepDivider == 0 && p.sweepEnabled && p.sweepShift > 0 && !p.muted() {
+		p.period = uint16(p.sweepTarget())
+	}
+	if p.sweepDivider == 0 || p.sweepReload {
+		p.sweepDivider = p.sweepPeriod
+		p.sweepReload = false
+	} else {
+		p.sweepDivider--
+	}
+}
+
+// output returns the channel's current output, 0-15.
+func (p *pulse) output() uint8 {
+	if p.length.count == 0 || p.muted() || dutySequences[p.duty][p.step] == 0 {
+		return 0
+	}
+	return p.envelope.output()
+}
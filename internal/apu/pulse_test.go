@@ -0,0 +1,226 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestPulse creates a pulse channel playing at constant volume 15 with a
+// period of 8, as pulse 1 if onesComplement is set.
+func newTestPulse(onesComplement bool) *pulse {
+	p := &pulse{onesComplement: onesComplement}
+	p.length.setEnabled(true)
+	p.write(0, 0x3f)
+	p.write(2, 0x08)
+	p.write(3, 0x08)
+	return p
+}
+
+func TestPulse_Duty(t *testing.T) {
+	testCases := []struct {
+		name     string
+		duty     uint8
+		expected []uint8
+	}{
+		{
+			name:     "12.5%",
+			duty:     0,
+			expected: []uint8{15, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "25%",
+			duty:     1,
+			expected: []uint8{15, 15, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "50%",
+			duty:     2,
+			expected: []uint8{15, 15, 15, 15, 0, 0, 0, 0},
+		},
+		{
+			name:     "25% negated",
+			duty:     3,
+			expected: []uint8{0, 0, 15, 15, 15, 15, 15, 15},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestPulse(false)
+			p.write(0, tc.duty<<6|0x3f)
+
+			// the timer is at 0, so the sequencer steps on the first clock and
+			// every 9 clocks after
+			var actual []uint8
+			for i := 0; i < 8; i++ {
+				p.clockTimer()
+				actual = append(actual, p.output())
+				for j := 0; j < 8; j++ {
+					p.clockTimer()
+				}
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestPulse_Output(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(p *pulse)
+		expected uint8
+	}{
+		{
+			name:     "plays",
+			setup:    func(p *pulse) {},
+			expected: 15,
+		},
+		{
+			name: "silenced by the length counter",
+			setup: func(p *pulse) {
+				p.length.setEnabled(false)
+			},
+		},
+		{
+			name: "muted by a period below 8",
+			setup: func(p *pulse) {
+				p.write(2, 0x07)
+			},
+		},
+		{
+			name: "muted by a sweep target overflow while the sweep is disabled",
+			setup: func(p *pulse) {
+				p.write(3, 0x07)
+				p.write(1, 0x00)
+			},
+		},
+		{
+			name: "envelope volume",
+			setup: func(p *pulse) {
+				p.write(0, 0x80)
+				p.envelope.clock()
+				p.envelope.clock()
+			},
+			expected: 14,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestPulse(false)
+			p.step = 1
+			tc.setup(p)
+
+			assert.Equal(t, tc.expected, p.output())
+		})
+	}
+}
+
+func TestPulse_SweepTarget(t *testing.T) {
+	testCases := []struct {
+		name           string
+		onesComplement bool
+		period         uint16
+		sweep          uint8
+		expectedTarget int
+		expectedMuted  bool
+	}{
+		{
+			name:           "adds the shifted period",
+			period:         0x100,
+			sweep:          0x01,
+			expectedTarget: 0x180,
+		},
+		{
+			name:           "pulse 2 negates with two's complement",
+			period:         0x100,
+			sweep:          0x09,
+			expectedTarget: 0x080,
+		},
+		{
+			name:           "pulse 1 negates with one's complement",
+			onesComplement: true,
+			period:         0x100,
+			sweep:          0x09,
+			expectedTarget: 0x07f,
+		},
+		{
+			name:           "pulse 1 negating shift 0 stops at 0",
+			onesComplement: true,
+			period:         0x100,
+			sweep:          0x08,
+			expectedTarget: 0x000,
+		},
+		{
+			name:           "overflowing target mutes",
+			period:         0x600,
+			sweep:          0x01,
+			expectedTarget: 0x900,
+			expectedMuted:  true,
+		},
+		{
+			name:           "shift 0 doubles the period",
+			period:         0x400,
+			sweep:          0x00,
+			expectedTarget: 0x800,
+			expectedMuted:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &pulse{onesComplement: tc.onesComplement, period: tc.period}
+			p.write(1, tc.sweep)
+
+			assert.Equal(t, tc.expectedTarget, p.sweepTarget())
+			assert.Equal(t, tc.expectedMuted, p.muted())
+		})
+	}
+}
+
+func TestPulse_Sweep(t *testing.T) {
+	testCases := []struct {
+		name            string
+		sweep           uint8
+		expectedPeriods []uint16
+	}{
+		{
+			name:            "disabled",
+			sweep:           0x11,
+			expectedPeriods: []uint16{0x100, 0x100, 0x100, 0x100},
+		},
+		{
+			name:            "shift 0 leaves the period",
+			sweep:           0x80,
+			expectedPeriods: []uint16{0x100, 0x100, 0x100, 0x100},
+		},
+		{
+			name:            "every half frame at period 0",
+			sweep:           0x82,
+			expectedPeriods: []uint16{0x140, 0x190, 0x1f4, 0x271},
+		},
+		{
+			name:            "every period+1 half frames",
+			sweep:           0x9a,
+			expectedPeriods: []uint16{0x0c0, 0x0c0, 0x090, 0x090},
+		},
+		{
+			name:            "stops while muted",
+			sweep:           0x81,
+			expectedPeriods: []uint16{0x180, 0x240, 0x360, 0x510, 0x798, 0x798},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &pulse{period: 0x100}
+			p.write(1, tc.sweep)
+
+			var actual []uint16
+			for range tc.expectedPeriods {
+				p.clockSweep()
+				actual = append(actual, p.period)
+			}
+
+			assert.Equal(t, tc.expectedPeriods, actual)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package apu
+
+// triangleSequence is the waveform of the triangle channel.
+var triangleSequence = [32]uint8{
+	15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0,
+	0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+}
+
+// triangle is the triangle wave channel at $4008-$400B. It has no volume
+// control, but besides its length counter has a linear counter giving finer
+// control over how long it plays.
+type triangle struct {
+	length lengthCounter
+
+	control      bool // halts the length counter and keeps reloading the linear counter
+	linearPeriod uint8
+	linear       uint8
+	linearReload bool
+
+	step   uint8
+	period uint16
+	timer  uint16
+}
+
+// write writes one of the channel's registers. $4009 is unused.
+func (t *triangle) write(register uint16, data uint8) {
+	switch register {
+	case 0:
+		t.control = data&0x80 > 0
+		t.length.halt = t.control
+		t.linearPeriod = data & 0x7f
+	case 2:
+		t.period = t.period&0x0700 | uint16(data)
+	case 3:
+		t.period = t.period&0x00ff | uint16(data&0x07)<<8
+		t.length.load(data >> 3)
+		t.linearReload = true
+	}
+}
+
+// clockTimer advances the channel by one CPU cycle. The sequence only steps
+// while both counters are non-zero, so silencing the channel leaves its output
+// where it was rather than popping to 0.
+func (t *triangle) clockTimer() {
+	if t.timer > 0 {
+		t.timer--
+		return
+	}
+	t.timer = t.period
+	if t.linear > 0 && t.length.count > 0 {
+		t.step = (t.step + 1) & 0x1f
+	}
+}
+
+// clockLinear advances the linear counter on quarter frames.
+func (t *triangle) clockLinear() {
+	if t.linearReload {
+		t.linear = t.linearPeriod
+	} else if t.linear > 0 {
+		t.linear--
+	}
+	if !t.control {
+		t.linearReload = false
+	}
+}
+
+// output returns the channel's current output, 0-15.
+func (t *triangle) output() uint8 {
+	return triangleSequence[t.step]
+}
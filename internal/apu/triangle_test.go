@@ -0,0 +1,91 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriangle_LinearCounter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		control  uint8
+		clocks   int
+		expected []uint8
+	}{
+		{
+			name:     "reloaded then counts down",
+			control:  0x03,
+			clocks:   5,
+			expected: []uint8{3, 2, 1, 0, 0},
+		},
+		{
+			name:     "reloaded every quarter frame with the control flag set",
+			control:  0x83,
+			clocks:   3,
+			expected: []uint8{3, 3, 3},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &triangle{}
+			tr.write(0, tc.control)
+			tr.write(3, 0x00)
+
+			var actual []uint8
+			for i := 0; i < tc.clocks; i++ {
+				tr.clockLinear()
+				actual = append(actual, tr.linear)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestTriangle_Sequence(t *testing.T) {
+	testCases := []struct {
+		name     string
+		enabled  bool
+		linear   uint8
+		expected []uint8
+	}{
+		{
+			name:     "steps through the sequence",
+			enabled:  true,
+			linear:   0x7f,
+			expected: []uint8{14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 15},
+		},
+		{
+			name:     "held by the linear counter",
+			enabled:  true,
+			linear:   0x00,
+			expected: []uint8{15, 15, 15, 15},
+		},
+		{
+			name:     "held by the length counter",
+			linear:   0x7f,
+			expected: []uint8{15, 15, 15, 15},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &triangle{}
+			tr.length.setEnabled(tc.enabled)
+			tr.write(0, tc.linear)
+			tr.write(2, 0x01)
+			tr.write(3, 0x08)
+			tr.clockLinear()
+
+			// with a period of 1, the sequence steps every 2 CPU cycles
+			var actual []uint8
+			for range tc.expected {
+				tr.clockTimer()
+				tr.clockTimer()
+				actual = append(actual, tr.output())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package apu
+
+// lengthTable maps the 5-bit length index written to a channel's last
+// register to the number of half frames the channel plays for.
+var lengthTable = [32]uint8{
+	10, 254, 20, 2, 40, 4, 80, 6, 160, 8, 60, 10, 14, 12, 26, 14,
+	12, 16, 24, 18, 48, 20, 96, 22, 192, 24, 72, 26, 16, 28, 32, 30,
+}
+
+// lengthCounter silences a channel after a number of half frames, unless
+// halted.
+type lengthCounter struct {
+	enabled bool
+	halt    bool
+	count   uint8
+}
+
+// load loads the counter from the length table, if the channel is enabled.
+func (l *lengthCounter) load(index uint8) {
+	if l.enabled {
+		l.count = lengthTable[index&0x1f]
+	}
+}
+
+// setEnabled enables or disables the channel through $4015. Disabling it
+// clears the counter, silencing it immediately.
+func (l *lengthCounter) setEnabled(enabled bool) {
+	l.enabled = enabled
+	if !enabled {
+		l.count = 0
+	}
+}
+
+// clock counts down on half frames.
+func (l *lengthCounter) clock() {
+	if !l.halt && l.count > 0 {
+		l.count--
+	}
+}
+
+// envelope generates the volume of the pulse and noise channels, either a
+// constant or a sawtooth decaying from 15 to 0 at a programmable rate.
+type envelope struct {
+	start    bool
+	loop     bool
+	constant bool
+	volume   uint8 // constant volume, or the period of the divider
+	divider  uint8
+	decay    uint8
+}
+
+// write sets up the envelope from the lower bits of a channel's first
+// register.
+func (e *envelope) write(data uint8) {
+	e.loop = data&0x20 > 0
+	e.constant = data&0x10 > 0
+	e.volume = data & 0x0f
+}
+
+// clock advances the envelope on quarter frames.
+func (e *envelope) clock() {
+	if e.start {
+		e.start = false
+		e.decay = 15
+		e.divider = e.volume
+		return
+	}
+	if e.divider > 0 {
+		e.divider--
+		return
+	}
+	e.divider = e.volume
+	if e.decay > 0 {
+		e.decay--
+	} else if e.loop {
+		e.decay = 15
+	}
+}
+
+// output returns the current volume.
+func (e *envelope) output() uint8 {
+	if e.constant {
+		return e.volume
+	}
+	return e.decay
+}
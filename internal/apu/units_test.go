@@ -0,0 +1,121 @@
+package apu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLengthCounter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		enabled       bool
+		halt          bool
+		index         uint8
+		clocks        int
+		expectedCount uint8
+	}{
+		{
+			name:          "loaded from the length table",
+			enabled:       true,
+			index:         0x01,
+			expectedCount: 254,
+		},
+		{
+			name:          "not loaded while disabled",
+			index:         0x01,
+			expectedCount: 0,
+		},
+		{
+			name:          "counts down on half frames",
+			enabled:       true,
+			index:         0x03,
+			clocks:        1,
+			expectedCount: 1,
+		},
+		{
+			name:          "stops at 0",
+			enabled:       true,
+			index:         0x03,
+			clocks:        3,
+			expectedCount: 0,
+		},
+		{
+			name:          "halted",
+			enabled:       true,
+			halt:          true,
+			index:         0x03,
+			clocks:        3,
+			expectedCount: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := lengthCounter{halt: tc.halt}
+			l.setEnabled(tc.enabled)
+			l.load(tc.index)
+			for i := 0; i < tc.clocks; i++ {
+				l.clock()
+			}
+
+			assert.Equal(t, tc.expectedCount, l.count)
+		})
+	}
+
+	t.Run("cleared when disabled", func(t *testing.T) {
+		l := lengthCounter{}
+		l.setEnabled(true)
+		l.load(0x01)
+		l.setEnabled(false)
+
+		assert.Equal(t, uint8(0), l.count)
+	})
+}
+
+func TestEnvelope(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     uint8
+		clocks   int
+		expected []uint8
+	}{
+		{
+			name:     "constant volume",
+			data:     0x17,
+			clocks:   4,
+			expected: []uint8{7, 7, 7, 7},
+		},
+		{
+			name:     "decays once per quarter frame at period 0",
+			data:     0x00,
+			clocks:   18,
+			expected: []uint8{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0, 0, 0},
+		},
+		{
+			name:     "decays every period+1 quarter frames",
+			data:     0x02,
+			clocks:   7,
+			expected: []uint8{15, 15, 15, 14, 14, 14, 13},
+		},
+		{
+			name:     "loops",
+			data:     0x20,
+			clocks:   18,
+			expected: []uint8{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0, 15, 14},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := envelope{start: true}
+			e.write(tc.data)
+
+			var actual []uint8
+			for i := 0; i < tc.clocks; i++ {
+				e.clock()
+				actual = append(actual, e.output())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
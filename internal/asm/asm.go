@@ -0,0 +1,479 @@
+package asm
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+)
+
+// Error is an error in the source being assembled, at a line of a file.
+type Error struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// aliases map the other names assemblers give instructions to the names in
+// the opcode table.
+var aliases = map[string]string{
+	"JAM": "KIL",
+	"ANE": "XAA",
+	"ISB": "ISC",
+	"SBX": "AXS",
+	"ASR": "ALR",
+	"AHX": "SHA",
+}
+
+// opcodeKey is an instruction and address mode, which select an opcode.
+type opcodeKey struct {
+	mnemonic string
+	mode     cpu.AddressMode
+}
+
+// Assembler assembles 6502 source for a CPU Variant, using the opcode table the
+// Variant runs and disassembles with.
+type Assembler struct {
+	opcodes map[opcodeKey]byte
+	modes   map[string]map[cpu.AddressMode]bool
+}
+
+// New constructs and returns a pointer to an Assembler for a Variant. Where
+// the opcode table has more than one opcode for an instruction, the documented
+// one is assembled, or else the lowest.
+func New(variant cpu.Variant) *Assembler {
+	a := &Assembler{
+		opcodes: make(map[opcodeKey]byte),
+		modes:   make(map[string]map[cpu.AddressMode]bool),
+	}
+	opcodes := cpu.Opcodes(variant)
+	for i, opcode := range opcodes {
+		k := opcodeKey{opcode.Mnemonic, opcode.Mode}
+		if current, ok := a.opcodes[k]; ok && (opcodes[current].Official || !opcode.Official) {
+			continue
+		}
+		a.opcodes[k] = byte(i)
+		if a.modes[opcode.Mnemonic] == nil {
+			a.modes[opcode.Mnemonic] = make(map[cpu.AddressMode]bool)
+		}
+		a.modes[opcode.Mnemonic][opcode.Mode] = true
+	}
+	return a
+}
+
+// AssembleFile assembles the source file at a path.
+func (a *Assembler) AssembleFile(path string) (*Program, error) {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return a.Assemble(path, source)
+}
+
+// Assemble assembles source, named file in errors and the base of relative
+// .include paths.
+//
+// Each line holds an optional label followed by an instruction, a directive or
+// an equate, as in
+//
+//	        .org $8000
+//	reset:  ldx #0
+//	@loop:  lda message,x
+//	        beq @done
+//	        sta $2007
+//	        inx
+//	        bne @loop
+//	@done:  jmp @done
+//	message: .byte "HELLO", 0
+//	        .org $fffa
+//	        .word reset, reset, reset
+//
+// Labels starting with '@' are local to the label before them. Instructions
+// take operands in the syntax of ca65, with "a:" forcing an absolute address
+// and "z:" a zero page one; otherwise an address that is known to fit is
+// assembled as zero page. Expressions are made of numbers, symbols, '*' for
+// the address of the statement, parentheses and the operators + - * / & | ^
+// << >>, with unary - ~, '<' for the low byte and '>' for the high byte.
+//
+// The directives are .org address, .byte values, .word values, .res count[,
+// fill] and .include "file"; symbols are assigned with name = value.
+func (a *Assembler) Assemble(file string, source []byte) (*Program, error) {
+	p := &parser{}
+	if err := p.parseFile(file, source); err != nil {
+		return nil, err
+	}
+
+	s := &assembly{
+		Assembler:  a,
+		statements: p.statements,
+		chosen:     make([]cpu.AddressMode, len(p.statements)),
+		symbols:    make(map[string]int),
+	}
+	if err := s.pass(1); err != nil {
+		return nil, err
+	}
+	if err := s.pass(2); err != nil {
+		return nil, err
+	}
+	return &Program{Segments: s.segments, Symbols: s.symbols}, nil
+}
+
+// assembly holds the state of one assembly, which takes two passes over the
+// statements. The first defines the labels and decides the size of each
+// instruction, and the second encodes them.
+type assembly struct {
+	*Assembler
+	statements []statement
+	chosen     []cpu.AddressMode // chosen are the address modes of the instructions, chosen by the first pass.
+	symbols    map[string]int
+	segments   []Segment
+
+	pass2    bool
+	pc       int
+	org      bool // org is whether the program counter has been set.
+	segment  int  // segment is the index of the segment being written, or -1.
+	current  statement
+	currentI int
+}
+
+// errorf returns an Error at the current statement.
+func (s *assembly) errorf(format string, args ...interface{}) error {
+	return errorf(s.current.file, s.current.line, format, args...)
+}
+
+// eval evaluates an expression at the current statement. In the first pass
+// symbols may not be defined yet, which is only an error if required is true.
+func (s *assembly) eval(e expr, required bool) (int, bool, error) {
+	value, err := eval(e, s.symbols, s.pc)
+	var undefined *undefinedError
+	if errors.As(err, &undefined) && !s.pass2 && !required {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, s.errorf("%v", err)
+	}
+	return value, true, nil
+}
+
+// define defines a symbol, which may only be given one value.
+func (s *assembly) define(name string, value int) error {
+	if current, ok := s.symbols[name]; ok && (!s.pass2 || current != value) {
+		return s.errorf("symbol %q is already defined", name)
+	}
+	s.symbols[name] = value
+	return nil
+}
+
+// pass runs a pass over the statements.
+func (s *assembly) pass(n int) error {
+	s.pass2 = n == 2
+	s.pc, s.org, s.segment = 0, false, -1
+	for i, st := range s.statements {
+		s.current, s.currentI = st, i
+		if st.label != "" {
+			if err := s.needOrg(); err != nil {
+				return err
+			}
+			if err := s.define(st.label, s.pc); err != nil {
+				return err
+			}
+		}
+		var err error
+		switch {
+		case st.equate != "":
+			err = s.equate(st)
+		case st.directive != "":
+			err = s.directive(st)
+		case st.mnemonic != "":
+			err = s.instruction(st)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needOrg returns an error if the program counter has not been set by .org.
+func (s *assembly) needOrg() error {
+	if !s.org {
+		return s.errorf("no .org before the first label or statement")
+	}
+	return nil
+}
+
+// equate assigns a symbol. Values that refer to symbols defined later are
+// assigned in the second pass.
+func (s *assembly) equate(st statement) error {
+	value, ok, err := s.eval(st.args[0], false)
+	if err != nil || !ok {
+		return err
+	}
+	return s.define(st.equate, value)
+}
+
+// emit writes bytes at the program counter in the second pass, and advances
+// the program counter by their number in both.
+func (s *assembly) emit(data ...byte) error {
+	if err := s.needOrg(); err != nil {
+		return err
+	}
+	if s.pc+len(data) > 0x10000 {
+		return s.errorf("program runs past $FFFF")
+	}
+	if s.pass2 {
+		if s.segment < 0 {
+			s.segments = append(s.segments, Segment{Origin: uint16(s.pc)})
+			s.segment = len(s.segments) - 1
+		}
+		s.segments[s.segment].Data = append(s.segments[s.segment].Data, data...)
+	}
+	s.pc += len(data)
+	return nil
+}
+
+// directive runs a directive.
+func (s *assembly) directive(st statement) error {
+	switch st.directive {
+	case ".org":
+		if len(st.args) != 1 {
+			return s.errorf(".org takes an address")
+		}
+		address, _, err := s.eval(st.args[0], true)
+		if err != nil {
+			return err
+		}
+		if address < 0 || address > 0xffff {
+			return s.errorf(".org address $%X is out of range", address)
+		}
+		s.pc, s.org, s.segment = address, true, -1
+	case ".byte", ".word":
+		if len(st.args) == 0 {
+			return s.errorf("%s takes at least one value", st.directive)
+		}
+		for _, arg := range st.args {
+			value, _, err := s.eval(arg, false)
+			if err != nil {
+				return err
+			}
+			var data []byte
+			if st.directive == ".byte" {
+				b, err := s.byteValue(value)
+				if err != nil {
+					return err
+				}
+				data = []byte{b}
+			} else {
+				w, err := s.wordValue(value)
+				if err != nil {
+					return err
+				}
+				data = []byte{byte(w), byte(w >> 8)}
+			}
+			if err := s.emit(data...); err != nil {
+				return err
+			}
+		}
+	case ".res":
+		if len(st.args) < 1 || len(st.args) > 2 {
+			return s.errorf(".res takes a count and an optional fill value")
+		}
+		count, _, err := s.eval(st.args[0], true)
+		if err != nil {
+			return err
+		}
+		if count < 0 {
+			return s.errorf(".res count %d is negative", count)
+		}
+		var fill byte
+		if len(st.args) == 2 {
+			value, _, err := s.eval(st.args[1], false)
+			if err != nil {
+				return err
+			}
+			if fill, err = s.byteValue(value); err != nil {
+				return err
+			}
+		}
+		data := make([]byte, count)
+		for i := range data {
+			data[i] = fill
+		}
+		return s.emit(data...)
+	default:
+		return s.errorf("unknown directive %q", st.directive)
+	}
+	return nil
+}
+
+// byteValue checks that a value fits in a byte, as either a signed or an
+// unsigned number. Values are not checked in the first pass, when they may not
+// be known.
+func (s *assembly) byteValue(value int) (byte, error) {
+	if s.pass2 && (value < -0x80 || value > 0xff) {
+		return 0, s.errorf("value $%X does not fit in a byte", value)
+	}
+	return byte(value), nil
+}
+
+// wordValue checks that a value fits in a word, as either a signed or an
+// unsigned number.
+func (s *assembly) wordValue(value int) (uint16, error) {
+	if s.pass2 && (value < -0x8000 || value > 0xffff) {
+		return 0, s.errorf("value $%X does not fit in a word", value)
+	}
+	return uint16(value), nil
+}
+
+// mode chooses the address mode of an instruction from the syntax of its
+// operand. It is called in the first pass, and the second uses its choice so
+// that instructions keep their size.
+func (s *assembly) mode(mnemonic string, op operand) (cpu.AddressMode, error) {
+	modes := s.modes[mnemonic]
+	choose := func(zeroPage cpu.AddressMode, absolute cpu.AddressMode) (cpu.AddressMode, error) {
+		switch {
+		case op.force == "z" && modes[zeroPage]:
+			return zeroPage, nil
+		case op.force == "a" && modes[absolute]:
+			return absolute, nil
+		case op.force == "" && modes[zeroPage] && !modes[absolute]:
+			return zeroPage, nil
+		case op.force == "" && modes[absolute] && !modes[zeroPage]:
+			return absolute, nil
+		case op.force == "" && modes[zeroPage]:
+			value, ok, err := s.eval(op.value, false)
+			if err != nil {
+				return "", err
+			}
+			if ok && value >= 0 && value <= 0xff {
+				return zeroPage, nil
+			}
+			return absolute, nil
+		}
+		return "", s.errorf("%s does not take this operand", mnemonic)
+	}
+
+	var mode cpu.AddressMode
+	switch op.syntax {
+	case syntaxNone, syntaxAccumulator:
+		mode = cpu.Implied
+	case syntaxImmediate:
+		mode = cpu.Immediate
+	case syntaxDirect:
+		if modes[cpu.Relative] {
+			mode = cpu.Relative
+		} else {
+			return choose(cpu.ZeroPage, cpu.Absolute)
+		}
+	case syntaxDirectX:
+		return choose(cpu.ZeroPageX, cpu.AbsoluteX)
+	case syntaxDirectY:
+		return choose(cpu.ZeroPageY, cpu.AbsoluteY)
+	case syntaxIndirect:
+		return choose(cpu.ZeroPageIndirect, cpu.Indirect)
+	case syntaxIndirectX:
+		return choose(cpu.IndirectX, cpu.AbsoluteIndexedIndirect)
+	case syntaxIndirectY:
+		mode = cpu.IndirectY
+	case syntaxBitBranch:
+		mode = cpu.ZeroPageRelative
+	}
+	if !modes[mode] {
+		return "", s.errorf("%s does not take this operand", mnemonic)
+	}
+	return mode, nil
+}
+
+// instruction assembles an instruction.
+func (s *assembly) instruction(st statement) error {
+	mnemonic := st.mnemonic
+	if alias, ok := aliases[mnemonic]; ok {
+		mnemonic = alias
+	}
+	if s.modes[mnemonic] == nil {
+		return s.errorf("unknown instruction %q", st.mnemonic)
+	}
+	if !s.pass2 {
+		mode, err := s.mode(mnemonic, st.operand)
+		if err != nil {
+			return err
+		}
+		s.chosen[s.currentI] = mode
+	}
+	mode := s.chosen[s.currentI]
+
+	data := []byte{s.opcodes[opcodeKey{mnemonic, mode}]}
+	var value int
+	if st.operand.value != nil {
+		var err error
+		if value, _, err = s.eval(st.operand.value, false); err != nil {
+			return err
+		}
+	}
+	switch mode {
+	case cpu.Implied:
+	case cpu.Immediate:
+		b, err := s.byteValue(value)
+		if err != nil {
+			return err
+		}
+		data = append(data, b)
+	case cpu.Relative:
+		offset, err := s.branchOffset(value, 2)
+		if err != nil {
+			return err
+		}
+		data = append(data, offset)
+	case cpu.ZeroPageRelative:
+		target, _, err := s.eval(st.operand.branch, false)
+		if err != nil {
+			return err
+		}
+		address, err := s.zeroPage(value)
+		if err != nil {
+			return err
+		}
+		offset, err := s.branchOffset(target, 3)
+		if err != nil {
+			return err
+		}
+		data = append(data, address, offset)
+	default:
+		if mode.Size() == 2 {
+			address, err := s.zeroPage(value)
+			if err != nil {
+				return err
+			}
+			data = append(data, address)
+		} else {
+			if s.pass2 && (value < 0 || value > 0xffff) {
+				return s.errorf("address $%X is out of range", value)
+			}
+			data = append(data, byte(value), byte(value>>8))
+		}
+	}
+	return s.emit(data...)
+}
+
+// zeroPage checks that an address is in page 0x00.
+func (s *assembly) zeroPage(address int) (byte, error) {
+	if s.pass2 && (address < 0 || address > 0xff) {
+		return 0, s.errorf("address $%X is not in zero page", address)
+	}
+	return byte(address), nil
+}
+
+// branchOffset returns the offset of a branch target from the end of a branch
+// instruction of the given size.
+func (s *assembly) branchOffset(target int, size int) (byte, error) {
+	offset := target - (s.pc + size)
+	if s.pass2 && (offset < -0x80 || offset > 0x7f) {
+		return 0, s.errorf("branch target $%X is out of range", target)
+	}
+	return byte(offset), nil
+}
@@ -0,0 +1,303 @@
+package asm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssembler_Assemble(t *testing.T) {
+	testCases := []struct {
+		name     string
+		variant  cpu.Variant
+		source   string
+		expected []Segment
+	}{
+		{
+			name: "address modes",
+			source: `
+				.org $8000
+				lsr a
+				lsr
+				lda #$10
+				lda $10
+				lda $10,x
+				ldx $10,y
+				lda $1234
+				lda $1234,x
+				lda $1234,y
+				lda ($10,x)
+				lda ($10),y
+				jmp ($FFFC)
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0x4a,
+				0x4a,
+				0xa9, 0x10,
+				0xa5, 0x10,
+				0xb5, 0x10,
+				0xb6, 0x10,
+				0xad, 0x34, 0x12,
+				0xbd, 0x34, 0x12,
+				0xb9, 0x34, 0x12,
+				0xa1, 0x10,
+				0xb1, 0x10,
+				0x6c, 0xfc, 0xff,
+			}}},
+		},
+		{
+			name: "forced address sizes",
+			source: `
+				.org $8000
+				lda a:$10
+				sta z:zp
+				lda $10,y
+				zp = $20
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0xad, 0x10, 0x00,
+				0x85, 0x20,
+				0xb9, 0x10, 0x00,
+			}}},
+		},
+		{
+			name: "forward references are absolute",
+			source: `
+				.org $8000
+				lda later
+				later = $10
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{0xad, 0x10, 0x00}}},
+		},
+		{
+			name: "branches and local labels",
+			source: `
+				.org $8000
+			first:
+			@loop:	dex
+					bne @loop
+					beq @end
+			@end:	rts
+			second:
+			@loop:	bne @loop
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0xca,
+				0xd0, 0xfd,
+				0xf0, 0x00,
+				0x60,
+				0xd0, 0xfe,
+			}}},
+		},
+		{
+			name: "expressions",
+			source: `
+				.org $8000
+				table = $1234
+				lda #<table
+				ldx #>table
+				ldy #(1 + 2) * 3
+				.word table + 2 * 2, -1, * - 2
+				.byte %1010 | $f0, 'A', 10 / 3, 1 << 4, ~0 & $7f
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0xa9, 0x34,
+				0xa2, 0x12,
+				0xa0, 0x09,
+				0x38, 0x12, 0xff, 0xff, 0x08, 0x80,
+				0xfa, 0x41, 0x03, 0x10, 0x7f,
+			}}},
+		},
+		{
+			name: "directives",
+			source: `
+				.org $c000
+				.byte "OK", 0
+				.res 3
+				.res 2, $ea
+				.org $fffc
+				.word $c000
+			`,
+			expected: []Segment{
+				{Origin: 0xc000, Data: []byte{'O', 'K', 0x00, 0x00, 0x00, 0x00, 0xea, 0xea}},
+				{Origin: 0xfffc, Data: []byte{0x00, 0xc0}},
+			},
+		},
+		{
+			name: "unofficial instructions and aliases",
+			source: `
+				.org $8000
+				lax $10
+				jam
+				kil
+				isb $1234
+				sbc #$01
+				nop $10
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0xa7, 0x10,
+				0x02,
+				0x02,
+				0xef, 0x34, 0x12,
+				0xe9, 0x01,
+				0x04, 0x10,
+			}}},
+		},
+		{
+			name:    "65C02 address modes",
+			variant: cpu.WDC65C02,
+			source: `
+				.org $8000
+				lda ($10)
+				jmp ($1234,x)
+				jmp ($0010)
+				inc a
+				bra *
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0xb2, 0x10,
+				0x7c, 0x34, 0x12,
+				0x6c, 0x10, 0x00,
+				0x1a,
+				0x80, 0xfe,
+			}}},
+		},
+		{
+			name:    "Rockwell bit instructions",
+			variant: cpu.R65C02,
+			source: `
+				.org $8000
+			wait:	bbr7 $10, wait
+				smb0 $10
+			`,
+			expected: []Segment{{Origin: 0x8000, Data: []byte{
+				0x7f, 0x10, 0xfd,
+				0x87, 0x10,
+			}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.variant).Assemble("test.s", []byte(tc.source))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p.Segments)
+		})
+	}
+}
+
+func TestAssembler_Assemble_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        string
+		expectedError string
+	}{
+		{
+			name:          "no origin",
+			source:        "nop",
+			expectedError: "test.s:1: no .org before the first label or statement",
+		},
+		{
+			name:          "unknown instruction",
+			source:        ".org $8000\nfoo",
+			expectedError: `test.s:2: unknown instruction "FOO"`,
+		},
+		{
+			name:          "unknown directive",
+			source:        ".org $8000\n.foo",
+			expectedError: `test.s:2: unknown directive ".foo"`,
+		},
+		{
+			name:          "address mode not taken",
+			source:        ".org $8000\njsr #$10",
+			expectedError: "test.s:2: JSR does not take this operand",
+		},
+		{
+			name:          "65C02 address mode on the 2A03",
+			source:        ".org $8000\nlda ($10)",
+			expectedError: "test.s:2: LDA does not take this operand",
+		},
+		{
+			name:          "undefined symbol",
+			source:        ".org $8000\njmp nowhere",
+			expectedError: `test.s:2: undefined symbol "nowhere"`,
+		},
+		{
+			name:          "redefined label",
+			source:        ".org $8000\nstart: nop\nstart: nop",
+			expectedError: `test.s:3: symbol "start" is already defined`,
+		},
+		{
+			name:          "branch out of range",
+			source:        ".org $8000\nbne $8100",
+			expectedError: "test.s:2: branch target $8100 is out of range",
+		},
+		{
+			name:          "byte out of range",
+			source:        ".org $8000\n.byte 256",
+			expectedError: "test.s:2: value $100 does not fit in a byte",
+		},
+		{
+			name:          "forced zero page out of range",
+			source:        ".org $8000\nlda z:$1234",
+			expectedError: "test.s:2: address $1234 is not in zero page",
+		},
+		{
+			name:          "origin needs a known address",
+			source:        ".org later\nlater = $8000",
+			expectedError: `test.s:1: undefined symbol "later"`,
+		},
+		{
+			name:          "program past end of memory",
+			source:        ".org $ffff\n.word 0",
+			expectedError: "test.s:2: program runs past $FFFF",
+		},
+		{
+			name:          "invalid number",
+			source:        ".org $80g0",
+			expectedError: `test.s:1: invalid number "$80g0"`,
+		},
+		{
+			name:          "accumulator label",
+			source:        "a: nop",
+			expectedError: `test.s:1: "a" is the accumulator and cannot be a label`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(cpu.RP2A03).Assemble("test.s", []byte(tc.source))
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestAssembler_AssembleFile_Include(t *testing.T) {
+	dir, err := ioutil.TempDir("", "asm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "lib"), 0755))
+	files := map[string]string{
+		"main.s":       ".org $8000\n.include \"lib/util.s\"\njsr util\n",
+		"lib/util.s":   ".include \"consts.s\"\nutil: lda #VALUE\nrts\n",
+		"lib/consts.s": "VALUE = 7\n",
+		"loop.s":       ".include \"loop.s\"\n",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	p, err := New(cpu.RP2A03).AssembleFile(filepath.Join(dir, "main.s"))
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{{Origin: 0x8000, Data: []byte{0xa9, 0x07, 0x60, 0x20, 0x00, 0x80}}}, p.Segments)
+	assert.Equal(t, 0x8000, p.Symbols["util"])
+
+	_, err = New(cpu.RP2A03).AssembleFile(filepath.Join(dir, "loop.s"))
+	assert.EqualError(t, err, filepath.Join(dir, "loop.s")+":1: includes nested deeper than 16 files")
+}
@@ -0,0 +1,224 @@
+package asm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// expr is a parsed expression: a number, symbol, programCounter, unary or
+// binary.
+type expr interface{}
+
+// number is a literal value.
+type number int
+
+// symbol refers to a label or equate by its qualified name.
+type symbol string
+
+// programCounter refers to the address of the current statement, written '*'.
+type programCounter struct{}
+
+// unary applies an operator to one operand. Besides negation and complement,
+// '<' takes the low byte of a value and '>' the high byte.
+type unary struct {
+	op string
+	x  expr
+}
+
+// binary applies an operator to two operands.
+type binary struct {
+	op   string
+	x, y expr
+}
+
+// precedences are the binding strengths of the binary operators.
+var precedences = map[string]int{
+	"|":  1,
+	"^":  2,
+	"&":  3,
+	"<<": 4, ">>": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6,
+}
+
+// qualify returns the full name of a symbol. Local labels, which start with
+// '@', belong to the scope of the global label before them.
+func qualify(name string, scope string) string {
+	if strings.HasPrefix(name, "@") {
+		return scope + name
+	}
+	return name
+}
+
+// exprParser parses an expression from tokens by precedence climbing.
+type exprParser struct {
+	tokens []token
+	pos    int
+	scope  string
+}
+
+// parseExpr parses tokens that make up a whole expression.
+func parseExpr(tokens []token, scope string) (expr, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("missing expression")
+	}
+	p := &exprParser{tokens: tokens, scope: scope}
+	e, err := p.binary(1)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in expression", p.tokens[p.pos].text)
+	}
+	return e, nil
+}
+
+// peek returns the next token, or false at the end.
+func (p *exprParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// binary parses binary operations of at least the given precedence.
+func (p *exprParser) binary(precedence int) (expr, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokenPunct {
+			return x, nil
+		}
+		opPrecedence, ok := precedences[t.text]
+		if !ok || opPrecedence < precedence {
+			return x, nil
+		}
+		p.pos++
+		y, err := p.binary(opPrecedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op: t.text, x: x, y: y}
+	}
+}
+
+// unary parses a unary operation or a primary expression.
+func (p *exprParser) unary() (expr, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of expression")
+	}
+	switch {
+	case t.is("-"), t.is("~"), t.is("<"), t.is(">"), t.is("+"):
+		p.pos++
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: t.text, x: x}, nil
+	case t.is("*"):
+		p.pos++
+		return programCounter{}, nil
+	case t.is("("):
+		p.pos++
+		x, err := p.binary(1)
+		if err != nil {
+			return nil, err
+		}
+		if t, ok := p.peek(); !ok || !t.is(")") {
+			return nil, errors.New("missing ')' in expression")
+		}
+		p.pos++
+		return x, nil
+	case t.kind == tokenNumber:
+		p.pos++
+		return number(t.value), nil
+	case t.kind == tokenName && !strings.HasPrefix(t.text, "."):
+		p.pos++
+		return symbol(qualify(t.text, p.scope)), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q in expression", t.text)
+	}
+}
+
+// undefinedError is returned by eval when an expression refers to a symbol that
+// has not been defined.
+type undefinedError struct {
+	name string
+}
+
+func (e *undefinedError) Error() string {
+	return fmt.Sprintf("undefined symbol %q", e.name)
+}
+
+// eval evaluates an expression with the symbols defined so far and the address
+// of the current statement.
+func eval(e expr, symbols map[string]int, pc int) (int, error) {
+	switch e := e.(type) {
+	case number:
+		return int(e), nil
+	case symbol:
+		value, ok := symbols[string(e)]
+		if !ok {
+			return 0, &undefinedError{name: string(e)}
+		}
+		return value, nil
+	case programCounter:
+		return pc, nil
+	case unary:
+		x, err := eval(e.x, symbols, pc)
+		if err != nil {
+			return 0, err
+		}
+		switch e.op {
+		case "-":
+			return -x, nil
+		case "~":
+			return ^x, nil
+		case "<":
+			return x & 0xff, nil
+		case ">":
+			return x >> 8 & 0xff, nil
+		default:
+			return x, nil
+		}
+	case binary:
+		x, err := eval(e.x, symbols, pc)
+		if err != nil {
+			return 0, err
+		}
+		y, err := eval(e.y, symbols, pc)
+		if err != nil {
+			return 0, err
+		}
+		switch e.op {
+		case "|":
+			return x | y, nil
+		case "^":
+			return x ^ y, nil
+		case "&":
+			return x & y, nil
+		case "<<":
+			return x << uint(y), nil
+		case ">>":
+			return x >> uint(y), nil
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		default:
+			if y == 0 {
+				return 0, errors.New("division by zero")
+			}
+			return x / y, nil
+		}
+	default:
+		return 0, fmt.Errorf("invalid expression %v", e)
+	}
+}
@@ -0,0 +1,75 @@
+package asm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	symbols := map[string]int{"table": 0x1234, "main@loop": 0x8010}
+	testCases := []struct {
+		source   string
+		expected int
+	}{
+		{source: "42", expected: 42},
+		{source: "$ff", expected: 0xff},
+		{source: "%101", expected: 5},
+		{source: "'A'", expected: 0x41},
+		{source: "*", expected: 0x8000},
+		{source: "table", expected: 0x1234},
+		{source: "@loop", expected: 0x8010},
+		{source: "<table", expected: 0x34},
+		{source: ">table", expected: 0x12},
+		{source: "-1", expected: -1},
+		{source: "~0", expected: -1},
+		{source: "1 + 2 * 3", expected: 7},
+		{source: "(1 + 2) * 3", expected: 9},
+		{source: "10 - 2 - 3", expected: 5},
+		{source: "100 / 10 / 5", expected: 2},
+		{source: "1 << 4 | 1", expected: 0x11},
+		{source: "$ff & $0f ^ $01", expected: 0x0e},
+		{source: "* - 2", expected: 0x7ffe},
+		{source: "2 * * ", expected: 0x10000},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.source, func(t *testing.T) {
+			tokens, err := lex(tc.source)
+			require.NoError(t, err)
+			e, err := parseExpr(tokens, "main")
+			require.NoError(t, err)
+
+			value, err := eval(e, symbols, 0x8000)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	testCases := []struct {
+		source        string
+		expectedError string
+	}{
+		{source: "1 +", expectedError: "unexpected end of expression"},
+		{source: "(1 + 2", expectedError: "missing ')' in expression"},
+		{source: "1 2", expectedError: `unexpected "2" in expression`},
+		{source: "nowhere", expectedError: `undefined symbol "nowhere"`},
+		{source: "1 / 0", expectedError: "division by zero"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.source, func(t *testing.T) {
+			tokens, err := lex(tc.source)
+			require.NoError(t, err)
+
+			e, err := parseExpr(tokens, "")
+			if err == nil {
+				_, err = eval(e, map[string]int{}, 0)
+			}
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
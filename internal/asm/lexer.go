@@ -0,0 +1,109 @@
+package asm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenKind is the kind of a token of a source line.
+type tokenKind uint8
+
+const (
+	tokenName   tokenKind = iota // tokenName is a label, symbol, mnemonic or directive.
+	tokenNumber                  // tokenNumber is a number or character literal.
+	tokenString                  // tokenString is a string literal, without its quotes.
+	tokenPunct                   // tokenPunct is an operator or punctuation.
+)
+
+// token is a token of a source line.
+type token struct {
+	kind  tokenKind
+	text  string
+	value int
+}
+
+// is returns whether the token is the punctuation given.
+func (t token) is(punct string) bool {
+	return t.kind == tokenPunct && t.text == punct
+}
+
+// isName returns whether the token is a name, ignoring case.
+func (t token) isName(name string) bool {
+	return t.kind == tokenName && strings.EqualFold(t.text, name)
+}
+
+// isNameStart returns whether c can start a name. Directives start with '.'
+// and local labels with '@'.
+func isNameStart(c byte) bool {
+	return c == '_' || c == '.' || c == '@' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isNamePart returns whether c can be part of a name after its first
+// character.
+func isNamePart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// lex splits a source line into tokens, dropping any comment. Numbers are
+// written in decimal, in hexadecimal after '$', in binary after '%' or as a
+// character in single quotes.
+func lex(line string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == ';':
+			return tokens, nil
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case isNameStart(c):
+			j := i + 1
+			for j < len(line) && isNamePart(line[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenName, text: line[i:j]})
+			i = j
+		case c >= '0' && c <= '9', c == '$', c == '%':
+			base, start := 10, i
+			switch c {
+			case '$':
+				base, start = 16, i+1
+			case '%':
+				base, start = 2, i+1
+			}
+			j := start
+			for j < len(line) && isNamePart(line[j]) {
+				j++
+			}
+			value, err := strconv.ParseInt(line[start:j], base, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", line[i:j])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: line[i:j], value: int(value)})
+			i = j
+		case c == '\'':
+			if i+2 >= len(line) || line[i+2] != '\'' {
+				return nil, fmt.Errorf("invalid character literal %q", line[i:])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: line[i : i+3], value: int(line[i+1])})
+			i += 3
+		case c == '"':
+			end := strings.IndexByte(line[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string %q", line[i:])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: line[i+1 : i+1+end]})
+			i += end + 2
+		case strings.HasPrefix(line[i:], "<<"), strings.HasPrefix(line[i:], ">>"):
+			tokens = append(tokens, token{kind: tokenPunct, text: line[i : i+2]})
+			i += 2
+		case strings.IndexByte("+-*/&|^~<>()#,:=", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunct, text: line[i : i+1]})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,292 @@
+package asm
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// maxIncludeDepth is the deepest .include nesting allowed, which stops a file
+// from including itself forever.
+const maxIncludeDepth = 16
+
+// syntax is the way an operand is written, which with the instruction decides
+// its address mode.
+type syntax uint8
+
+const (
+	syntaxNone        syntax = iota // e.g. "rts"
+	syntaxAccumulator               // e.g. "lsr a"
+	syntaxImmediate                 // e.g. "lda #$10"
+	syntaxDirect                    // e.g. "lda $10", "jmp label" or "bne label"
+	syntaxDirectX                   // e.g. "lda $10,x"
+	syntaxDirectY                   // e.g. "lda $10,y"
+	syntaxIndirect                  // e.g. "jmp ($FFFC)"
+	syntaxIndirectX                 // e.g. "lda ($10,x)"
+	syntaxIndirectY                 // e.g. "lda ($10),y"
+	syntaxBitBranch                 // e.g. "bbr0 $10,label"
+)
+
+// operand is the parsed operand of an instruction.
+type operand struct {
+	syntax syntax
+	value  expr
+	branch expr   // branch is the target of a syntaxBitBranch operand.
+	force  string // force is "a" to force an absolute address, or "z" for zero page.
+}
+
+// statement is a parsed line of source.
+type statement struct {
+	file string
+	line int
+
+	label string // label is the qualified name of a label defined on the line.
+
+	equate string // equate is the qualified name of a symbol assigned the first argument.
+
+	directive string // directive is the lowercase name of a directive, e.g. ".byte".
+	args      []expr
+
+	mnemonic string // mnemonic is the uppercase name of an instruction.
+	operand  operand
+}
+
+// parser parses source into statements, following .include directives.
+type parser struct {
+	statements []statement
+	scope      string
+	depth      int
+}
+
+// errorf returns an Error at a line of a file.
+func errorf(file string, line int, format string, args ...interface{}) error {
+	return &Error{File: file, Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseFile parses the lines of a file.
+func (p *parser) parseFile(file string, source []byte) error {
+	for i, text := range strings.Split(string(source), "\n") {
+		st, include, err := p.parseLine(text)
+		if err != nil {
+			return errorf(file, i+1, "%v", err)
+		}
+		st.file, st.line = file, i+1
+		if include == "" {
+			p.statements = append(p.statements, st)
+			continue
+		}
+
+		if p.depth == maxIncludeDepth {
+			return errorf(file, i+1, "includes nested deeper than %d files", maxIncludeDepth)
+		}
+		path := include
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errorf(file, i+1, "%v", err)
+		}
+		// a label on the include line is defined before the included code
+		p.statements = append(p.statements, st)
+		p.depth++
+		err = p.parseFile(path, data)
+		p.depth--
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseLine parses a line of source, returning the path of the file to
+// include if it is an .include directive.
+func (p *parser) parseLine(text string) (statement, string, error) {
+	var st statement
+	tokens, err := lex(text)
+	if err != nil {
+		return st, "", err
+	}
+
+	if len(tokens) >= 2 && tokens[0].kind == tokenName && tokens[1].is(":") && !strings.HasPrefix(tokens[0].text, ".") {
+		name := tokens[0].text
+		if strings.EqualFold(name, "a") {
+			return st, "", errors.New(`"a" is the accumulator and cannot be a label`)
+		}
+		if !strings.HasPrefix(name, "@") {
+			p.scope = name
+		}
+		st.label = qualify(name, p.scope)
+		tokens = tokens[2:]
+	}
+	if len(tokens) == 0 {
+		return st, "", nil
+	}
+
+	first := tokens[0]
+	switch {
+	case first.kind != tokenName:
+		return st, "", fmt.Errorf("unexpected %q", first.text)
+	case len(tokens) >= 2 && tokens[1].is("="):
+		st.equate = qualify(first.text, p.scope)
+		value, err := parseExpr(tokens[2:], p.scope)
+		if err != nil {
+			return st, "", err
+		}
+		st.args = []expr{value}
+	case strings.HasPrefix(first.text, "."):
+		if strings.EqualFold(first.text, ".include") {
+			if len(tokens) != 2 || tokens[1].kind != tokenString {
+				return st, "", errors.New(`.include takes a file name in double quotes`)
+			}
+			return st, tokens[1].text, nil
+		}
+		st.directive = strings.ToLower(first.text)
+		st.args, err = p.parseArgs(st.directive, tokens[1:])
+		if err != nil {
+			return st, "", err
+		}
+	default:
+		st.mnemonic = strings.ToUpper(first.text)
+		st.operand, err = p.parseOperand(tokens[1:])
+		if err != nil {
+			return st, "", err
+		}
+	}
+	return st, "", nil
+}
+
+// parseArgs parses the comma separated arguments of a directive. Strings are
+// allowed in .byte, where each character is a byte.
+func (p *parser) parseArgs(directive string, tokens []token) ([]expr, error) {
+	var args []expr
+	for _, arg := range splitArgs(tokens) {
+		if len(arg) == 1 && arg[0].kind == tokenString {
+			if directive != ".byte" {
+				return nil, fmt.Errorf("%s does not take strings", directive)
+			}
+			for _, c := range []byte(arg[0].text) {
+				args = append(args, number(c))
+			}
+			continue
+		}
+		value, err := parseExpr(arg, p.scope)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, value)
+	}
+	return args, nil
+}
+
+// splitArgs splits tokens at the commas outside of parentheses.
+func splitArgs(tokens []token) [][]token {
+	if len(tokens) == 0 {
+		return nil
+	}
+	var args [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case t.is(",") && depth == 0:
+			args = append(args, tokens[start:i])
+			start = i + 1
+		}
+	}
+	return append(args, tokens[start:])
+}
+
+// isRegister returns whether tokens are just the name of an index register.
+func isRegister(tokens []token, register string) bool {
+	return len(tokens) == 1 && tokens[0].isName(register)
+}
+
+// parseOperand parses the operand of an instruction.
+func (p *parser) parseOperand(tokens []token) (operand, error) {
+	var op operand
+	switch {
+	case len(tokens) == 0:
+		op.syntax = syntaxNone
+		return op, nil
+	case isRegister(tokens, "a"):
+		op.syntax = syntaxAccumulator
+		return op, nil
+	case tokens[0].is("#"):
+		value, err := parseExpr(tokens[1:], p.scope)
+		op.syntax, op.value = syntaxImmediate, value
+		return op, err
+	}
+
+	if len(tokens) >= 2 && (tokens[0].isName("a") || tokens[0].isName("z")) && tokens[1].is(":") {
+		op.force = strings.ToLower(tokens[0].text)
+		tokens = tokens[2:]
+	}
+
+	if len(tokens) > 0 && tokens[0].is("(") {
+		closing := matchingParen(tokens)
+		inner := splitArgs(tokens[1:closing])
+		rest := tokens[closing+1:]
+		switch {
+		case closing == len(tokens)-1 && len(inner) == 2 && isRegister(inner[1], "x"):
+			op.syntax = syntaxIndirectX
+			tokens = inner[0]
+		case closing == len(tokens)-1 && len(inner) == 1:
+			op.syntax = syntaxIndirect
+			tokens = inner[0]
+		case len(rest) == 2 && rest[0].is(",") && isRegister(rest[1:], "y") && len(inner) == 1:
+			op.syntax = syntaxIndirectY
+			tokens = inner[0]
+		}
+		if op.syntax != syntaxNone {
+			value, err := parseExpr(tokens, p.scope)
+			op.value = value
+			return op, err
+		}
+	}
+
+	args := splitArgs(tokens)
+	switch {
+	case len(args) == 1:
+		op.syntax = syntaxDirect
+	case len(args) == 2 && isRegister(args[1], "x"):
+		op.syntax = syntaxDirectX
+	case len(args) == 2 && isRegister(args[1], "y"):
+		op.syntax = syntaxDirectY
+	case len(args) == 2:
+		op.syntax = syntaxBitBranch
+		branch, err := parseExpr(args[1], p.scope)
+		if err != nil {
+			return op, err
+		}
+		op.branch = branch
+	default:
+		return op, errors.New("too many operands")
+	}
+	value, err := parseExpr(args[0], p.scope)
+	op.value = value
+	return op, err
+}
+
+// matchingParen returns the index of the parenthesis closing the one that
+// starts tokens, or the last index if it is not closed.
+func matchingParen(tokens []token) int {
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
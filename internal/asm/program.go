@@ -0,0 +1,74 @@
+package asm
+
+import (
+	"fmt"
+)
+
+// Segment is a block of assembled bytes, started by an .org directive.
+type Segment struct {
+	Origin uint16
+	Data   []byte
+}
+
+// Program is an assembled program.
+type Program struct {
+	Segments []Segment      // Segments are the blocks of bytes assembled, in source order.
+	Symbols  map[string]int // Symbols are the values of the labels and equates, by qualified name.
+}
+
+// Bytes returns the program as one block from its lowest address to its
+// highest, with the gaps between segments filled with fill. Where segments
+// overlap, the later one is kept.
+func (p *Program) Bytes(fill byte) (uint16, []byte) {
+	if len(p.Segments) == 0 {
+		return 0, nil
+	}
+	start, end := 0x10000, 0
+	for _, s := range p.Segments {
+		if int(s.Origin) < start {
+			start = int(s.Origin)
+		}
+		if int(s.Origin)+len(s.Data) > end {
+			end = int(s.Origin) + len(s.Data)
+		}
+	}
+	data := make([]byte, end-start)
+	for i := range data {
+		data[i] = fill
+	}
+	for _, s := range p.Segments {
+		copy(data[int(s.Origin)-start:], s.Data)
+	}
+	return uint16(start), data
+}
+
+// prgBankSize is the size of an iNES PRG-ROM bank.
+const prgBankSize = 0x4000
+
+// INES returns the program as an iNES image of an NROM cartridge with CHR-RAM.
+// The program must lie in $8000-$FFFF, and fills 16KB of PRG-ROM at $C000 if
+// it lies wholly in $C000-$FFFF, or else 32KB at $8000. Unused bytes are $FF.
+func (p *Program) INES() ([]byte, error) {
+	start := 0xc000
+	for _, s := range p.Segments {
+		if s.Origin < 0x8000 {
+			return nil, fmt.Errorf("asm: segment at $%04X is outside of PRG-ROM at $8000-$FFFF", s.Origin)
+		}
+		if s.Origin < 0xc000 {
+			start = 0x8000
+		}
+	}
+
+	banks := (0x10000 - start) / prgBankSize
+	image := make([]byte, 16+banks*prgBankSize)
+	copy(image, "NES\x1a")
+	image[4] = byte(banks)
+	prg := image[16:]
+	for i := range prg {
+		prg[i] = 0xff
+	}
+	for _, s := range p.Segments {
+		copy(prg[int(s.Origin)-start:], s.Data)
+	}
+	return image, nil
+}
@@ -0,0 +1,70 @@
+package asm
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgram_Bytes(t *testing.T) {
+	p := &Program{Segments: []Segment{
+		{Origin: 0x8004, Data: []byte{0x04, 0x05}},
+		{Origin: 0x8000, Data: []byte{0x00, 0x01}},
+		{Origin: 0x8001, Data: []byte{0x11}},
+	}}
+
+	origin, data := p.Bytes(0xff)
+
+	assert.Equal(t, uint16(0x8000), origin)
+	assert.Equal(t, []byte{0x00, 0x11, 0xff, 0xff, 0x04, 0x05}, data)
+}
+
+func TestProgram_INES(t *testing.T) {
+	testCases := []struct {
+		name             string
+		segments         []Segment
+		expectedPRGBanks uint8
+	}{
+		{
+			name: "16KB at $C000",
+			segments: []Segment{
+				{Origin: 0xc000, Data: []byte{0xa9, 0x01}},
+				{Origin: 0xfffc, Data: []byte{0x00, 0xc0}},
+			},
+			expectedPRGBanks: 1,
+		},
+		{
+			name: "32KB at $8000",
+			segments: []Segment{
+				{Origin: 0x8000, Data: []byte{0xa9, 0x01}},
+				{Origin: 0xfffc, Data: []byte{0x00, 0x80}},
+			},
+			expectedPRGBanks: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			image, err := (&Program{Segments: tc.segments}).INES()
+			require.NoError(t, err)
+
+			c, err := cartridge.New(image)
+			require.NoError(t, err)
+
+			assert.Equal(t, int(tc.expectedPRGBanks)*0x4000, c.Header.PRGROMSize)
+			for _, s := range tc.segments {
+				for i, b := range s.Data {
+					assert.Equal(t, b, c.Peek(s.Origin+uint16(i)), "incorrect byte at $%04X", s.Origin+uint16(i))
+				}
+			}
+			assert.Equal(t, uint8(0xff), c.Peek(0xf000), "unused byte not filled")
+		})
+	}
+
+	t.Run("outside PRG-ROM", func(t *testing.T) {
+		_, err := (&Program{Segments: []Segment{{Origin: 0x6000, Data: []byte{0x00}}}}).INES()
+
+		assert.EqualError(t, err, "asm: segment at $6000 is outside of PRG-ROM at $8000-$FFFF")
+	})
+}
@@ -0,0 +1,107 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+	"github.com/stretchr/testify/assert"
+)
+
+// variants are the CPU variants round-tripped.
+var variants = []cpu.Variant{cpu.RP2A03, cpu.NMOS6502, cpu.WDC65C02, cpu.R65C02}
+
+// roundTripper assembles and disassembles programs at $8000 for a Variant.
+type roundTripper struct {
+	a *Assembler
+	b *bus.Bus
+	d *cpu.Disassembler
+}
+
+// newRoundTripper constructs and returns a pointer to a roundTripper.
+func newRoundTripper(variant cpu.Variant) *roundTripper {
+	b := bus.NewBus(bus.RAM{})
+	return &roundTripper{a: New(variant), b: b, d: cpu.NewDisassembler(b, variant)}
+}
+
+// disassemble disassembles bytes in ca65 syntax.
+func (r *roundTripper) disassemble(data []byte) []string {
+	for i := 0; i < 0x200; i++ {
+		r.b.Write(0x8000+uint16(i), 0x00)
+	}
+	for i, d := range data {
+		r.b.Write(0x8000+uint16(i), d)
+	}
+	var lines []string
+	for _, in := range r.d.Disassemble(0x8000, 0x8000+uint16(len(data))) {
+		lines = append(lines, cpu.CA65Formatter{}.Format(in))
+	}
+	return lines
+}
+
+// assemble assembles lines of source.
+func (r *roundTripper) assemble(lines []string) ([]byte, error) {
+	p, err := r.a.Assemble("roundtrip.s", []byte(".org $8000\n"+strings.Join(lines, "\n")))
+	if err != nil {
+		return nil, err
+	}
+	_, data := p.Bytes(0x00)
+	return data, nil
+}
+
+func TestRoundTrip_Instructions(t *testing.T) {
+	for _, variant := range variants {
+		t.Run(fmt.Sprintf("variant %d", variant), func(t *testing.T) {
+			r := newRoundTripper(variant)
+			for i, opcode := range cpu.Opcodes(variant) {
+				canonical := r.a.opcodes[opcodeKey{opcode.Mnemonic, opcode.Mode}] == byte(i)
+
+				// the disassembly of any instruction assembles back to an
+				// instruction with the same disassembly, and to the same bytes
+				// unless another opcode is preferred for it
+				property := func(low byte, high byte) bool {
+					data := []byte{byte(i), low, high}[:opcode.Mode.Size()]
+					text := r.disassemble(data)
+					assembled, err := r.assemble(text)
+					if err != nil {
+						t.Logf("%q: %v", text, err)
+						return false
+					}
+					if canonical && !assert.Equal(t, data, assembled, "%q", text) {
+						return false
+					}
+					return assert.Equal(t, text, r.disassemble(assembled))
+				}
+				if err := quick.Check(property, &quick.Config{MaxCount: 16}); err != nil {
+					t.Errorf("opcode $%02X: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTrip_Programs(t *testing.T) {
+	for _, variant := range variants {
+		t.Run(fmt.Sprintf("variant %d", variant), func(t *testing.T) {
+			r := newRoundTripper(variant)
+
+			// the disassembly of any block of bytes assembles back to a
+			// program with the same disassembly
+			property := func(data []byte) bool {
+				text := r.disassemble(data)
+				assembled, err := r.assemble(text)
+				if err != nil {
+					t.Logf("%q: %v", text, err)
+					return false
+				}
+				return assert.Equal(t, text, r.disassemble(assembled))
+			}
+			if err := quick.Check(property, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
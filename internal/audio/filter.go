@@ -0,0 +1,81 @@
+package audio
+
+import "math"
+
+// filter is a digital filter applied one sample at a time.
+type filter interface {
+	apply(x float64) float64
+}
+
+// highPass is a first-order high-pass filter.
+type highPass struct {
+	alpha   float64
+	prevIn  float64
+	prevOut float64
+}
+
+// newHighPass constructs a high-pass filter with a cutoff frequency for samples
+// at a sample rate, both in Hz.
+func newHighPass(cutoff float64, rate float64) *highPass {
+	rc := 1 / (2 * math.Pi * cutoff)
+	return &highPass{alpha: rc / (rc + 1/rate)}
+}
+
+func (f *highPass) apply(x float64) float64 {
+	f.prevOut = f.alpha * (f.prevOut + x - f.prevIn)
+	f.prevIn = x
+	return f.prevOut
+}
+
+// lowPass is a first-order low-pass filter.
+type lowPass struct {
+	alpha   float64
+	prevOut float64
+}
+
+// newLowPass constructs a low-pass filter with a cutoff frequency for samples
+// at a sample rate, both in Hz.
+func newLowPass(cutoff float64, rate float64) *lowPass {
+	rc := 1 / (2 * math.Pi * cutoff)
+	dt := 1 / rate
+	return &lowPass{alpha: dt / (rc + dt)}
+}
+
+func (f *lowPass) apply(x float64) float64 {
+	f.prevOut += f.alpha * (x - f.prevOut)
+	return f.prevOut
+}
+
+// newNESFilters returns the chain of filters between the 2A03 and the NES's
+// audio output: two high-pass filters at 90Hz and 440Hz, which remove the
+// APU's DC offset, and a low-pass filter at 14kHz.
+func newNESFilters(rate float64) []filter {
+	return []filter{
+		newHighPass(90, rate),
+		newHighPass(440, rate),
+		newLowPass(14000, rate),
+	}
+}
+
+// newLowPassFIR returns the coefficients of a Blackman windowed sinc low-pass
+// filter with n taps and a cutoff given as a fraction of the sample rate.
+func newLowPassFIR(n int, cutoff float64) []float64 {
+	taps := make([]float64, n)
+	m := float64(n - 1)
+	sum := 0.0
+	for i := range taps {
+		x := float64(i) - m/2
+		sinc := 2 * cutoff
+		if x != 0 {
+			sinc = math.Sin(2*math.Pi*cutoff*x) / (math.Pi * x)
+		}
+		window := 0.42 - 0.5*math.Cos(2*math.Pi*float64(i)/m) + 0.08*math.Cos(4*math.Pi*float64(i)/m)
+		taps[i] = sinc * window
+		sum += taps[i]
+	}
+	// normalize for unity gain at DC
+	for i := range taps {
+		taps[i] /= sum
+	}
+	return taps
+}
@@ -0,0 +1,105 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gain returns the gain of a filter for a sine wave at a frequency, sampled
+// at a rate, once the filter has settled.
+func gain(f filter, frequency float64, rate float64) float64 {
+	settle := int(rate / 10)
+	peak := 0.0
+	for i := 0; i < 2*settle; i++ {
+		y := f.apply(math.Sin(2 * math.Pi * frequency * float64(i) / rate))
+		if i >= settle {
+			peak = math.Max(peak, math.Abs(y))
+		}
+	}
+	return peak
+}
+
+func TestHighPass(t *testing.T) {
+	testCases := []struct {
+		name      string
+		frequency float64
+		expected  float64
+	}{
+		{
+			name:      "attenuates below the cutoff",
+			frequency: 44,
+			expected:  0.0995,
+		},
+		{
+			name:      "half power at the cutoff",
+			frequency: 440,
+			expected:  math.Sqrt(0.5),
+		},
+		{
+			name:      "passes above the cutoff",
+			frequency: 4400,
+			expected:  0.995,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := gain(newHighPass(440, 192000), tc.frequency, 192000)
+
+			assert.InDelta(t, tc.expected, actual, 0.02)
+		})
+	}
+
+	t.Run("removes DC", func(t *testing.T) {
+		f := newHighPass(90, 48000)
+		var y float64
+		for i := 0; i < 48000; i++ {
+			y = f.apply(1)
+		}
+
+		assert.InDelta(t, 0, y, 0.0001)
+	})
+}
+
+func TestLowPass(t *testing.T) {
+	testCases := []struct {
+		name      string
+		frequency float64
+		expected  float64
+	}{
+		{
+			name:      "passes below the cutoff",
+			frequency: 140,
+			expected:  1,
+		},
+		{
+			name:      "half power at the cutoff",
+			frequency: 1400,
+			expected:  math.Sqrt(0.5),
+		},
+		{
+			name:      "attenuates above the cutoff",
+			frequency: 14000,
+			expected:  0.1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := gain(newLowPass(1400, 192000), tc.frequency, 192000)
+
+			assert.InDelta(t, tc.expected, actual, 0.02)
+		})
+	}
+}
+
+func TestLowPassFIR(t *testing.T) {
+	taps := newLowPassFIR(firTaps, 0.1)
+
+	sum := 0.0
+	for i, c := range taps {
+		sum += c
+		assert.InDelta(t, c, taps[len(taps)-1-i], 1e-12, "not symmetric")
+	}
+	assert.InDelta(t, 1, sum, 1e-12, "incorrect DC gain")
+}
@@ -0,0 +1,95 @@
+package audio
+
+// Resampler parameters.
+const (
+	// oversampling is the ratio of the intermediate rate, at which the NES's
+	// filters are applied, to the output rate.
+	oversampling = 4
+	// firTaps is the length of the low-pass filter applied before decimating
+	// from the intermediate rate to the output rate.
+	firTaps = 64
+	// passband is the fraction of the output's Nyquist frequency left
+	// unattenuated by that filter.
+	passband = 0.9
+)
+
+// Resampler converts the APU's output from the CPU clock rate down to an audio
+// sample rate such as 44.1kHz or 48kHz, passing it through the NES's filters
+// on the way.
+//
+// The input is first averaged down to an intermediate rate a few times the
+// output rate, where the NES's filters are applied, then band-limited with a
+// windowed sinc filter below the output's Nyquist frequency before being
+// decimated, so that the APU's ultrasonic content doesn't alias.
+type Resampler struct {
+	step     float64 // input samples per intermediate sample
+	position float64 // input samples averaged into the current intermediate sample
+	sum      float64
+
+	filters []filter
+
+	fir     []float64
+	history []float64 // the last firTaps intermediate samples, as a ring
+	head    int
+	phase   int
+
+	samples []float32
+}
+
+// NewResampler constructs and returns a pointer to a Resampler from an input
+// sample rate to an output sample rate, both in Hz.
+func NewResampler(inputRate float64, outputRate float64) *Resampler {
+	intermediateRate := outputRate * oversampling
+	return &Resampler{
+		step:    inputRate / intermediateRate,
+		filters: newNESFilters(intermediateRate),
+		fir:     newLowPassFIR(firTaps, passband*0.5/oversampling),
+		history: make([]float64, firTaps),
+	}
+}
+
+// Add adds a sample at the input rate.
+func (r *Resampler) Add(sample float32) {
+	x := float64(sample)
+	left := 1.0
+	// an input sample can straddle the boundary between intermediate samples
+	for r.position+left >= r.step {
+		part := r.step - r.position
+		r.sum += x * part
+		r.addIntermediate(r.sum / r.step)
+		left -= part
+		r.sum = 0
+		r.position = 0
+	}
+	r.sum += x * left
+	r.position += left
+}
+
+// addIntermediate filters a sample at the intermediate rate, producing an
+// output sample from every oversampling of them.
+func (r *Resampler) addIntermediate(x float64) {
+	for _, f := range r.filters {
+		x = f.apply(x)
+	}
+	r.history[r.head] = x
+	r.head = (r.head + 1) % firTaps
+
+	r.phase++
+	if r.phase < oversampling {
+		return
+	}
+	r.phase = 0
+
+	y := 0.0
+	for i, c := range r.fir {
+		y += c * r.history[(r.head+i)%firTaps]
+	}
+	r.samples = append(r.samples, float32(y))
+}
+
+// Samples returns the output samples produced since it was last called.
+func (r *Resampler) Samples() []float32 {
+	samples := r.samples
+	r.samples = nil
+	return samples
+}
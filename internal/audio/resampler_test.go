@@ -0,0 +1,108 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/console"
+	"github.com/stretchr/testify/assert"
+)
+
+// resampleSine resamples a second of a sine wave at a frequency from the NTSC
+// clock rate, returning the output.
+func resampleSine(outputRate float64, frequency float64) []float32 {
+	clockRate := console.NTSC.CPUClockRate()
+	r := NewResampler(clockRate, outputRate)
+	for i := 0; float64(i) < clockRate; i++ {
+		r.Add(float32(math.Sin(2 * math.Pi * frequency * float64(i) / clockRate)))
+	}
+	return r.Samples()
+}
+
+// peak returns the peak amplitude of the second half of some samples, once
+// the filters have settled.
+func peak(samples []float32) float64 {
+	p := 0.0
+	for _, s := range samples[len(samples)/2:] {
+		p = math.Max(p, math.Abs(float64(s)))
+	}
+	return p
+}
+
+func TestResampler_Rate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		outputRate float64
+	}{
+		{
+			name:       "44.1kHz",
+			outputRate: 44100,
+		},
+		{
+			name:       "48kHz",
+			outputRate: 48000,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			samples := resampleSine(tc.outputRate, 1000)
+
+			assert.InDelta(t, tc.outputRate, len(samples), 1)
+		})
+	}
+}
+
+func TestResampler_Response(t *testing.T) {
+	testCases := []struct {
+		name      string
+		frequency float64
+		minimum   float64
+		maximum   float64
+	}{
+		{
+			name:      "bass is removed by the high-pass filters",
+			frequency: 20,
+			maximum:   0.05,
+		},
+		{
+			name:      "mid range passes",
+			frequency: 2000,
+			minimum:   0.95,
+			maximum:   1,
+		},
+		{
+			name:      "treble is softened by the low-pass filter",
+			frequency: 14000,
+			minimum:   0.6,
+			maximum:   0.75,
+		},
+		{
+			name:      "ultrasonic content does not alias",
+			frequency: 30000,
+			maximum:   0.01,
+		},
+		{
+			name:      "ultrasonic content near multiples of the output rate does not alias",
+			frequency: 44100 + 1000,
+			maximum:   0.01,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := peak(resampleSine(44100, tc.frequency))
+
+			assert.GreaterOrEqual(t, actual, tc.minimum)
+			assert.LessOrEqual(t, actual, tc.maximum)
+		})
+	}
+}
+
+func TestResampler_Samples(t *testing.T) {
+	r := NewResampler(console.NTSC.CPUClockRate(), 44100)
+	for i := 0; i < 1000; i++ {
+		r.Add(0.5)
+	}
+
+	assert.Len(t, r.Samples(), 24)
+	assert.Empty(t, r.Samples(), "samples not drained")
+}
@@ -0,0 +1,57 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// wavHeader is the header of a mono 16-bit PCM WAV file.
+type wavHeader struct {
+	RIFF          [4]byte
+	ChunkSize     uint32
+	WAVE          [4]byte
+	Fmt           [4]byte
+	FmtSize       uint32
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+	Data          [4]byte
+	DataSize      uint32
+}
+
+// wavHeaderSize is the size of a wavHeader once encoded.
+const wavHeaderSize = 44
+
+// WriteWAV writes samples to w as a mono 16-bit PCM WAV file. Samples are
+// clipped to the range -1 to 1.
+func WriteWAV(w io.Writer, sampleRate int, samples []float32) error {
+	dataSize := uint32(len(samples) * 2)
+	header := wavHeader{
+		RIFF:          [4]byte{'R', 'I', 'F', 'F'},
+		ChunkSize:     wavHeaderSize - 8 + dataSize,
+		WAVE:          [4]byte{'W', 'A', 'V', 'E'},
+		Fmt:           [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		AudioFormat:   1,
+		Channels:      1,
+		SampleRate:    uint32(sampleRate),
+		ByteRate:      uint32(sampleRate * 2),
+		BlockAlign:    2,
+		BitsPerSample: 16,
+		Data:          [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      dataSize,
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	data := make([]int16, len(samples))
+	for i, s := range samples {
+		data[i] = int16(math.Max(-1, math.Min(1, float64(s))) * math.MaxInt16)
+	}
+	return binary.Write(w, binary.LittleEndian, data)
+}
@@ -0,0 +1,37 @@
+package audio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteWAV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteWAV(&buf, 44100, []float32{0, 1, -1, 0.5, 2, -2}))
+
+	expected := []byte{
+		'R', 'I', 'F', 'F',
+		0x30, 0x00, 0x00, 0x00, // 36 + 12 bytes of data
+		'W', 'A', 'V', 'E',
+		'f', 'm', 't', ' ',
+		0x10, 0x00, 0x00, 0x00, // format chunk size
+		0x01, 0x00, // PCM
+		0x01, 0x00, // mono
+		0x44, 0xac, 0x00, 0x00, // 44100Hz
+		0x88, 0x58, 0x01, 0x00, // 88200 bytes per second
+		0x02, 0x00, // block alignment
+		0x10, 0x00, // 16 bits per sample
+		'd', 'a', 't', 'a',
+		0x0c, 0x00, 0x00, 0x00, // data size
+		0x00, 0x00, // 0
+		0xff, 0x7f, // 1
+		0x01, 0x80, // -1
+		0xff, 0x3f, // 0.5
+		0xff, 0x7f, // clipped to 1
+		0x01, 0x80, // clipped to -1
+	}
+	assert.Equal(t, expected, buf.Bytes())
+}
@@ -1,36 +1,242 @@
 package bus
 
+import "fmt"
+
 // RAMsize is the size of the bus RAM space.
 const RAMsize = 64 * 1024
 
+// InternalRAMsize is the size of the NES's internal work RAM.
+const InternalRAMsize = 2 * 1024
+
+// Address ranges of the NES CPU memory map.
+const (
+	InternalRAMStart = 0x0000
+	InternalRAMEnd   = 0x1fff
+	PPUStart         = 0x2000
+	PPUEnd           = 0x3fff
+	CartridgeStart   = 0x4020
+	CartridgeEnd     = 0xffff
+)
+
+// Device is a component that can be attached to the Bus at an address range.
+// Addresses passed to a Device are the full CPU addresses, so a Device is
+// responsible for any mirroring within its range.
+type Device interface {
+	// Read reads a byte at the address, including any side effects the read
+	// has on the device.
+	Read(address uint16) uint8
+	// Write writes a byte of data to the address.
+	Write(address uint16, data uint8)
+	// Peek reads a byte at the address without mutating the device's state.
+	Peek(address uint16) uint8
+}
+
+// PartialDevice is implemented by Devices that only drive some of the data lines
+// when read, such as APU status at $4015 or the controller ports at
+// $4016-$4017. Bits that are clear in the mask returned by DrivenBits are not
+// driven by the Device and read back the value already latched on the data
+// bus. A Device with write-only registers returns 0 for those addresses so that
+// reading them is fully open bus. Internal reports addresses that are registers
+// inside the CPU package, such as APU status at $4015, whose reads never reach
+// the external data bus and so leave the value latched on it unchanged.
+type PartialDevice interface {
+	Device
+	DrivenBits(address uint16) uint8
+	Internal(address uint16) bool
+}
+
+// Peeker is implemented by anything that can be read without side effects.
+// Debugging tools such as disassemblers and memory viewers should depend on
+// Peeker rather than Bus so that they cannot trigger read side effects on
+// memory-mapped registers.
+type Peeker interface {
+	Peek(address uint16) uint8
+}
+
 // RAM represents the addressable RAM space on the Bus.
 type RAM [RAMsize]uint8
 
+// Read reads a byte from RAM.
+func (r *RAM) Read(address uint16) uint8 {
+	return r[address]
+}
+
+// Write writes a byte to RAM.
+func (r *RAM) Write(address uint16, data uint8) {
+	r[address] = data
+}
+
+// Peek reads a byte from RAM.
+func (r *RAM) Peek(address uint16) uint8 {
+	return r[address]
+}
+
+// InternalRAM represents the 2KB of work RAM inside the NES, which is mirrored
+// four times across $0000-$1FFF.
+type InternalRAM [InternalRAMsize]uint8
+
+// Read reads a byte from internal RAM.
+func (r *InternalRAM) Read(address uint16) uint8 {
+	return r[address&(InternalRAMsize-1)]
+}
+
+// Write writes a byte to internal RAM.
+func (r *InternalRAM) Write(address uint16, data uint8) {
+	r[address&(InternalRAMsize-1)] = data
+}
+
+// Peek reads a byte from internal RAM.
+func (r *InternalRAM) Peek(address uint16) uint8 {
+	return r[address&(InternalRAMsize-1)]
+}
+
+// OverlapError is returned when a Device is attached to a range that is
+// already claimed by another Device.
+type OverlapError struct {
+	Start         uint16
+	End           uint16
+	ExistingStart uint16
+	ExistingEnd   uint16
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("bus: range $%04X-$%04X overlaps $%04X-$%04X", e.Start, e.End, e.ExistingStart, e.ExistingEnd)
+}
+
+// RangeError is returned when a Device is attached to a range whose start is
+// after its end.
+type RangeError struct {
+	Start uint16
+	End   uint16
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("bus: invalid range $%04X-$%04X", e.Start, e.End)
+}
+
+// mapping is an address range claimed by a Device.
+type mapping struct {
+	start  uint16
+	end    uint16
+	device Device
+}
+
 // Bus represents the bus used by the CPU to communicate with other components. It can be
-// read from and written to.
+// read from and written to. The Bus latches the last value driven on its data lines,
+// which is what reads of addresses not claimed by any Device (open bus) return.
 type Bus struct {
-	ram RAM
+	mappings []mapping
+	latch    uint8
+	dma      *DMA
 }
 
-// NewBus constructs and returns a Bus instance.
+// NewBus constructs and returns a Bus instance with the entire address space
+// backed by ram, as on a generic 6502 system.
 func NewBus(ram RAM) *Bus {
-	return &Bus{
-		ram: ram,
+	b := &Bus{}
+	_ = b.Attach(&ram, 0x0000, 0xffff)
+	return b
+}
+
+// NewNESBus constructs and returns a Bus instance laid out like the NES CPU
+// memory map, with 2KB of internal RAM mirrored across $0000-$1FFF and the DMA
+// unit at $4014, and the rest of the address space left for other Devices to
+// claim.
+func NewNESBus() *Bus {
+	b := &Bus{}
+	b.dma = NewDMA(b)
+	_ = b.Attach(&InternalRAM{}, InternalRAMStart, InternalRAMEnd)
+	_ = b.Attach(b.dma, OAMDMA, OAMDMA)
+	return b
+}
+
+// DMA returns the Bus's DMA unit, or nil if it has none.
+func (b *Bus) DMA() *DMA {
+	return b.dma
+}
+
+// Attach claims the inclusive address range start-end for the Device. It
+// returns an error if the range is invalid or overlaps an already attached
+// Device.
+func (b *Bus) Attach(d Device, start uint16, end uint16) error {
+	if start > end {
+		return &RangeError{Start: start, End: end}
+	}
+
+	i := 0
+	for ; i < len(b.mappings); i++ {
+		m := b.mappings[i]
+		if start <= m.end && m.start <= end {
+			return &OverlapError{Start: start, End: end, ExistingStart: m.start, ExistingEnd: m.end}
+		}
+		if end < m.start {
+			break
+		}
 	}
+
+	// keep mappings sorted by start address
+	b.mappings = append(b.mappings, mapping{})
+	copy(b.mappings[i+1:], b.mappings[i:])
+	b.mappings[i] = mapping{start: start, end: end, device: d}
+	return nil
+}
+
+// device returns the Device attached at the address, or nil if it is unmapped.
+func (b *Bus) device(address uint16) Device {
+	for _, m := range b.mappings {
+		if address < m.start {
+			return nil
+		}
+		if address <= m.end {
+			return m.device
+		}
+	}
+	return nil
 }
 
-// Read reads a byte at a given address on the Bus.
+// Read reads a byte at a given address on the Bus. Reads of internal registers
+// return the merged value without latching it.
 func (b *Bus) Read(address uint16) uint8 {
-	return b.ram[address]
+	d := b.device(address)
+	if d == nil {
+		return b.latch
+	}
+	data := b.merge(d, address, d.Read(address))
+	if pd, ok := d.(PartialDevice); ok && pd.Internal(address) {
+		return data
+	}
+	b.latch = data
+	return data
 }
 
-// ReadByteOnly will be used by diassaembler to read address with mutating state.
-// currently unused.
-func (b *Bus) ReadByteOnly(address uint16) uint8 {
-	return b.Read(address)
+// Peek reads a byte at a given address on the Bus without mutating the state of
+// the Bus or of the Device attached at the address.
+func (b *Bus) Peek(address uint16) uint8 {
+	if d := b.device(address); d != nil {
+		return b.merge(d, address, d.Peek(address))
+	}
+	return b.latch
+}
+
+// DataBus returns the value currently latched on the data bus.
+func (b *Bus) DataBus() uint8 {
+	return b.latch
+}
+
+// merge combines data read from a Device with the latched data bus value for
+// any bits the Device does not drive.
+func (b *Bus) merge(d Device, address uint16, data uint8) uint8 {
+	if pd, ok := d.(PartialDevice); ok {
+		driven := pd.DrivenBits(address)
+		return data&driven | b.latch&^driven
+	}
+	return data
 }
 
 // Write writes a byte of data to an address on the Bus.
 func (b *Bus) Write(address uint16, data uint8) {
-	b.ram[address] = data
+	b.latch = data
+	if d := b.device(address); d != nil {
+		d.Write(address, data)
+	}
 }
@@ -30,7 +30,7 @@ func TestBus_Read(t *testing.T) {
 	}
 }
 
-func TestBus_ReadByteOnly(t *testing.T) {
+func TestBus_Peek(t *testing.T) {
 	tests := []struct {
 		name         string
 		ramState     RAM
@@ -47,7 +47,7 @@ func TestBus_ReadByteOnly(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			b := NewBus(tt.ramState)
-			data := b.ReadByteOnly(tt.address)
+			data := b.Peek(tt.address)
 
 			assert.Equal(t, tt.expectedData, data)
 		})
@@ -82,7 +82,363 @@ func TestBus_Write(t *testing.T) {
 			b := NewBus(tt.initialRAMstate)
 			b.Write(tt.address, tt.data)
 
-			assert.Equal(t, tt.expectedRAMstate, b.ram)
+			assert.Equal(t, &tt.expectedRAMstate, b.mappings[0].device)
+		})
+	}
+}
+
+// testDevice is a Device backed by a map that records every access made to it.
+type testDevice struct {
+	data   map[uint16]uint8
+	reads  []uint16
+	writes []uint16
+}
+
+func newTestDevice() *testDevice {
+	return &testDevice{data: make(map[uint16]uint8)}
+}
+
+func (d *testDevice) Read(address uint16) uint8 {
+	d.reads = append(d.reads, address)
+	return d.data[address]
+}
+
+func (d *testDevice) Write(address uint16, data uint8) {
+	d.writes = append(d.writes, address)
+	d.data[address] = data
+}
+
+func (d *testDevice) Peek(address uint16) uint8 {
+	return d.data[address]
+}
+
+func TestBus_Attach(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         uint16
+		end           uint16
+		expectedError error
+	}{
+		{
+			name:  "non-overlapping range succeeds",
+			start: 0x4016,
+			end:   0x4017,
+		},
+		{
+			name:          "range overlapping the DMA unit fails",
+			start:         0x4000,
+			end:           0x4017,
+			expectedError: &OverlapError{Start: 0x4000, End: 0x4017, ExistingStart: OAMDMA, ExistingEnd: OAMDMA},
+		},
+		{
+			name:  "range adjacent to both neighbours succeeds",
+			start: 0x4015,
+			end:   0x401f,
+		},
+		{
+			name:          "range overlapping start of existing range fails",
+			start:         0x1f00,
+			end:           0x2000,
+			expectedError: &OverlapError{Start: 0x1f00, End: 0x2000, ExistingStart: 0x0000, ExistingEnd: 0x1fff},
+		},
+		{
+			name:          "range contained in existing range fails",
+			start:         0x2007,
+			end:           0x2007,
+			expectedError: &OverlapError{Start: 0x2007, End: 0x2007, ExistingStart: 0x2000, ExistingEnd: 0x3fff},
+		},
+		{
+			name:          "range containing existing range fails",
+			start:         0x3000,
+			end:           0xffff,
+			expectedError: &OverlapError{Start: 0x3000, End: 0xffff, ExistingStart: 0x2000, ExistingEnd: 0x3fff},
+		},
+		{
+			name:          "inverted range fails",
+			start:         0x4017,
+			end:           0x4016,
+			expectedError: &RangeError{Start: 0x4017, End: 0x4016},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewNESBus()
+			assert.NoError(t, b.Attach(newTestDevice(), 0x2000, 0x3fff))
+			assert.NoError(t, b.Attach(newTestDevice(), CartridgeStart, CartridgeEnd))
+
+			err := b.Attach(newTestDevice(), tt.start, tt.end)
+
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestBus_DeviceRouting(t *testing.T) {
+	ppu := newTestDevice()
+	ppu.data[0x2002] = 0x80
+	ppu.data[0x3ffa] = 0x81
+	cart := newTestDevice()
+	cart.data[0x8000] = 0x42
+
+	b := NewNESBus()
+	assert.NoError(t, b.Attach(cart, CartridgeStart, CartridgeEnd))
+	assert.NoError(t, b.Attach(ppu, 0x2000, 0x3fff))
+
+	tests := []struct {
+		name         string
+		address      uint16
+		expectedData uint8
+	}{
+		{
+			name:         "read routed to device at start of range",
+			address:      0x2002,
+			expectedData: 0x80,
+		},
+		{
+			name:         "read routed to device with full address",
+			address:      0x3ffa,
+			expectedData: 0x81,
+		},
+		{
+			name:         "read routed to cartridge",
+			address:      0x8000,
+			expectedData: 0x42,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedData, b.Read(tt.address))
+		})
+	}
+
+	b.Write(0x2006, 0x21)
+	b.Write(0x6000, 0x22)
+	assert.Equal(t, []uint16{0x2002, 0x3ffa}, ppu.reads)
+	assert.Equal(t, []uint16{0x2006}, ppu.writes)
+	assert.Equal(t, []uint16{0x8000}, cart.reads)
+	assert.Equal(t, []uint16{0x6000}, cart.writes)
+}
+
+func TestBus_InternalRAMMirroring(t *testing.T) {
+	tests := []struct {
+		name         string
+		writeAddress uint16
+		readAddress  uint16
+	}{
+		{
+			name:         "$0000 mirrored at $0800",
+			writeAddress: 0x0000,
+			readAddress:  0x0800,
+		},
+		{
+			name:         "$0123 mirrored at $1123",
+			writeAddress: 0x0123,
+			readAddress:  0x1123,
+		},
+		{
+			name:         "$1FFF mirrored at $07FF",
+			writeAddress: 0x1fff,
+			readAddress:  0x07ff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewNESBus()
+			b.Write(tt.writeAddress, 0x5a)
+			b.Write(0x4000, 0x00)
+
+			assert.Equal(t, uint8(0x5a), b.Read(tt.readAddress))
+		})
+	}
+}
+
+func TestBus_UnmappedRead(t *testing.T) {
+	b := NewNESBus()
+	b.Write(0x0000, 0x42)
+
+	assert.Equal(t, uint8(0x42), b.Read(0x0000))
+	assert.Equal(t, uint8(0x42), b.Read(0x5000))
+
+	b.Write(0x5000, 0x24)
+	assert.Equal(t, uint8(0x24), b.Read(0x8000))
+}
+
+// statusDevice is a Device modelling a read-sensitive status register, such as
+// PPUSTATUS, whose flag is cleared by reads.
+type statusDevice struct {
+	status uint8
+}
+
+func (d *statusDevice) Read(uint16) uint8 {
+	s := d.status
+	d.status &^= 0x80
+	return s
+}
+
+func (d *statusDevice) Write(uint16, uint8) {}
+
+func (d *statusDevice) Peek(uint16) uint8 {
+	return d.status
+}
+
+func TestBus_PeekHasNoSideEffects(t *testing.T) {
+	tests := []struct {
+		name         string
+		address      uint16
+		expectedData uint8
+	}{
+		{
+			name:         "peek of read-sensitive register",
+			address:      0x2002,
+			expectedData: 0x80,
+		},
+		{
+			name:         "peek of mirrored read-sensitive register",
+			address:      0x3ffa,
+			expectedData: 0x80,
+		},
+		{
+			name:         "peek of unmapped address returns data bus value",
+			address:      0x5000,
+			expectedData: 0x11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &statusDevice{status: 0x80}
+			b := NewNESBus()
+			assert.NoError(t, b.Attach(d, 0x2000, 0x3fff))
+			b.Write(0x0000, 0x11)
+
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, tt.expectedData, b.Peek(tt.address))
+			}
+			assert.Equal(t, uint8(0x80), d.status)
+			assert.Equal(t, uint8(0x11), b.latch)
+		})
+	}
+}
+
+// ioDevice is a PartialDevice laid out like the APU and I/O registers at
+// $4000-$4017, with write-only registers, a partially driven APU status
+// register and partially driven controller ports.
+type ioDevice struct {
+	data uint8
+}
+
+func (d *ioDevice) Read(uint16) uint8 {
+	return d.data
+}
+
+func (d *ioDevice) Write(uint16, uint8) {}
+
+func (d *ioDevice) Peek(uint16) uint8 {
+	return d.data
+}
+
+func (d *ioDevice) DrivenBits(address uint16) uint8 {
+	switch address {
+	case 0x4015:
+		return 0xdf
+	case 0x4016, 0x4017:
+		return 0x1f
+	default:
+		return 0x00
+	}
+}
+
+// Internal reports APU status as a register inside the CPU package.
+func (d *ioDevice) Internal(address uint16) bool {
+	return address == 0x4015
+}
+
+func TestBus_OpenBus(t *testing.T) {
+	tests := []struct {
+		name          string
+		deviceData    uint8
+		latchAddress  uint16
+		latchData     uint8
+		readAddress   uint16
+		expectedData  uint8
+		expectedLatch uint8
+	}{
+		{
+			name:          "unmapped read returns last written value",
+			latchAddress:  0x0000,
+			latchData:     0x42,
+			readAddress:   0x5000,
+			expectedData:  0x42,
+			expectedLatch: 0x42,
+		},
+		{
+			name:          "unmapped read returns last read value",
+			latchAddress:  0x0001,
+			latchData:     0x24,
+			readAddress:   0xffff,
+			expectedData:  0x24,
+			expectedLatch: 0x24,
+		},
+		{
+			name:          "write-only register is fully open bus",
+			deviceData:    0xff,
+			latchAddress:  0x0000,
+			latchData:     0x40,
+			readAddress:   0x4000,
+			expectedData:  0x40,
+			expectedLatch: 0x40,
+		},
+		{
+			name:          "$4015 bit 5 is open bus when set",
+			deviceData:    0x00,
+			latchAddress:  0x0000,
+			latchData:     0x40 | 0x20,
+			readAddress:   0x4015,
+			expectedData:  0x20,
+			expectedLatch: 0x40 | 0x20,
+		},
+		{
+			name:          "$4015 read is internal and leaves the latched value",
+			deviceData:    0xdf,
+			latchAddress:  0x0000,
+			latchData:     0x00,
+			readAddress:   0x4015,
+			expectedData:  0xdf,
+			expectedLatch: 0x00,
+		},
+		{
+			name:          "$4016 upper bits come from address high byte",
+			deviceData:    0x01,
+			latchAddress:  0x0000,
+			latchData:     0x40,
+			readAddress:   0x4016,
+			expectedData:  0x41,
+			expectedLatch: 0x41,
+		},
+		{
+			name:          "$4017 only drives low five bits",
+			deviceData:    0xff,
+			latchAddress:  0x0000,
+			latchData:     0x00,
+			readAddress:   0x4017,
+			expectedData:  0x1f,
+			expectedLatch: 0x1f,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewNESBus()
+			d := &ioDevice{data: tt.deviceData}
+			assert.NoError(t, b.Attach(d, 0x4000, OAMDMA-1))
+			assert.NoError(t, b.Attach(d, OAMDMA+1, 0x4017))
+			b.Write(0x0001, 0x24)
+			b.Write(0x0000, 0x42)
+			b.Write(tt.latchAddress, tt.latchData)
+			b.Read(tt.latchAddress)
+
+			assert.Equal(t, tt.expectedData, b.Peek(tt.readAddress), "incorrect peeked value")
+			assert.Equal(t, tt.latchData, b.DataBus(), "peek mutated the data bus")
+			assert.Equal(t, tt.expectedData, b.Read(tt.readAddress), "incorrect read value")
+			assert.Equal(t, tt.expectedLatch, b.DataBus(), "incorrect data bus value")
 		})
 	}
 }
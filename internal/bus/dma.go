@@ -0,0 +1,131 @@
+package bus
+
+// OAMDMA is the address of the register that starts an OAM DMA transfer.
+const OAMDMA = 0x4014
+
+// oamData is the address of the PPU's OAMDATA register, which OAM DMA writes.
+const oamData = 0x2004
+
+// SampleLoader receives a sample byte fetched by a DMC DMA transfer.
+type SampleLoader interface {
+	LoadSample(data uint8)
+}
+
+// DMA represents the 2A03's DMA unit, which takes over the Bus from the CPU to
+// copy a page of memory into PPU OAM, or to fetch sample bytes for the APU's
+// delta modulation channel (DMC).
+//
+// While a transfer is in progress the CPU is halted. The first halted cycle is
+// spent waiting for the CPU to stop, after which reads happen on get cycles and
+// writes on put cycles, which alternate. An OAM DMA transfer takes 513 cycles,
+// or 514 if the CPU was halted on an odd cycle and has to wait one more cycle
+// for a get cycle. A DMC DMA transfer needs a dummy cycle before its read, so
+// takes 3 or 4 cycles on its own; during an OAM DMA transfer it takes the place
+// of an OAM read, and the OAM transfer loses another cycle realigning.
+type DMA struct {
+	bus *Bus
+
+	halted bool
+
+	// OAM DMA
+	oamActive  bool
+	oamAddress uint16 // next address read
+	oamData    uint8
+	oamLoaded  bool // oamData holds a byte waiting to be written
+
+	// DMC DMA
+	dmcActive  bool
+	dmcAddress uint16
+	dmcDummy   bool // the dummy cycle before the read is done
+	dmcLoader  SampleLoader
+}
+
+// NewDMA constructs and returns a pointer to a DMA unit that transfers over a
+// Bus.
+func NewDMA(b *Bus) *DMA {
+	return &DMA{bus: b}
+}
+
+// Read reads the DMA unit's register, which is write-only and fully open bus.
+func (d *DMA) Read(uint16) uint8 {
+	return 0
+}
+
+// Peek reads the DMA unit's register without side effects.
+func (d *DMA) Peek(uint16) uint8 {
+	return 0
+}
+
+// DrivenBits returns 0, as no bits of the register are driven when read.
+func (d *DMA) DrivenBits(uint16) uint8 {
+	return 0
+}
+
+// Internal returns false, as the register is write-only and reading it is
+// open bus.
+func (d *DMA) Internal(uint16) bool {
+	return false
+}
+
+// Write starts an OAM DMA transfer of the page of memory selected by data,
+// which begins once the instruction doing the write has finished.
+func (d *DMA) Write(_ uint16, data uint8) {
+	d.oamActive = true
+	d.oamAddress = uint16(data) << 8
+	d.oamLoaded = false
+}
+
+// RequestDMC starts a DMC DMA transfer of the sample byte at address, which is
+// passed to the loader once read.
+func (d *DMA) RequestDMC(address uint16, loader SampleLoader) {
+	d.dmcActive = true
+	d.dmcAddress = address
+	d.dmcDummy = false
+	d.dmcLoader = loader
+}
+
+// Active returns whether a transfer is in progress or waiting to begin.
+func (d *DMA) Active() bool {
+	return d.oamActive || d.dmcActive
+}
+
+// Clock advances any transfer in progress by one CPU cycle, where cycle is the
+// number of CPU cycles since power on, used to tell get and put cycles apart.
+// It returns whether the CPU is halted for the cycle, and must be called on
+// each cycle the CPU reads, as the CPU can only be halted on read cycles.
+func (d *DMA) Clock(cycle uint32) bool {
+	if !d.Active() {
+		d.halted = false
+		return false
+	}
+	if !d.halted {
+		d.halted = true
+		return true
+	}
+
+	dmcReady := d.dmcActive && d.dmcDummy
+	if d.dmcActive {
+		d.dmcDummy = true
+	}
+
+	if cycle%2 == 1 {
+		// get cycle
+		switch {
+		case dmcReady:
+			d.dmcActive = false
+			d.dmcLoader.LoadSample(d.bus.Read(d.dmcAddress))
+		case d.oamActive && !d.oamLoaded:
+			d.oamData = d.bus.Read(d.oamAddress)
+			d.oamLoaded = true
+		}
+	} else if d.oamLoaded {
+		// put cycle
+		d.bus.Write(oamData, d.oamData)
+		d.oamLoaded = false
+		d.oamAddress++
+		if d.oamAddress&0x00ff == 0 {
+			d.oamActive = false
+		}
+	}
+	return true
+}
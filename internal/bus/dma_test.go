@@ -0,0 +1,192 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oamRecorder is a Device recording the bytes written to OAMDATA.
+type oamRecorder struct {
+	data []uint8
+}
+
+func (r *oamRecorder) Read(uint16) uint8 {
+	return 0
+}
+
+func (r *oamRecorder) Write(address uint16, data uint8) {
+	if address == oamData {
+		r.data = append(r.data, data)
+	}
+}
+
+func (r *oamRecorder) Peek(uint16) uint8 {
+	return 0
+}
+
+// sampleRecorder is a SampleLoader recording the samples it is given.
+type sampleRecorder struct {
+	samples []uint8
+}
+
+func (r *sampleRecorder) LoadSample(data uint8) {
+	r.samples = append(r.samples, data)
+}
+
+// newDMATestBus creates a NES Bus with an oamRecorder attached for the PPU and
+// a testDevice for the cartridge, whose every byte at $8000-$80FF holds the low
+// byte of its address.
+func newDMATestBus(t *testing.T) (*Bus, *oamRecorder, *testDevice) {
+	b := NewNESBus()
+	oam := &oamRecorder{}
+	cart := newTestDevice()
+	for i := uint16(0); i < 0x100; i++ {
+		cart.data[0x8000|i] = uint8(i)
+	}
+	require.NoError(t, b.Attach(oam, PPUStart, PPUEnd))
+	require.NoError(t, b.Attach(cart, CartridgeStart, CartridgeEnd))
+	return b, oam, cart
+}
+
+// stall clocks a DMA unit from a cycle until it releases the CPU, calling
+// during on each halted cycle, and returns the number of cycles the CPU was
+// halted for.
+func stall(d *DMA, cycle uint32, during func(halted int)) int {
+	halted := 0
+	for d.Clock(cycle) {
+		halted++
+		cycle++
+		if during != nil {
+			during(halted)
+		}
+	}
+	return halted
+}
+
+// page returns the bytes $00-$FF in order.
+func page() []uint8 {
+	data := make([]uint8, 0x100)
+	for i := range data {
+		data[i] = uint8(i)
+	}
+	return data
+}
+
+func TestDMA_OAM(t *testing.T) {
+	tests := []struct {
+		name          string
+		cycle         uint32
+		expectedStall int
+	}{
+		{
+			name:          "halted on an even cycle",
+			cycle:         100,
+			expectedStall: 513,
+		},
+		{
+			name:          "halted on an odd cycle",
+			cycle:         101,
+			expectedStall: 514,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, oam, cart := newDMATestBus(t)
+			b.Write(OAMDMA, 0x80)
+
+			assert.True(t, b.DMA().Active())
+			assert.Equal(t, tt.expectedStall, stall(b.DMA(), tt.cycle, nil))
+			assert.False(t, b.DMA().Active())
+			assert.Equal(t, page(), oam.data)
+			assert.Len(t, cart.reads, 0x100, "reads not made through the Bus")
+		})
+	}
+}
+
+func TestDMA_OAMFromInternalRAM(t *testing.T) {
+	b, oam, _ := newDMATestBus(t)
+	for i := uint16(0); i < 0x100; i++ {
+		b.Write(0x0700|i, uint8(i))
+	}
+	b.Write(OAMDMA, 0x07)
+	stall(b.DMA(), 0, nil)
+
+	assert.Equal(t, page(), oam.data)
+}
+
+func TestDMA_DMC(t *testing.T) {
+	tests := []struct {
+		name          string
+		oam           bool
+		cycle         uint32
+		requestAt     int
+		expectedStall int
+	}{
+		{
+			name:          "halted on an even cycle",
+			cycle:         100,
+			expectedStall: 4,
+		},
+		{
+			name:          "halted on an odd cycle",
+			cycle:         101,
+			expectedStall: 3,
+		},
+		{
+			name:          "during OAM DMA",
+			oam:           true,
+			cycle:         100,
+			requestAt:     100,
+			expectedStall: 513 + 2,
+		},
+		{
+			name:          "during OAM DMA halted on an odd cycle",
+			oam:           true,
+			cycle:         101,
+			requestAt:     51,
+			expectedStall: 514 + 2,
+		},
+		{
+			name:          "during the last OAM DMA write costs one cycle",
+			oam:           true,
+			cycle:         100,
+			requestAt:     512,
+			expectedStall: 513 + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, oam, _ := newDMATestBus(t)
+			samples := &sampleRecorder{}
+
+			var during func(int)
+			if tt.oam {
+				b.Write(OAMDMA, 0x80)
+				during = func(halted int) {
+					if halted == tt.requestAt {
+						b.DMA().RequestDMC(0x8042, samples)
+					}
+				}
+			} else {
+				b.DMA().RequestDMC(0x8042, samples)
+			}
+
+			assert.Equal(t, tt.expectedStall, stall(b.DMA(), tt.cycle, during))
+			assert.Equal(t, []uint8{0x42}, samples.samples)
+			if tt.oam {
+				assert.Equal(t, page(), oam.data)
+			}
+		})
+	}
+}
+
+func TestDMA_OpenBus(t *testing.T) {
+	b := NewNESBus()
+	b.Write(0x0000, 0x42)
+	b.Read(0x0000)
+
+	assert.Equal(t, uint8(0x42), b.Read(OAMDMA))
+	assert.Equal(t, uint8(0x42), b.Peek(OAMDMA))
+}
@@ -0,0 +1,63 @@
+package cartridge
+
+// axrom is mapper 7, the AMROM/ANROM/AOROM boards. Writes to $8000-$FFFF
+// select a 32KB PRG-ROM bank with bits 0-2 and which nametable page is used
+// for single-screen mirroring with bit 4.
+type axrom struct {
+	baseMapper
+
+	bank uint8
+}
+
+func newAxROM(c *Cartridge) Mapper {
+	return &axrom{baseMapper: newBaseMapper(c)}
+}
+
+// CPURead reads a byte from the selected PRG-ROM bank.
+func (m *axrom) CPURead(address uint16) uint8 {
+	switch {
+	case address >= 0x8000:
+		return m.readPRG(int(m.bank&0x07), 0x8000, address)
+	case address >= 0x6000:
+		return m.readPRGRAM(address)
+	default:
+		return 0
+	}
+}
+
+// PRGOffset returns the offset into PRG-ROM mapped at an address.
+func (m *axrom) PRGOffset(address uint16) (int, bool) {
+	if address < 0x8000 {
+		return 0, false
+	}
+	return m.prgOffset(int(m.bank&0x07), 0x8000, address), true
+}
+
+// CPUWrite selects the PRG-ROM bank and nametable page.
+func (m *axrom) CPUWrite(address uint16, data uint8) {
+	switch {
+	case address >= 0x8000:
+		m.bank = data
+	case address >= 0x6000:
+		m.writePRGRAM(address, data)
+	}
+}
+
+// PPURead reads a byte from the only CHR bank.
+func (m *axrom) PPURead(address uint16) uint8 {
+	return m.readCHR(0, 0x2000, address)
+}
+
+// PPUWrite writes a byte to the only CHR bank.
+func (m *axrom) PPUWrite(address uint16, data uint8) {
+	m.writeCHR(0, 0x2000, address, data)
+}
+
+// Mirroring returns the single-screen page selected by bit 4 of the bank
+// register.
+func (m *axrom) Mirroring() Mirroring {
+	if m.bank&0x10 > 0 {
+		return SingleScreenUpper
+	}
+	return SingleScreenLower
+}
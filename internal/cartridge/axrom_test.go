@@ -0,0 +1,49 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAxROM(t *testing.T) {
+	testCases := []struct {
+		name              string
+		bank              uint8
+		expected8000      uint8
+		expectedC000      uint8
+		expectedMirroring Mirroring
+	}{
+		{
+			name:              "power on maps first 32KB bank and lower nametable",
+			bank:              0x00,
+			expected8000:      0x00,
+			expectedC000:      0x01,
+			expectedMirroring: SingleScreenLower,
+		},
+		{
+			name:              "bank select switches whole 32KB",
+			bank:              0x03,
+			expected8000:      0x06,
+			expectedC000:      0x07,
+			expectedMirroring: SingleScreenLower,
+		},
+		{
+			name:              "bit 4 selects upper nametable",
+			bank:              0x12,
+			expected8000:      0x04,
+			expectedC000:      0x05,
+			expectedMirroring: SingleScreenUpper,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(8, 0).mapper(7))
+			b.Write(0x8000, tc.bank)
+
+			assert.Equal(t, tc.expected8000, b.Read(0x8000), "incorrect bank at $8000")
+			assert.Equal(t, tc.expectedC000, b.Read(0xc000), "incorrect bank at $C000")
+			assert.Equal(t, tc.expectedMirroring, c.Mirroring())
+		})
+	}
+}
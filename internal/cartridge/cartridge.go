@@ -0,0 +1,206 @@
+package cartridge
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// UnsupportedMapperError is returned when a ROM image uses a mapper that has
+// not been implemented.
+type UnsupportedMapperError struct {
+	Mapper    uint16
+	Submapper uint8
+}
+
+func (e *UnsupportedMapperError) Error() string {
+	return fmt.Sprintf("cartridge: unsupported mapper %d (submapper %d)", e.Mapper, e.Submapper)
+}
+
+// Cartridge represents a game cartridge. It owns the PRG and CHR memory of a
+// ROM image and exposes them to the CPU at $4020-$FFFF, where it is attached to
+// the bus as a bus.Device, and to the PPU at $0000-$1FFF.
+type Cartridge struct {
+	Header Header
+
+	trainer []byte
+	prgROM  []byte
+	chr     []byte
+	prgRAM  []byte
+
+	chrIsRAM bool
+
+	mapper Mapper
+}
+
+// New parses an iNES or NES 2.0 ROM image and returns the Cartridge it
+// describes.
+func New(data []byte) (*Cartridge, error) {
+	h, err := ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	offset := HeaderSize
+
+	c := &Cartridge{Header: h}
+	if h.Trainer {
+		if c.trainer, err = section(data, offset, TrainerSize, "trainer"); err != nil {
+			return nil, err
+		}
+		offset += TrainerSize
+	}
+	if c.prgROM, err = section(data, offset, h.PRGROMSize, "PRG-ROM"); err != nil {
+		return nil, err
+	}
+	offset += h.PRGROMSize
+	if c.chr, err = section(data, offset, h.CHRROMSize, "CHR-ROM"); err != nil {
+		return nil, err
+	}
+
+	if h.CHRROMSize == 0 {
+		c.chr = make([]byte, h.CHRRAMSize+h.CHRNVRAMSize)
+		c.chrIsRAM = true
+	}
+	c.prgRAM = make([]byte, h.PRGRAMSize+h.PRGNVRAMSize)
+	if h.Trainer {
+		if len(c.prgRAM) < 0x2000 {
+			c.prgRAM = make([]byte, 0x2000)
+		}
+		copy(c.prgRAM[0x1000:], c.trainer)
+	}
+
+	newMapper, ok := mapperConstructors[h.Mapper]
+	if !ok {
+		return nil, &UnsupportedMapperError{Mapper: h.Mapper, Submapper: h.Submapper}
+	}
+	c.mapper = newMapper(c)
+
+	return c, nil
+}
+
+// Load reads a ROM image from r and returns the Cartridge it describes.
+func Load(r io.Reader) (*Cartridge, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(data)
+}
+
+// LoadFile reads the ROM image at path and returns the Cartridge it describes.
+func LoadFile(path string) (*Cartridge, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(data)
+}
+
+// section returns a copy of the size bytes at offset in data.
+func section(data []byte, offset int, size int, name string) ([]byte, error) {
+	if len(data)-offset < size {
+		return nil, &SizeError{Section: name, Expected: size, Actual: len(data) - offset}
+	}
+	s := make([]byte, size)
+	copy(s, data[offset:offset+size])
+	return s, nil
+}
+
+// Trainer returns the 512 byte trainer of the ROM image, or nil if it has none.
+// Trainers are loaded into PRG-RAM at $7000-$71FF.
+func (c *Cartridge) Trainer() []byte {
+	return c.trainer
+}
+
+// Mapper returns the bank switching hardware of the cartridge.
+func (c *Cartridge) Mapper() Mapper {
+	return c.mapper
+}
+
+// Read reads a byte from the cartridge's CPU address space.
+func (c *Cartridge) Read(address uint16) uint8 {
+	return c.mapper.CPURead(address)
+}
+
+// Peek reads a byte from the cartridge's CPU address space without side effects.
+func (c *Cartridge) Peek(address uint16) uint8 {
+	return c.mapper.CPURead(address)
+}
+
+// Write writes a byte to the cartridge's CPU address space.
+func (c *Cartridge) Write(address uint16, data uint8) {
+	c.mapper.CPUWrite(address, data)
+}
+
+// DrivenBits reports which data lines the cartridge drives when the address is
+// read. The expansion area below $6000 and disabled or missing PRG-RAM are open
+// bus.
+func (c *Cartridge) DrivenBits(address uint16) uint8 {
+	if address >= 0x8000 {
+		return 0xff
+	}
+	if address < 0x6000 {
+		return 0x00
+	}
+
+	enabled := len(c.prgRAM) > 0
+	if gate, ok := c.mapper.(prgRAMGate); ok {
+		enabled = gate.prgRAMEnabled(address)
+	}
+	if enabled {
+		return 0xff
+	}
+	return 0x00
+}
+
+// Internal returns false, as the cartridge is on the external data bus.
+func (c *Cartridge) Internal(uint16) bool {
+	return false
+}
+
+// PRGOffset returns the offset into PRG-ROM of the byte the CPU currently sees
+// at an address, following the mapper's bank switching, or false if the
+// address is not mapped to PRG-ROM. Debug symbols for banked code are keyed by
+// this offset.
+func (c *Cartridge) PRGOffset(address uint16) (int, bool) {
+	return c.mapper.PRGOffset(address)
+}
+
+// PPURead reads a byte from the cartridge's pattern table space ($0000-$1FFF).
+func (c *Cartridge) PPURead(address uint16) uint8 {
+	return c.mapper.PPURead(address & 0x1fff)
+}
+
+// PPUWrite writes a byte to the cartridge's pattern table space. Writes are
+// only honoured when the cartridge uses CHR-RAM.
+func (c *Cartridge) PPUWrite(address uint16, data uint8) {
+	c.mapper.PPUWrite(address&0x1fff, data)
+}
+
+// Mirroring returns the nametable mirroring mode currently selected by the
+// cartridge.
+func (c *Cartridge) Mirroring() Mirroring {
+	return c.mapper.Mirroring()
+}
+
+// IRQ returns whether the cartridge is asserting the CPU's IRQ line.
+func (c *Cartridge) IRQ() bool {
+	return c.mapper.IRQ()
+}
+
+// Clock advances the cartridge by one CPU cycle.
+func (c *Cartridge) Clock() {
+	c.mapper.Clock()
+}
+
+// Reset signals the cartridge that the console's reset button was pressed.
+func (c *Cartridge) Reset() {
+	c.mapper.Reset()
+}
+
+// PowerCycle returns the cartridge to its power on state by rebuilding its
+// mapper, which clears the mapper's registers. PRG-RAM and CHR-RAM keep their
+// contents.
+func (c *Cartridge) PowerCycle() {
+	c.mapper = mapperConstructors[c.Header.Mapper](c)
+}
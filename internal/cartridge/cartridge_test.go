@@ -0,0 +1,296 @@
+package cartridge
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// romBuilder is a test utility for creating iNES ROM images inline in test
+// case definitions.
+type romBuilder struct {
+	header  [HeaderSize]byte
+	trainer []byte
+	prg     []byte
+	chr     []byte
+}
+
+// newROMBuilder creates a romBuilder for an iNES image with the given number
+// of 16KB PRG-ROM and 8KB CHR-ROM banks. Every byte of a bank is filled with
+// the bank's index so tests can tell which bank is mapped.
+func newROMBuilder(prgBanks int, chrBanks int) *romBuilder {
+	b := &romBuilder{}
+	copy(b.header[:], magic)
+	b.header[4] = byte(prgBanks)
+	b.header[5] = byte(chrBanks)
+	for i := 0; i < prgBanks; i++ {
+		b.prg = append(b.prg, bytes.Repeat([]byte{byte(i)}, prgROMUnit)...)
+	}
+	for i := 0; i < chrBanks; i++ {
+		b.chr = append(b.chr, bytes.Repeat([]byte{byte(i)}, chrROMUnit)...)
+	}
+	return b
+}
+
+// mapper sets the mapper number of the image being built.
+func (b *romBuilder) mapper(m uint8) *romBuilder {
+	b.header[6] = b.header[6]&0x0f | m<<4
+	b.header[7] = b.header[7]&0x0f | m&0xf0
+	return b
+}
+
+// nes20 marks the image being built as NES 2.0 with the given submapper and
+// 8KB of PRG-RAM.
+func (b *romBuilder) nes20(submapper uint8) *romBuilder {
+	b.header[7] = b.header[7]&0xf3 | 0x08
+	b.header[8] = submapper << 4
+	b.header[10] = 0x07
+	return b
+}
+
+// flags6 ORs the passed bits into header byte 6.
+func (b *romBuilder) flags6(f byte) *romBuilder {
+	b.header[6] |= f
+	return b
+}
+
+// withTrainer adds a trainer filled with the passed byte.
+func (b *romBuilder) withTrainer(fill byte) *romBuilder {
+	b.header[6] |= 0x04
+	b.trainer = bytes.Repeat([]byte{fill}, TrainerSize)
+	return b
+}
+
+// fillCHR refills CHR-ROM so every byte of each bank of the given size holds
+// the bank's index.
+func (b *romBuilder) fillCHR(size int) *romBuilder {
+	for i := range b.chr {
+		b.chr[i] = byte(i / size)
+	}
+	return b
+}
+
+// prgByte overwrites a single byte of PRG-ROM.
+func (b *romBuilder) prgByte(offset int, data byte) *romBuilder {
+	b.prg[offset] = data
+	return b
+}
+
+// build returns the raw bytes of the image.
+func (b *romBuilder) build() []byte {
+	data := append([]byte{}, b.header[:]...)
+	data = append(data, b.trainer...)
+	data = append(data, b.prg...)
+	return append(data, b.chr...)
+}
+
+// cartridge parses the built image, failing the test on error.
+func (b *romBuilder) cartridge(t *testing.T) *Cartridge {
+	t.Helper()
+	c, err := New(b.build())
+	require.NoError(t, err)
+	return c
+}
+
+func TestNew_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          []byte
+		expectedError error
+	}{
+		{
+			name:          "truncated PRG-ROM",
+			data:          newROMBuilder(2, 1).build()[:HeaderSize+prgROMUnit],
+			expectedError: &SizeError{Section: "PRG-ROM", Expected: 2 * prgROMUnit, Actual: prgROMUnit},
+		},
+		{
+			name:          "truncated CHR-ROM",
+			data:          newROMBuilder(1, 1).build()[:HeaderSize+prgROMUnit+10],
+			expectedError: &SizeError{Section: "CHR-ROM", Expected: chrROMUnit, Actual: 10},
+		},
+		{
+			name:          "truncated trainer",
+			data:          newROMBuilder(1, 1).withTrainer(0xaa).build()[:HeaderSize+100],
+			expectedError: &SizeError{Section: "trainer", Expected: TrainerSize, Actual: 100},
+		},
+		{
+			name:          "unsupported mapper",
+			data:          newROMBuilder(1, 1).mapper(0xff).build(),
+			expectedError: &UnsupportedMapperError{Mapper: 0xff},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(tc.data)
+
+			assert.Nil(t, c)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c, err := Load(bytes.NewReader(newROMBuilder(1, 1).flags6(0x01).build()))
+
+	require.NoError(t, err)
+	assert.Equal(t, Vertical, c.Header.Mirroring)
+	assert.Len(t, c.prgROM, prgROMUnit)
+	assert.Len(t, c.chr, chrROMUnit)
+	assert.False(t, c.chrIsRAM)
+
+	_, err = Load(bytes.NewReader([]byte("NES")))
+	var sizeErr *SizeError
+	assert.True(t, errors.As(err, &sizeErr))
+}
+
+func TestCartridge_Read(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rom          *romBuilder
+		address      uint16
+		expectedData uint8
+	}{
+		{
+			name:         "NROM-128 first bank at $8000",
+			rom:          newROMBuilder(1, 1).prgByte(0x0000, 0x42),
+			address:      0x8000,
+			expectedData: 0x42,
+		},
+		{
+			name:         "NROM-128 mirrored at $C000",
+			rom:          newROMBuilder(1, 1).prgByte(0x3ffc, 0x42),
+			address:      0xfffc,
+			expectedData: 0x42,
+		},
+		{
+			name:         "NROM-256 second bank at $C000",
+			rom:          newROMBuilder(2, 1),
+			address:      0xc000,
+			expectedData: 0x01,
+		},
+		{
+			name:         "trainer loaded into PRG-RAM at $7000",
+			rom:          newROMBuilder(1, 1).withTrainer(0x99),
+			address:      0x7000,
+			expectedData: 0x99,
+		},
+		{
+			name:         "expansion area reads zero",
+			rom:          newROMBuilder(1, 1),
+			address:      0x4020,
+			expectedData: 0x00,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.rom.cartridge(t)
+
+			assert.Equal(t, tc.expectedData, c.Read(tc.address))
+		})
+	}
+}
+
+func TestCartridge_Write(t *testing.T) {
+	c := newROMBuilder(1, 1).cartridge(t)
+
+	c.Write(0x6000, 0x11)
+	c.Write(0x8000, 0x22)
+
+	assert.Equal(t, uint8(0x11), c.Read(0x6000))
+	assert.Equal(t, uint8(0x00), c.Read(0x8000))
+}
+
+func TestCartridge_PowerCycle(t *testing.T) {
+	b, c := attach(t, newROMBuilder(8, 0).mapper(2))
+	b.Write(0xffff, 0x03)
+	b.Write(0x6000, 0x11)
+
+	c.PowerCycle()
+
+	assert.Equal(t, uint8(0x00), b.Read(0x8000), "bank select not cleared")
+	assert.Equal(t, uint8(0x11), b.Read(0x6000), "PRG-RAM not kept")
+}
+
+func TestCartridge_PRGOffset(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rom            *romBuilder
+		writes         []busWrite
+		address        uint16
+		expectedOffset int
+		expectedMapped bool
+	}{
+		{
+			name:           "NROM-128 mirrors $C000 onto $8000",
+			rom:            newROMBuilder(1, 1),
+			address:        0xc123,
+			expectedOffset: 0x0123,
+			expectedMapped: true,
+		},
+		{
+			name:           "UxROM switched bank",
+			rom:            newROMBuilder(8, 0).mapper(2),
+			writes:         []busWrite{{0x8000, 0x03}},
+			address:        0x8123,
+			expectedOffset: 0xc123,
+			expectedMapped: true,
+		},
+		{
+			name:           "UxROM fixed last bank",
+			rom:            newROMBuilder(8, 0).mapper(2),
+			writes:         []busWrite{{0x8000, 0x03}},
+			address:        0xfffc,
+			expectedOffset: 0x1fffc,
+			expectedMapped: true,
+		},
+		{
+			name:           "PRG-RAM is not PRG-ROM",
+			rom:            newROMBuilder(1, 1),
+			address:        0x6000,
+			expectedMapped: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, tc.rom)
+			for _, w := range tc.writes {
+				b.Write(w.address, w.data)
+			}
+
+			offset, mapped := c.PRGOffset(tc.address)
+
+			assert.Equal(t, tc.expectedMapped, mapped, "incorrect mapped")
+			assert.Equal(t, tc.expectedOffset, offset, "incorrect offset")
+		})
+	}
+}
+
+func TestCartridge_PPUWrite(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rom          *romBuilder
+		expectedData uint8
+	}{
+		{
+			name:         "CHR-ROM ignores writes",
+			rom:          newROMBuilder(1, 1),
+			expectedData: 0x00,
+		},
+		{
+			name:         "CHR-RAM accepts writes",
+			rom:          newROMBuilder(1, 0),
+			expectedData: 0x5a,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.rom.cartridge(t)
+			c.PPUWrite(0x1234, 0x5a)
+
+			assert.Equal(t, tc.expectedData, c.PPURead(0x1234))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package cartridge
+
+// cnrom is mapper 3, the CNROM board. PRG-ROM is laid out as on NROM, and an
+// 8KB CHR-ROM bank is selected by writes to $8000-$FFFF.
+type cnrom struct {
+	baseMapper
+
+	chrBank uint8
+}
+
+func newCNROM(c *Cartridge) Mapper {
+	return &cnrom{baseMapper: newBaseMapper(c)}
+}
+
+// CPURead reads a byte from PRG-RAM or PRG-ROM.
+func (m *cnrom) CPURead(address uint16) uint8 {
+	switch {
+	case address >= 0x8000:
+		return m.readPRG(0, len(m.prgROM), address-0x8000)
+	case address >= 0x6000:
+		return m.readPRGRAM(address)
+	default:
+		return 0
+	}
+}
+
+// PRGOffset returns the offset into PRG-ROM mapped at an address.
+func (m *cnrom) PRGOffset(address uint16) (int, bool) {
+	if address < 0x8000 {
+		return 0, false
+	}
+	return m.prgOffset(0, len(m.prgROM), address-0x8000), true
+}
+
+// CPUWrite writes to PRG-RAM or selects the CHR bank.
+func (m *cnrom) CPUWrite(address uint16, data uint8) {
+	switch {
+	case address >= 0x8000:
+		m.chrBank = data
+	case address >= 0x6000:
+		m.writePRGRAM(address, data)
+	}
+}
+
+// PPURead reads a byte from the selected CHR bank.
+func (m *cnrom) PPURead(address uint16) uint8 {
+	return m.readCHR(int(m.chrBank), 0x2000, address)
+}
+
+// PPUWrite writes a byte to the selected CHR bank.
+func (m *cnrom) PPUWrite(address uint16, data uint8) {
+	m.writeCHR(int(m.chrBank), 0x2000, address, data)
+}
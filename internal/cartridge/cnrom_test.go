@@ -0,0 +1,41 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCNROM(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bank         uint8
+		expectedData uint8
+	}{
+		{
+			name:         "power on maps first CHR bank",
+			bank:         0x00,
+			expectedData: 0x00,
+		},
+		{
+			name:         "bank select switches CHR bank",
+			bank:         0x02,
+			expectedData: 0x02,
+		},
+		{
+			name:         "bank numbers wrap around CHR-ROM size",
+			bank:         0x05,
+			expectedData: 0x01,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(2, 4).mapper(3))
+			b.Write(0x8000, tc.bank)
+
+			assert.Equal(t, tc.expectedData, c.PPURead(0x0000))
+			assert.Equal(t, tc.expectedData, c.PPURead(0x1fff))
+			assert.Equal(t, uint8(0x01), b.Read(0xc000), "PRG-ROM should not be banked")
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package cartridge
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// HeaderSize is the size of an iNES/NES 2.0 header in bytes.
+const HeaderSize = 16
+
+// TrainerSize is the size of the optional trainer that precedes PRG ROM.
+const TrainerSize = 512
+
+const (
+	prgROMUnit = 16 * 1024
+	chrROMUnit = 8 * 1024
+	prgRAMUnit = 8 * 1024
+)
+
+var magic = []byte{'N', 'E', 'S', 0x1a}
+
+// Format is the header format a ROM image was dumped in.
+type Format uint8
+
+// Supported header formats.
+const (
+	INES  Format = iota // INES is the original iNES 1.0 format.
+	NES20               // NES20 is the extended NES 2.0 format.
+)
+
+// String returns the human readable name of the header format.
+func (f Format) String() string {
+	switch f {
+	case INES:
+		return "iNES"
+	case NES20:
+		return "NES 2.0"
+	default:
+		return fmt.Sprintf("Format(%d)", uint8(f))
+	}
+}
+
+// Mirroring is the nametable mirroring arrangement used by the PPU.
+type Mirroring uint8
+
+// Nametable mirroring modes.
+const (
+	Horizontal        Mirroring = iota // Horizontal mirrors $2000/$2400 and $2800/$2C00.
+	Vertical                           // Vertical mirrors $2000/$2800 and $2400/$2C00.
+	FourScreen                         // FourScreen uses extra cartridge VRAM for all four nametables.
+	SingleScreenLower                  // SingleScreenLower maps all nametables to the first page.
+	SingleScreenUpper                  // SingleScreenUpper maps all nametables to the second page.
+)
+
+// String returns the human readable name of the mirroring mode.
+func (m Mirroring) String() string {
+	switch m {
+	case Horizontal:
+		return "horizontal"
+	case Vertical:
+		return "vertical"
+	case FourScreen:
+		return "four-screen"
+	case SingleScreenLower:
+		return "single-screen (lower)"
+	case SingleScreenUpper:
+		return "single-screen (upper)"
+	default:
+		return fmt.Sprintf("Mirroring(%d)", uint8(m))
+	}
+}
+
+// Timing is the CPU/PPU timing region a ROM image targets.
+type Timing uint8
+
+// Timing regions.
+const (
+	NTSC        Timing = iota // NTSC is the RP2C02/RP2A03 timing used in North America and Japan.
+	PAL                       // PAL is the RP2C07/RP2A07 timing used in Europe and Australia.
+	MultiRegion               // MultiRegion images run on either NTSC or PAL consoles.
+	Dendy                     // Dendy is the UMC 6527P timing used by Famiclones.
+)
+
+// String returns the human readable name of the timing region.
+func (t Timing) String() string {
+	switch t {
+	case NTSC:
+		return "NTSC"
+	case PAL:
+		return "PAL"
+	case MultiRegion:
+		return "multi-region"
+	case Dendy:
+		return "Dendy"
+	default:
+		return fmt.Sprintf("Timing(%d)", uint8(t))
+	}
+}
+
+// Header holds the decoded contents of an iNES or NES 2.0 header. All sizes are
+// in bytes.
+type Header struct {
+	Format       Format
+	PRGROMSize   int
+	CHRROMSize   int
+	Mapper       uint16
+	Submapper    uint8
+	Mirroring    Mirroring
+	Battery      bool
+	Trainer      bool
+	PRGRAMSize   int
+	PRGNVRAMSize int
+	CHRRAMSize   int
+	CHRNVRAMSize int
+	Timing       Timing
+}
+
+// HeaderError is returned when the 16 byte header of a ROM image is malformed.
+type HeaderError struct {
+	Reason string
+}
+
+func (e *HeaderError) Error() string {
+	return "cartridge: invalid header: " + e.Reason
+}
+
+// SizeError is returned when a ROM image is shorter than its header declares.
+type SizeError struct {
+	Section  string
+	Expected int
+	Actual   int
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("cartridge: %s truncated: expected %d bytes, got %d", e.Section, e.Expected, e.Actual)
+}
+
+// ParseHeader decodes the first 16 bytes of data as an iNES 1.0 or NES 2.0 header.
+func ParseHeader(data []byte) (Header, error) {
+	if len(data) < HeaderSize {
+		return Header{}, &SizeError{Section: "header", Expected: HeaderSize, Actual: len(data)}
+	}
+	if !bytes.Equal(data[0:4], magic) {
+		return Header{}, &HeaderError{Reason: fmt.Sprintf("bad magic % X", data[0:4])}
+	}
+
+	flags6 := data[6]
+	flags7 := data[7]
+
+	h := Header{
+		Battery: flags6&0x02 > 0,
+		Trainer: flags6&0x04 > 0,
+	}
+	switch {
+	case flags6&0x08 > 0:
+		h.Mirroring = FourScreen
+	case flags6&0x01 > 0:
+		h.Mirroring = Vertical
+	default:
+		h.Mirroring = Horizontal
+	}
+
+	var err error
+	if flags7&0x0c == 0x08 {
+		h, err = parseNES20(h, data)
+	} else {
+		h = parseINES(h, data)
+	}
+	if err != nil {
+		return Header{}, err
+	}
+	if h.PRGROMSize == 0 {
+		return Header{}, &HeaderError{Reason: "no PRG-ROM"}
+	}
+	return h, nil
+}
+
+// parseINES decodes the iNES 1.0 specific fields of a header.
+func parseINES(h Header, data []byte) Header {
+	h.Format = INES
+	h.PRGROMSize = int(data[4]) * prgROMUnit
+	h.CHRROMSize = int(data[5]) * chrROMUnit
+	h.Mapper = uint16(data[6] >> 4)
+
+	// Headers written by old tools often have garbage such as "DiskDude!" in
+	// bytes 7-15, in which case the upper mapper nibble cannot be trusted.
+	if bytes.Equal(data[12:16], []byte{0, 0, 0, 0}) {
+		h.Mapper |= uint16(data[7] & 0xf0)
+		if data[9]&0x01 > 0 {
+			h.Timing = PAL
+		}
+	}
+
+	h.PRGRAMSize = int(data[8]) * prgRAMUnit
+	if h.PRGRAMSize == 0 {
+		h.PRGRAMSize = prgRAMUnit
+	}
+	if h.Battery {
+		h.PRGNVRAMSize, h.PRGRAMSize = h.PRGRAMSize, 0
+	}
+	if h.CHRROMSize == 0 {
+		h.CHRRAMSize = chrROMUnit
+	}
+	return h
+}
+
+// parseNES20 decodes the NES 2.0 specific fields of a header.
+func parseNES20(h Header, data []byte) (Header, error) {
+	h.Format = NES20
+	h.Mapper = uint16(data[6]>>4) | uint16(data[7]&0xf0) | uint16(data[8]&0x0f)<<8
+	h.Submapper = data[8] >> 4
+
+	var err error
+	if h.PRGROMSize, err = romSize(data[4], data[9]&0x0f, prgROMUnit, "PRG-ROM"); err != nil {
+		return Header{}, err
+	}
+	if h.CHRROMSize, err = romSize(data[5], data[9]>>4, chrROMUnit, "CHR-ROM"); err != nil {
+		return Header{}, err
+	}
+	if h.PRGRAMSize, err = ramSize(data[10]&0x0f, "PRG-RAM"); err != nil {
+		return Header{}, err
+	}
+	if h.PRGNVRAMSize, err = ramSize(data[10]>>4, "PRG-NVRAM"); err != nil {
+		return Header{}, err
+	}
+	if h.CHRRAMSize, err = ramSize(data[11]&0x0f, "CHR-RAM"); err != nil {
+		return Header{}, err
+	}
+	if h.CHRNVRAMSize, err = ramSize(data[11]>>4, "CHR-NVRAM"); err != nil {
+		return Header{}, err
+	}
+	h.Timing = Timing(data[12] & 0x03)
+	return h, nil
+}
+
+// romSize computes a NES 2.0 ROM size from its LSB and MSB nibble. An MSB
+// nibble of 0xf selects the exponent-multiplier notation, where the LSB holds
+// the size as 2^E * (MM*2+1).
+func romSize(lsb byte, msb byte, unit int, name string) (int, error) {
+	if msb == 0x0f {
+		exponent := uint(lsb >> 2)
+		if exponent > 30 {
+			return 0, &HeaderError{Reason: fmt.Sprintf("%s exponent %d out of range", name, exponent)}
+		}
+		multiplier := int(lsb&0x03)*2 + 1
+		return (1 << exponent) * multiplier, nil
+	}
+	return (int(msb)<<8 | int(lsb)) * unit, nil
+}
+
+// ramSize computes a NES 2.0 RAM size from its shift count nibble.
+func ramSize(shift byte, name string) (int, error) {
+	if shift == 0 {
+		return 0, nil
+	}
+	if shift > 14 {
+		return 0, &HeaderError{Reason: fmt.Sprintf("%s shift count %d out of range", name, shift)}
+	}
+	return 64 << shift, nil
+}
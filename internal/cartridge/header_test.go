@@ -0,0 +1,156 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeader(t *testing.T) {
+	testCases := []struct {
+		name           string
+		header         []byte
+		expectedHeader Header
+	}{
+		{
+			name:   "iNES NROM-256 with vertical mirroring",
+			header: []byte{'N', 'E', 'S', 0x1a, 2, 1, 0x01, 0x00, 0, 0, 0, 0, 0, 0, 0, 0},
+			expectedHeader: Header{
+				Format:     INES,
+				PRGROMSize: 32 * 1024,
+				CHRROMSize: 8 * 1024,
+				Mapper:     0,
+				Mirroring:  Vertical,
+				PRGRAMSize: 8 * 1024,
+				Timing:     NTSC,
+			},
+		},
+		{
+			name:   "iNES MMC1 with battery, trainer, CHR-RAM and PAL timing",
+			header: []byte{'N', 'E', 'S', 0x1a, 8, 0, 0x16, 0x00, 0, 1, 0, 0, 0, 0, 0, 0},
+			expectedHeader: Header{
+				Format:       INES,
+				PRGROMSize:   128 * 1024,
+				Mapper:       1,
+				Mirroring:    Horizontal,
+				Battery:      true,
+				Trainer:      true,
+				PRGNVRAMSize: 8 * 1024,
+				CHRRAMSize:   8 * 1024,
+				Timing:       PAL,
+			},
+		},
+		{
+			name:   "iNES upper mapper nibble from flags 7",
+			header: []byte{'N', 'E', 'S', 0x1a, 8, 16, 0x48, 0x40, 0, 0, 0, 0, 0, 0, 0, 0},
+			expectedHeader: Header{
+				Format:     INES,
+				PRGROMSize: 128 * 1024,
+				CHRROMSize: 128 * 1024,
+				Mapper:     68,
+				Mirroring:  FourScreen,
+				PRGRAMSize: 8 * 1024,
+			},
+		},
+		{
+			name:   "iNES dirty header ignores flags 7 mapper nibble",
+			header: []byte{'N', 'E', 'S', 0x1a, 2, 1, 0x41, 'D', 'i', 's', 'k', 'D', 'u', 'd', 'e', '!'},
+			expectedHeader: Header{
+				Format:     INES,
+				PRGROMSize: 32 * 1024,
+				CHRROMSize: 8 * 1024,
+				Mapper:     4,
+				Mirroring:  Vertical,
+				PRGRAMSize: 0x69 * 8 * 1024,
+			},
+		},
+		{
+			name:   "NES 2.0 MMC3 with submapper, RAM sizes and Dendy timing",
+			header: []byte{'N', 'E', 'S', 0x1a, 16, 32, 0x43, 0x08, 0x10, 0x00, 0x70, 0x07, 0x03, 0, 0, 0},
+			expectedHeader: Header{
+				Format:       NES20,
+				PRGROMSize:   256 * 1024,
+				CHRROMSize:   256 * 1024,
+				Mapper:       4,
+				Submapper:    1,
+				Mirroring:    Vertical,
+				Battery:      true,
+				PRGNVRAMSize: 8 * 1024,
+				CHRRAMSize:   8 * 1024,
+				Timing:       Dendy,
+			},
+		},
+		{
+			name:   "NES 2.0 extended mapper number and ROM size MSB",
+			header: []byte{'N', 'E', 'S', 0x1a, 0x00, 0x00, 0x10, 0x18, 0x21, 0x11, 0x00, 0x00, 0x01, 0, 0, 0},
+			expectedHeader: Header{
+				Format:     NES20,
+				PRGROMSize: 256 * 16 * 1024,
+				CHRROMSize: 256 * 8 * 1024,
+				Mapper:     0x111,
+				Submapper:  2,
+				Mirroring:  Horizontal,
+				Timing:     PAL,
+			},
+		},
+		{
+			name:   "NES 2.0 exponent-multiplier PRG-ROM size",
+			header: []byte{'N', 'E', 'S', 0x1a, 0x2a, 0x00, 0x00, 0x08, 0x00, 0x0f, 0x00, 0x00, 0x00, 0, 0, 0},
+			expectedHeader: Header{
+				Format:     NES20,
+				PRGROMSize: (1 << 10) * 5,
+				Mirroring:  Horizontal,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := ParseHeader(tc.header)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedHeader, h)
+		})
+	}
+}
+
+func TestParseHeader_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		header        []byte
+		expectedError error
+	}{
+		{
+			name:          "truncated header",
+			header:        []byte{'N', 'E', 'S', 0x1a, 2, 1},
+			expectedError: &SizeError{Section: "header", Expected: 16, Actual: 6},
+		},
+		{
+			name:          "bad magic",
+			header:        []byte{'N', 'E', 'S', 0x00, 2, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			expectedError: &HeaderError{Reason: "bad magic 4E 45 53 00"},
+		},
+		{
+			name:          "no PRG-ROM",
+			header:        []byte{'N', 'E', 'S', 0x1a, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			expectedError: &HeaderError{Reason: "no PRG-ROM"},
+		},
+		{
+			name:          "NES 2.0 PRG-ROM exponent out of range",
+			header:        []byte{'N', 'E', 'S', 0x1a, 0xfc, 0, 0, 0x08, 0, 0x0f, 0, 0, 0, 0, 0, 0},
+			expectedError: &HeaderError{Reason: "PRG-ROM exponent 63 out of range"},
+		},
+		{
+			name:          "NES 2.0 PRG-RAM shift count out of range",
+			header:        []byte{'N', 'E', 'S', 0x1a, 1, 0, 0, 0x08, 0, 0, 0x0f, 0, 0, 0, 0, 0},
+			expectedError: &HeaderError{Reason: "PRG-RAM shift count 15 out of range"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := ParseHeader(tc.header)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, Header{}, h)
+		})
+	}
+}
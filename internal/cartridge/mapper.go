@@ -0,0 +1,139 @@
+package cartridge
+
+// Mapper is the bank switching hardware on a cartridge. It decides which parts
+// of PRG and CHR memory are visible to the CPU and PPU, and may control the
+// nametable mirroring and drive the CPU's IRQ line.
+type Mapper interface {
+	// CPURead reads a byte from the CPU address space ($4020-$FFFF). It must
+	// not have side effects, so that it can also serve peeks.
+	CPURead(address uint16) uint8
+	// CPUWrite writes a byte to the CPU address space ($4020-$FFFF), which is
+	// where mapper registers are written.
+	CPUWrite(address uint16, data uint8)
+	// PRGOffset returns the offset into PRG-ROM of the byte the CPU currently
+	// sees at an address, or false if the address is not mapped to PRG-ROM.
+	PRGOffset(address uint16) (int, bool)
+	// PPURead reads a byte from the PPU pattern table space ($0000-$1FFF).
+	PPURead(address uint16) uint8
+	// PPUWrite writes a byte to the PPU pattern table space ($0000-$1FFF).
+	PPUWrite(address uint16, data uint8)
+	// Mirroring returns the current nametable mirroring mode.
+	Mirroring() Mirroring
+	// IRQ returns whether the mapper is currently asserting the IRQ line.
+	IRQ() bool
+	// Clock advances the mapper by one CPU cycle.
+	Clock()
+	// Reset signals the mapper that the console's reset button was pressed.
+	Reset()
+}
+
+// mapperConstructors maps iNES mapper numbers to the functions that construct
+// them.
+var mapperConstructors = map[uint16]func(c *Cartridge) Mapper{
+	0: newNROM,
+	1: newMMC1,
+	2: newUxROM,
+	3: newCNROM,
+	4: newMMC3,
+	7: newAxROM,
+}
+
+// prgRAMGate is implemented by mappers that can disable PRG-RAM, in which
+// case reads from it are open bus.
+type prgRAMGate interface {
+	prgRAMEnabled(address uint16) bool
+}
+
+// baseMapper holds the memory shared by every mapper and provides default
+// implementations of the parts of Mapper most boards don't use.
+type baseMapper struct {
+	prgROM    []byte
+	chr       []byte
+	prgRAM    []byte
+	chrIsRAM  bool
+	mirroring Mirroring
+}
+
+// newBaseMapper constructs a baseMapper over the cartridge's memory.
+func newBaseMapper(c *Cartridge) baseMapper {
+	return baseMapper{
+		prgROM:    c.prgROM,
+		chr:       c.chr,
+		prgRAM:    c.prgRAM,
+		chrIsRAM:  c.chrIsRAM,
+		mirroring: c.Header.Mirroring,
+	}
+}
+
+// Mirroring returns the mirroring mode wired on the board.
+func (m *baseMapper) Mirroring() Mirroring {
+	return m.mirroring
+}
+
+// IRQ returns false, as most boards cannot raise interrupts.
+func (m *baseMapper) IRQ() bool {
+	return false
+}
+
+// Clock does nothing, as most boards don't count CPU cycles.
+func (m *baseMapper) Clock() {}
+
+// Reset does nothing, as most boards have no state affected by reset.
+func (m *baseMapper) Reset() {}
+
+// readPRGRAM reads from PRG-RAM at $6000-$7FFF.
+func (m *baseMapper) readPRGRAM(address uint16) uint8 {
+	if len(m.prgRAM) == 0 {
+		return 0
+	}
+	return m.prgRAM[int(address-0x6000)%len(m.prgRAM)]
+}
+
+// writePRGRAM writes to PRG-RAM at $6000-$7FFF.
+func (m *baseMapper) writePRGRAM(address uint16, data uint8) {
+	if len(m.prgRAM) > 0 {
+		m.prgRAM[int(address-0x6000)%len(m.prgRAM)] = data
+	}
+}
+
+// readPRG reads from the PRG-ROM bank of the given size, wrapping bank numbers
+// that exceed the ROM size. Negative banks count back from the last bank.
+func (m *baseMapper) readPRG(bank int, size int, address uint16) uint8 {
+	return m.prgROM[m.prgOffset(bank, size, address)]
+}
+
+// prgOffset returns the offset into PRG-ROM of an address within a bank of the
+// given size, wrapping bank numbers as readPRG does.
+func (m *baseMapper) prgOffset(bank int, size int, address uint16) int {
+	return bankAddress(len(m.prgROM), bank, size, address)
+}
+
+// readCHR reads from the CHR bank of the given size.
+func (m *baseMapper) readCHR(bank int, size int, address uint16) uint8 {
+	if len(m.chr) == 0 {
+		return 0
+	}
+	return m.chr[bankAddress(len(m.chr), bank, size, address)]
+}
+
+// writeCHR writes to the CHR bank of the given size, if it is CHR-RAM.
+func (m *baseMapper) writeCHR(bank int, size int, address uint16, data uint8) {
+	if m.chrIsRAM && len(m.chr) > 0 {
+		m.chr[bankAddress(len(m.chr), bank, size, address)] = data
+	}
+}
+
+// bankAddress returns the offset into memory of length memSize of an address
+// within a bank of the given size. Bank numbers wrap around the number of banks
+// present, as the unused upper bank lines are not connected.
+func bankAddress(memSize int, bank int, size int, address uint16) int {
+	banks := memSize / size
+	if banks == 0 {
+		return int(address) % memSize
+	}
+	bank %= banks
+	if bank < 0 {
+		bank += banks
+	}
+	return bank*size + int(address)%size
+}
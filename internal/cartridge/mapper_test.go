@@ -0,0 +1,24 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/require"
+)
+
+// attach builds the cartridge described by rom and attaches it to a NES bus so
+// that mapper registers are exercised through the bus routing.
+func attach(t *testing.T, rom *romBuilder) (*bus.Bus, *Cartridge) {
+	t.Helper()
+	c := rom.cartridge(t)
+	b := bus.NewNESBus()
+	require.NoError(t, b.Attach(c, bus.CartridgeStart, bus.CartridgeEnd))
+	return b, c
+}
+
+// busWrite is a single CPU write made through the bus.
+type busWrite struct {
+	address uint16
+	data    uint8
+}
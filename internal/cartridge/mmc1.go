@@ -0,0 +1,179 @@
+package cartridge
+
+// mmc1 is mapper 1, the Nintendo MMC1 (SxROM boards). Its registers are loaded
+// serially: five writes to $8000-$FFFF shift bit 0 into a shift register, and
+// the fifth write copies it to the register selected by address bits 13-14.
+type mmc1 struct {
+	baseMapper
+
+	shift      uint8
+	shiftCount uint8
+
+	control  uint8 // $8000-$9FFF: mirroring, PRG and CHR bank modes
+	chrBank0 uint8 // $A000-$BFFF
+	chrBank1 uint8 // $C000-$DFFF
+	prgBank  uint8 // $E000-$FFFF: PRG bank and PRG-RAM disable
+
+	// The MMC1 ignores a write to its serial port on the cycle after another,
+	// which matters for the double write of read-modify-write instructions.
+	wroteThisCycle bool
+	wroteLastCycle bool
+}
+
+func newMMC1(c *Cartridge) Mapper {
+	m := &mmc1{baseMapper: newBaseMapper(c)}
+	m.Reset()
+	return m
+}
+
+// CPURead reads a byte from PRG-RAM or the banked PRG-ROM.
+func (m *mmc1) CPURead(address uint16) uint8 {
+	switch {
+	case address >= 0x8000:
+		bank, size := m.prgBankAt(address)
+		return m.readPRG(bank, size, address)
+	case address >= 0x6000 && m.prgRAMEnabled(address):
+		return m.readPRGRAM(address)
+	default:
+		return 0
+	}
+}
+
+// PRGOffset returns the offset into PRG-ROM mapped at an address.
+func (m *mmc1) PRGOffset(address uint16) (int, bool) {
+	if address < 0x8000 {
+		return 0, false
+	}
+	bank, size := m.prgBankAt(address)
+	return m.prgOffset(bank, size, address), true
+}
+
+// CPUWrite writes to PRG-RAM or shifts a bit into the serial port.
+func (m *mmc1) CPUWrite(address uint16, data uint8) {
+	switch {
+	case address >= 0x8000:
+		m.writeSerial(address, data)
+	case address >= 0x6000 && m.prgRAMEnabled(address):
+		m.writePRGRAM(address, data)
+	}
+}
+
+// writeSerial handles a write to the serial port at $8000-$FFFF.
+func (m *mmc1) writeSerial(address uint16, data uint8) {
+	ignored := m.wroteLastCycle
+	m.wroteThisCycle = true
+	if ignored {
+		return
+	}
+
+	if data&0x80 > 0 {
+		m.resetShift()
+		m.control |= 0x0c
+		return
+	}
+
+	m.shift |= (data & 0x01) << m.shiftCount
+	m.shiftCount++
+	if m.shiftCount < 5 {
+		return
+	}
+
+	switch (address >> 13) & 0x03 {
+	case 0:
+		m.control = m.shift
+	case 1:
+		m.chrBank0 = m.shift
+	case 2:
+		m.chrBank1 = m.shift
+	case 3:
+		m.prgBank = m.shift
+	}
+	m.resetShift()
+}
+
+// resetShift empties the shift register.
+func (m *mmc1) resetShift() {
+	m.shift = 0
+	m.shiftCount = 0
+}
+
+// prgBankAt returns the PRG-ROM bank and bank size mapped at an address in
+// $8000-$FFFF.
+func (m *mmc1) prgBankAt(address uint16) (int, int) {
+	bank := int(m.prgBank & 0x0f)
+	// SUROM and SXROM boards use CHR bank bit 4 to select the 256KB PRG-ROM half.
+	outer := 0
+	if len(m.prgROM) > 256*1024 {
+		outer = int(m.chrBank0 & 0x10)
+	}
+
+	switch (m.control >> 2) & 0x03 {
+	case 0, 1:
+		return (outer | bank) >> 1, 0x8000
+	case 2:
+		if address < 0xc000 {
+			return outer, 0x4000
+		}
+		return outer | bank, 0x4000
+	default:
+		if address < 0xc000 {
+			return outer | bank, 0x4000
+		}
+		return outer | 0x0f, 0x4000
+	}
+}
+
+// prgRAMEnabled returns whether PRG-RAM at $6000-$7FFF is enabled.
+func (m *mmc1) prgRAMEnabled(uint16) bool {
+	return len(m.prgRAM) > 0 && m.prgBank&0x10 == 0
+}
+
+// PPURead reads a byte from the banked CHR memory.
+func (m *mmc1) PPURead(address uint16) uint8 {
+	bank, size := m.chrBankAt(address)
+	return m.readCHR(bank, size, address)
+}
+
+// PPUWrite writes a byte to the banked CHR memory.
+func (m *mmc1) PPUWrite(address uint16, data uint8) {
+	bank, size := m.chrBankAt(address)
+	m.writeCHR(bank, size, address, data)
+}
+
+// chrBankAt returns the CHR bank and bank size mapped at an address in
+// $0000-$1FFF.
+func (m *mmc1) chrBankAt(address uint16) (int, int) {
+	if m.control&0x10 == 0 {
+		return int(m.chrBank0) >> 1, 0x2000
+	}
+	if address < 0x1000 {
+		return int(m.chrBank0), 0x1000
+	}
+	return int(m.chrBank1), 0x1000
+}
+
+// Mirroring returns the mirroring mode selected by the control register.
+func (m *mmc1) Mirroring() Mirroring {
+	switch m.control & 0x03 {
+	case 0:
+		return SingleScreenLower
+	case 1:
+		return SingleScreenUpper
+	case 2:
+		return Vertical
+	default:
+		return Horizontal
+	}
+}
+
+// Clock advances the consecutive write detection by one CPU cycle.
+func (m *mmc1) Clock() {
+	m.wroteLastCycle = m.wroteThisCycle
+	m.wroteThisCycle = false
+}
+
+// Reset empties the shift register and fixes the last PRG bank at $C000.
+func (m *mmc1) Reset() {
+	m.resetShift()
+	m.control |= 0x0c
+}
@@ -0,0 +1,210 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeMMC1 loads a register of an MMC1 through its serial port, clocking the
+// cartridge between writes so none are ignored as consecutive.
+func writeMMC1(b *bus.Bus, c *Cartridge, address uint16, data uint8) {
+	for i := uint(0); i < 5; i++ {
+		b.Write(address, data>>i&0x01)
+		c.Clock()
+		c.Clock()
+	}
+}
+
+func TestMMC1_PRGBanking(t *testing.T) {
+	testCases := []struct {
+		name         string
+		control      uint8
+		prgBank      uint8
+		chrBank0     uint8
+		prgBanks     int
+		expected8000 uint8
+		expectedC000 uint8
+	}{
+		{
+			name:         "power on fixes last bank at $C000",
+			control:      0x0c,
+			prgBank:      0x00,
+			prgBanks:     8,
+			expected8000: 0x00,
+			expectedC000: 0x07,
+		},
+		{
+			name:         "mode 3 switches bank at $8000",
+			control:      0x0c,
+			prgBank:      0x05,
+			prgBanks:     8,
+			expected8000: 0x05,
+			expectedC000: 0x07,
+		},
+		{
+			name:         "mode 2 fixes first bank at $8000 and switches $C000",
+			control:      0x08,
+			prgBank:      0x05,
+			prgBanks:     8,
+			expected8000: 0x00,
+			expectedC000: 0x05,
+		},
+		{
+			name:         "32KB mode ignores low bank bit",
+			control:      0x00,
+			prgBank:      0x03,
+			prgBanks:     8,
+			expected8000: 0x02,
+			expectedC000: 0x03,
+		},
+		{
+			name:         "bank numbers wrap around PRG-ROM size",
+			control:      0x0c,
+			prgBank:      0x0a,
+			prgBanks:     8,
+			expected8000: 0x02,
+			expectedC000: 0x07,
+		},
+		{
+			name:         "SUROM selects upper 256KB with CHR bank bit 4",
+			control:      0x0c,
+			prgBank:      0x01,
+			chrBank0:     0x10,
+			prgBanks:     32,
+			expected8000: 0x11,
+			expectedC000: 0x1f,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(tc.prgBanks, 1).mapper(1))
+			writeMMC1(b, c, 0x8000, tc.control)
+			writeMMC1(b, c, 0xa000, tc.chrBank0)
+			writeMMC1(b, c, 0xe000, tc.prgBank)
+
+			assert.Equal(t, tc.expected8000, b.Read(0x8000), "incorrect bank at $8000")
+			assert.Equal(t, tc.expectedC000, b.Read(0xc000), "incorrect bank at $C000")
+		})
+	}
+}
+
+func TestMMC1_CHRBanking(t *testing.T) {
+	testCases := []struct {
+		name         string
+		control      uint8
+		chrBank0     uint8
+		chrBank1     uint8
+		expected0000 uint8
+		expected1000 uint8
+	}{
+		{
+			name:         "8KB mode ignores low bank bit and CHR bank 1",
+			control:      0x0c,
+			chrBank0:     0x03,
+			chrBank1:     0x07,
+			expected0000: 0x02,
+			expected1000: 0x03,
+		},
+		{
+			name:         "4KB mode switches both halves",
+			control:      0x1c,
+			chrBank0:     0x03,
+			chrBank1:     0x07,
+			expected0000: 0x03,
+			expected1000: 0x07,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(2, 4).fillCHR(0x1000).mapper(1))
+			writeMMC1(b, c, 0x8000, tc.control)
+			writeMMC1(b, c, 0xa000, tc.chrBank0)
+			writeMMC1(b, c, 0xc000, tc.chrBank1)
+
+			assert.Equal(t, tc.expected0000, c.PPURead(0x0000), "incorrect bank at $0000")
+			assert.Equal(t, tc.expected1000, c.PPURead(0x1000), "incorrect bank at $1000")
+		})
+	}
+}
+
+func TestMMC1_Mirroring(t *testing.T) {
+	testCases := []struct {
+		control           uint8
+		expectedMirroring Mirroring
+	}{
+		{control: 0x00, expectedMirroring: SingleScreenLower},
+		{control: 0x01, expectedMirroring: SingleScreenUpper},
+		{control: 0x02, expectedMirroring: Vertical},
+		{control: 0x03, expectedMirroring: Horizontal},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expectedMirroring.String(), func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(2, 1).mapper(1))
+			writeMMC1(b, c, 0x9fff, tc.control)
+
+			assert.Equal(t, tc.expectedMirroring, c.Mirroring())
+		})
+	}
+}
+
+func TestMMC1_SerialPort(t *testing.T) {
+	t.Run("write with bit 7 set resets shift register", func(t *testing.T) {
+		b, c := attach(t, newROMBuilder(8, 1).mapper(1))
+		b.Write(0xe000, 0x01)
+		c.Clock()
+		c.Clock()
+		b.Write(0xe000, 0x80)
+		c.Clock()
+		c.Clock()
+		writeMMC1(b, c, 0xe000, 0x04)
+
+		assert.Equal(t, uint8(0x04), b.Read(0x8000))
+	})
+
+	t.Run("write with bit 7 set restores PRG mode 3", func(t *testing.T) {
+		b, c := attach(t, newROMBuilder(8, 1).mapper(1))
+		writeMMC1(b, c, 0x8000, 0x00)
+		b.Write(0x8000, 0x80)
+
+		assert.Equal(t, uint8(0x07), b.Read(0xc000))
+	})
+
+	t.Run("write on consecutive cycle is ignored", func(t *testing.T) {
+		b, c := attach(t, newROMBuilder(16, 1).mapper(1))
+		// a read-modify-write instruction writes the old value, then the new
+		// value on the next cycle; only the first write is seen
+		for i := 0; i < 5; i++ {
+			b.Write(0xe000, 0x01)
+			c.Clock()
+			b.Write(0xe000, 0x00)
+			c.Clock()
+			c.Clock()
+		}
+
+		assert.Equal(t, uint8(0x0f), b.Read(0x8000))
+	})
+
+	t.Run("reset fixes last bank at $C000", func(t *testing.T) {
+		b, c := attach(t, newROMBuilder(8, 1).mapper(1))
+		writeMMC1(b, c, 0x8000, 0x00)
+		c.Reset()
+
+		assert.Equal(t, uint8(0x07), b.Read(0xc000))
+	})
+}
+
+func TestMMC1_PRGRAMEnable(t *testing.T) {
+	b, c := attach(t, newROMBuilder(2, 1).mapper(1))
+	b.Write(0x6000, 0x42)
+	assert.Equal(t, uint8(0x42), b.Read(0x6000))
+
+	writeMMC1(b, c, 0xe000, 0x10)
+	b.Write(0x6000, 0x24)
+	b.Write(0x0000, 0x99)
+	assert.Equal(t, uint8(0x99), b.Read(0x6000), "disabled PRG-RAM should be open bus")
+
+	writeMMC1(b, c, 0xe000, 0x00)
+	assert.Equal(t, uint8(0x42), b.Read(0x6000), "write to disabled PRG-RAM should be ignored")
+}
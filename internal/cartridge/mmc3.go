@@ -0,0 +1,261 @@
+package cartridge
+
+// MMC3 revisions that differ in IRQ behaviour, selected by NES 2.0 submapper.
+const (
+	mmc3SubmapperMMC6  = 1
+	mmc3SubmapperMMC3A = 4
+)
+
+// a12FilterCycles is the number of CPU cycles PPU A12 must stay low before a
+// rising edge clocks the MMC3's scanline counter. This filters out the rapid
+// A12 toggling during sprite pattern fetches.
+const a12FilterCycles = 3
+
+// mmc3 is mapper 4, the Nintendo MMC3 (TxROM boards) and its MMC6 variant
+// (HKROM). It has fine grained PRG and CHR banking and a scanline counter,
+// clocked by rising edges on PPU address line A12, that can raise IRQs.
+type mmc3 struct {
+	baseMapper
+
+	bankSelect uint8    // $8000 even: target register, PRG and CHR modes
+	registers  [8]uint8 // $8001 odd: R0-R7 bank numbers
+	fourScreen bool
+	prgRAMCtrl uint8 // $A001 odd: PRG-RAM protect
+
+	irqLatch   uint8 // $C000 even
+	irqCounter uint8
+	irqReload  bool // $C001 odd
+	irqEnabled bool // $E000 even disables, $E001 odd enables
+	irqPending bool
+
+	// Rev A (MMC3A and MMC6) only raises an IRQ when the counter reaches zero
+	// by decrementing or by an explicit reload, so a latch of 0 raises one IRQ
+	// rather than one every scanline.
+	revA bool
+	mmc6 bool
+
+	a12         bool
+	a12LowCount uint8
+}
+
+func newMMC3(c *Cartridge) Mapper {
+	m := &mmc3{
+		baseMapper: newBaseMapper(c),
+		fourScreen: c.Header.Mirroring == FourScreen,
+		mmc6:       c.Header.Format == NES20 && c.Header.Submapper == mmc3SubmapperMMC6,
+	}
+	m.revA = m.mmc6 || (c.Header.Format == NES20 && c.Header.Submapper == mmc3SubmapperMMC3A)
+	if m.mmc6 {
+		// the MMC6 has 1KB of internal PRG-RAM regardless of the header
+		m.prgRAM = make([]byte, 1024)
+	} else {
+		m.prgRAMCtrl = 0x80
+	}
+	m.registers = [8]uint8{0, 2, 4, 5, 6, 7, 0, 1}
+	return m
+}
+
+// CPURead reads a byte from PRG-RAM or the banked PRG-ROM.
+func (m *mmc3) CPURead(address uint16) uint8 {
+	switch {
+	case address >= 0x8000:
+		return m.readPRG(m.prgBankAt(address), 0x2000, address)
+	case m.mmc6 && address >= 0x7000:
+		return m.readMMC6RAM(address)
+	case !m.mmc6 && address >= 0x6000 && m.prgRAMEnabled(address):
+		return m.readPRGRAM(address)
+	default:
+		return 0
+	}
+}
+
+// PRGOffset returns the offset into PRG-ROM mapped at an address.
+func (m *mmc3) PRGOffset(address uint16) (int, bool) {
+	if address < 0x8000 {
+		return 0, false
+	}
+	return m.prgOffset(m.prgBankAt(address), 0x2000, address), true
+}
+
+// CPUWrite writes to PRG-RAM or one of the MMC3's registers. Registers are
+// selected by the address range and whether the address is even or odd.
+func (m *mmc3) CPUWrite(address uint16, data uint8) {
+	if address < 0x8000 {
+		switch {
+		case m.mmc6 && address >= 0x7000:
+			m.writeMMC6RAM(address, data)
+		case !m.mmc6 && address >= 0x6000 && m.prgRAMEnabled(address) && m.prgRAMCtrl&0x40 == 0:
+			m.writePRGRAM(address, data)
+		}
+		return
+	}
+
+	even := address&0x01 == 0
+	switch {
+	case address < 0xa000 && even:
+		m.bankSelect = data
+	case address < 0xa000:
+		m.registers[m.bankSelect&0x07] = data
+	case address < 0xc000 && even:
+		if data&0x01 > 0 {
+			m.mirroring = Horizontal
+		} else {
+			m.mirroring = Vertical
+		}
+	case address < 0xc000:
+		// the MMC6 ignores protect writes until its RAM is enabled through $8000
+		if !m.mmc6 || m.bankSelect&0x20 > 0 {
+			m.prgRAMCtrl = data
+		}
+	case address < 0xe000 && even:
+		m.irqLatch = data
+	case address < 0xe000:
+		m.irqCounter = 0
+		m.irqReload = true
+	case even:
+		m.irqEnabled = false
+		m.irqPending = false
+	default:
+		m.irqEnabled = true
+	}
+}
+
+// prgBankAt returns the 8KB PRG-ROM bank mapped at an address in $8000-$FFFF.
+func (m *mmc3) prgBankAt(address uint16) int {
+	swapped := m.bankSelect&0x40 > 0
+	switch (address >> 13) & 0x03 {
+	case 0:
+		if swapped {
+			return -2
+		}
+		return int(m.registers[6] & 0x3f)
+	case 1:
+		return int(m.registers[7] & 0x3f)
+	case 2:
+		if swapped {
+			return int(m.registers[6] & 0x3f)
+		}
+		return -2
+	default:
+		return -1
+	}
+}
+
+// prgRAMEnabled returns whether PRG-RAM is enabled at an address in
+// $6000-$7FFF. The MMC6's RAM only appears at $7000-$7FFF, and is open bus
+// only when both of its halves are disabled for reading.
+func (m *mmc3) prgRAMEnabled(address uint16) bool {
+	if m.mmc6 {
+		return address >= 0x7000 && m.bankSelect&0x20 > 0 && m.prgRAMCtrl&0xa0 > 0
+	}
+	return len(m.prgRAM) > 0 && m.prgRAMCtrl&0x80 > 0
+}
+
+// readMMC6RAM reads the MMC6's 1KB of PRG-RAM, mirrored across $7000-$7FFF.
+// Each 512 byte half can be enabled for reading separately; a disabled half
+// reads as 0 while the other half is enabled.
+func (m *mmc3) readMMC6RAM(address uint16) uint8 {
+	offset := int(address & 0x03ff)
+	readBit := uint8(0x20)
+	if offset >= 0x200 {
+		readBit = 0x80
+	}
+	if m.bankSelect&0x20 == 0 || m.prgRAMCtrl&readBit == 0 {
+		return 0
+	}
+	return m.prgRAM[offset]
+}
+
+// writeMMC6RAM writes the MMC6's PRG-RAM if the half being written is enabled
+// for both reading and writing.
+func (m *mmc3) writeMMC6RAM(address uint16, data uint8) {
+	offset := int(address & 0x03ff)
+	enableBits := uint8(0x30)
+	if offset >= 0x200 {
+		enableBits = 0xc0
+	}
+	if m.bankSelect&0x20 > 0 && m.prgRAMCtrl&enableBits == enableBits {
+		m.prgRAM[offset] = data
+	}
+}
+
+// PPURead reads a byte from the banked CHR memory.
+func (m *mmc3) PPURead(address uint16) uint8 {
+	m.observeA12(address)
+	return m.readCHR(m.chrBankAt(address), 0x0400, address)
+}
+
+// PPUWrite writes a byte to the banked CHR memory.
+func (m *mmc3) PPUWrite(address uint16, data uint8) {
+	m.observeA12(address)
+	m.writeCHR(m.chrBankAt(address), 0x0400, address, data)
+}
+
+// chrBankAt returns the 1KB CHR bank mapped at an address in $0000-$1FFF. R0
+// and R1 select 2KB banks and R2-R5 select 1KB banks, with the two halves of
+// the pattern table swapped when bit 7 of the bank select register is set.
+func (m *mmc3) chrBankAt(address uint16) int {
+	slot := (address >> 10) & 0x07
+	if m.bankSelect&0x80 > 0 {
+		slot ^= 0x04
+	}
+	switch slot {
+	case 0, 1:
+		return int(m.registers[0]&0xfe) | int(slot&0x01)
+	case 2, 3:
+		return int(m.registers[1]&0xfe) | int(slot&0x01)
+	default:
+		return int(m.registers[slot-2])
+	}
+}
+
+// observeA12 watches PPU address line A12 and clocks the scanline counter on
+// filtered rising edges.
+func (m *mmc3) observeA12(address uint16) {
+	a12 := address&0x1000 > 0
+	if a12 && !m.a12 && m.a12LowCount >= a12FilterCycles {
+		m.clockScanlineCounter()
+	}
+	if a12 {
+		m.a12LowCount = 0
+	}
+	m.a12 = a12
+}
+
+// clockScanlineCounter clocks the IRQ counter once.
+func (m *mmc3) clockScanlineCounter() {
+	wasZero := m.irqCounter == 0
+	reloaded := m.irqReload
+	if wasZero || m.irqReload {
+		m.irqCounter = m.irqLatch
+	} else {
+		m.irqCounter--
+	}
+	m.irqReload = false
+
+	if m.irqCounter == 0 && m.irqEnabled && (!m.revA || !wasZero || reloaded) {
+		m.irqPending = true
+	}
+}
+
+// Mirroring returns the mirroring mode selected through $A000, or four-screen
+// if the board has its own nametable RAM.
+func (m *mmc3) Mirroring() Mirroring {
+	if m.fourScreen {
+		return FourScreen
+	}
+	return m.mirroring
+}
+
+// IRQ returns whether the scanline counter is asserting the IRQ line. It stays
+// asserted until acknowledged by a write to $E000.
+func (m *mmc3) IRQ() bool {
+	return m.irqPending
+}
+
+// Clock counts how many CPU cycles PPU A12 has been low for.
+func (m *mmc3) Clock() {
+	if !m.a12 && m.a12LowCount < a12FilterCycles {
+		m.a12LowCount++
+	}
+}
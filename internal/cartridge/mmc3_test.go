@@ -0,0 +1,258 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scanline simulates the PPU A12 activity of one rendered scanline with
+// backgrounds at $0000 and sprites at $1000: A12 stays low for the background
+// fetches and then rises for the sprite fetches.
+func scanline(c *Cartridge) {
+	c.PPURead(0x0000)
+	for i := 0; i < 100; i++ {
+		c.Clock()
+	}
+	c.PPURead(0x1000)
+	c.PPURead(0x0000)
+	c.PPURead(0x1000)
+}
+
+func TestMMC3_PRGBanking(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bankSelect   uint8
+		r6           uint8
+		r7           uint8
+		expected8000 uint8
+		expectedA000 uint8
+		expectedC000 uint8
+		expectedE000 uint8
+	}{
+		{
+			name:         "mode 0 switches $8000 and fixes second last bank at $C000",
+			bankSelect:   0x00,
+			r6:           0x03,
+			r7:           0x05,
+			expected8000: 0x03,
+			expectedA000: 0x05,
+			expectedC000: 0x0e,
+			expectedE000: 0x0f,
+		},
+		{
+			name:         "mode 1 switches $C000 and fixes second last bank at $8000",
+			bankSelect:   0x40,
+			r6:           0x03,
+			r7:           0x05,
+			expected8000: 0x0e,
+			expectedA000: 0x05,
+			expectedC000: 0x03,
+			expectedE000: 0x0f,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// fill each 8KB bank with its index
+			rom := newROMBuilder(8, 1).mapper(4)
+			for i := range rom.prg {
+				rom.prg[i] = byte(i / 0x2000)
+			}
+			b, _ := attach(t, rom)
+			b.Write(0x8000, 0x06)
+			b.Write(0x8001, tc.r6)
+			b.Write(0x8000, 0x07)
+			b.Write(0x8001, tc.r7)
+			b.Write(0x8000, tc.bankSelect)
+
+			assert.Equal(t, tc.expected8000, b.Read(0x8000), "incorrect bank at $8000")
+			assert.Equal(t, tc.expectedA000, b.Read(0xa000), "incorrect bank at $A000")
+			assert.Equal(t, tc.expectedC000, b.Read(0xc000), "incorrect bank at $C000")
+			assert.Equal(t, tc.expectedE000, b.Read(0xe000), "incorrect bank at $E000")
+		})
+	}
+}
+
+func TestMMC3_CHRBanking(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bankSelect uint8
+		expected   [8]uint8
+	}{
+		{
+			name:       "2KB banks at $0000",
+			bankSelect: 0x00,
+			expected:   [8]uint8{0x10, 0x11, 0x20, 0x21, 0x02, 0x03, 0x04, 0x05},
+		},
+		{
+			name:       "A12 inversion puts 2KB banks at $1000",
+			bankSelect: 0x80,
+			expected:   [8]uint8{0x02, 0x03, 0x04, 0x05, 0x10, 0x11, 0x20, 0x21},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(2, 8).fillCHR(0x0400).mapper(4))
+			for r, bank := range []uint8{0x11, 0x20, 0x02, 0x03, 0x04, 0x05} {
+				b.Write(0x8000, uint8(r))
+				b.Write(0x8001, bank)
+			}
+			b.Write(0x8000, tc.bankSelect)
+
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected, c.PPURead(uint16(i)*0x0400), "incorrect bank at $%04X", i*0x0400)
+			}
+		})
+	}
+}
+
+func TestMMC3_Mirroring(t *testing.T) {
+	b, c := attach(t, newROMBuilder(2, 1).mapper(4))
+	b.Write(0xa000, 0x01)
+	assert.Equal(t, Horizontal, c.Mirroring())
+	b.Write(0xbffe, 0x00)
+	assert.Equal(t, Vertical, c.Mirroring())
+
+	b, c = attach(t, newROMBuilder(2, 1).flags6(0x08).mapper(4))
+	b.Write(0xa000, 0x01)
+	assert.Equal(t, FourScreen, c.Mirroring())
+}
+
+func TestMMC3_PRGRAMProtect(t *testing.T) {
+	b, _ := attach(t, newROMBuilder(2, 1).mapper(4))
+	b.Write(0x6000, 0x42)
+	assert.Equal(t, uint8(0x42), b.Read(0x6000))
+
+	b.Write(0xa001, 0xc0)
+	b.Write(0x6000, 0x24)
+	assert.Equal(t, uint8(0x42), b.Read(0x6000), "write protected RAM was written")
+
+	b.Write(0xa001, 0x00)
+	b.Write(0x0000, 0x99)
+	assert.Equal(t, uint8(0x99), b.Read(0x6000), "disabled RAM should be open bus")
+}
+
+func TestMMC3_IRQ(t *testing.T) {
+	testCases := []struct {
+		name             string
+		submapper        uint8
+		latch            uint8
+		scanlines        int
+		acknowledgeOnIRQ bool
+		expectedIRQCount int
+	}{
+		{
+			name:             "counter raises IRQ after latch+1 scanlines",
+			latch:            3,
+			scanlines:        4,
+			expectedIRQCount: 1,
+		},
+		{
+			name:             "counter reloads and raises IRQ periodically",
+			latch:            3,
+			scanlines:        12,
+			acknowledgeOnIRQ: true,
+			expectedIRQCount: 3,
+		},
+		{
+			name:             "Rev B raises IRQ every scanline with latch 0",
+			latch:            0,
+			scanlines:        5,
+			acknowledgeOnIRQ: true,
+			expectedIRQCount: 5,
+		},
+		{
+			name:             "Rev A raises one IRQ with latch 0",
+			submapper:        mmc3SubmapperMMC3A,
+			latch:            0,
+			scanlines:        5,
+			acknowledgeOnIRQ: true,
+			expectedIRQCount: 1,
+		},
+		{
+			name:             "MMC6 behaves like Rev A",
+			submapper:        mmc3SubmapperMMC6,
+			latch:            0,
+			scanlines:        5,
+			acknowledgeOnIRQ: true,
+			expectedIRQCount: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, c := attach(t, newROMBuilder(2, 1).nes20(tc.submapper).mapper(4))
+			b.Write(0xc000, tc.latch)
+			b.Write(0xc001, 0x00)
+			b.Write(0xe001, 0x00)
+
+			irqs := 0
+			for i := 0; i < tc.scanlines; i++ {
+				scanline(c)
+				if c.IRQ() {
+					irqs++
+					if tc.acknowledgeOnIRQ {
+						b.Write(0xe000, 0x00)
+						b.Write(0xe001, 0x00)
+					}
+				}
+			}
+
+			assert.Equal(t, tc.expectedIRQCount, irqs)
+		})
+	}
+}
+
+func TestMMC3_IRQLine(t *testing.T) {
+	b, c := attach(t, newROMBuilder(2, 1).mapper(4))
+	b.Write(0xc000, 0x00)
+	b.Write(0xe001, 0x00)
+	scanline(c)
+	assert.True(t, c.IRQ())
+
+	for i := 0; i < 10; i++ {
+		c.Clock()
+	}
+	assert.True(t, c.IRQ(), "IRQ should stay asserted until acknowledged")
+
+	b.Write(0xe000, 0x00)
+	assert.False(t, c.IRQ(), "write to $E000 should acknowledge IRQ")
+}
+
+func TestMMC3_A12Filter(t *testing.T) {
+	b, c := attach(t, newROMBuilder(2, 1).mapper(4))
+	b.Write(0xc000, 0x00)
+	b.Write(0xe001, 0x00)
+
+	// A12 toggling faster than the filter, as during sprite fetches, is ignored
+	c.PPURead(0x0000)
+	c.Clock()
+	c.PPURead(0x1000)
+	assert.False(t, c.IRQ())
+
+	c.PPURead(0x0000)
+	c.Clock()
+	c.Clock()
+	c.Clock()
+	c.PPURead(0x1000)
+	assert.True(t, c.IRQ())
+}
+
+func TestMMC6_PRGRAM(t *testing.T) {
+	b, _ := attach(t, newROMBuilder(2, 1).nes20(mmc3SubmapperMMC6).mapper(4))
+	b.Write(0x0000, 0x99)
+	assert.Equal(t, uint8(0x99), b.Read(0x7000), "RAM should be open bus until enabled")
+
+	b.Write(0x8000, 0x20)
+	b.Write(0xa001, 0xf0)
+	b.Write(0x7000, 0x11)
+	b.Write(0x7200, 0x22)
+	assert.Equal(t, uint8(0x11), b.Read(0x7400), "RAM should be mirrored every 1KB")
+	assert.Equal(t, uint8(0x22), b.Read(0x7e00), "RAM should be mirrored every 1KB")
+
+	b.Write(0x0000, 0x99)
+	assert.Equal(t, uint8(0x99), b.Read(0x6000), "$6000-$6FFF should be open bus")
+
+	b.Write(0xa001, 0x80)
+	assert.Equal(t, uint8(0x00), b.Read(0x7000), "disabled half should read 0")
+	assert.Equal(t, uint8(0x22), b.Read(0x7200))
+}
@@ -0,0 +1,48 @@
+package cartridge
+
+// nrom is mapper 0, boards with no bank switching. 16KB of PRG-ROM is mirrored
+// at $8000 and $C000, while 32KB fills the whole range.
+type nrom struct {
+	baseMapper
+}
+
+func newNROM(c *Cartridge) Mapper {
+	return &nrom{baseMapper: newBaseMapper(c)}
+}
+
+// CPURead reads a byte from PRG-RAM or PRG-ROM.
+func (m *nrom) CPURead(address uint16) uint8 {
+	switch {
+	case address >= 0x8000:
+		return m.readPRG(0, len(m.prgROM), address-0x8000)
+	case address >= 0x6000:
+		return m.readPRGRAM(address)
+	default:
+		return 0
+	}
+}
+
+// PRGOffset returns the offset into PRG-ROM mapped at an address.
+func (m *nrom) PRGOffset(address uint16) (int, bool) {
+	if address < 0x8000 {
+		return 0, false
+	}
+	return m.prgOffset(0, len(m.prgROM), address-0x8000), true
+}
+
+// CPUWrite writes a byte to PRG-RAM.
+func (m *nrom) CPUWrite(address uint16, data uint8) {
+	if address >= 0x6000 && address < 0x8000 {
+		m.writePRGRAM(address, data)
+	}
+}
+
+// PPURead reads a byte from the only CHR bank.
+func (m *nrom) PPURead(address uint16) uint8 {
+	return m.readCHR(0, 0x2000, address)
+}
+
+// PPUWrite writes a byte to the only CHR bank.
+func (m *nrom) PPUWrite(address uint16, data uint8) {
+	m.writeCHR(0, 0x2000, address, data)
+}
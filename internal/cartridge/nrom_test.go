@@ -0,0 +1,54 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNROM(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rom          *romBuilder
+		writes       []busWrite
+		address      uint16
+		expectedData uint8
+	}{
+		{
+			name:         "NROM-128 mirrors bank 0 at $C000",
+			rom:          newROMBuilder(1, 1),
+			address:      0xc000,
+			expectedData: 0x00,
+		},
+		{
+			name:         "NROM-256 maps bank 1 at $C000",
+			rom:          newROMBuilder(2, 1),
+			address:      0xc000,
+			expectedData: 0x01,
+		},
+		{
+			name:         "writes to PRG-ROM do not switch banks",
+			rom:          newROMBuilder(2, 1),
+			writes:       []busWrite{{0x8000, 0x00}, {0xc000, 0x00}},
+			address:      0xc000,
+			expectedData: 0x01,
+		},
+		{
+			name:         "PRG-RAM is read and writable",
+			rom:          newROMBuilder(1, 1),
+			writes:       []busWrite{{0x7fff, 0x42}},
+			address:      0x7fff,
+			expectedData: 0x42,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, _ := attach(t, tc.rom.mapper(0))
+			for _, w := range tc.writes {
+				b.Write(w.address, w.data)
+			}
+
+			assert.Equal(t, tc.expectedData, b.Read(tc.address))
+		})
+	}
+}
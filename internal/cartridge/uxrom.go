@@ -0,0 +1,60 @@
+package cartridge
+
+// uxrom is mapper 2, the UNROM/UOROM boards. A 16KB PRG-ROM bank selected by
+// writes to $8000-$FFFF is mapped at $8000, and the last bank is fixed at
+// $C000. CHR is an unbanked 8KB, usually RAM.
+type uxrom struct {
+	baseMapper
+
+	prgBank uint8
+}
+
+func newUxROM(c *Cartridge) Mapper {
+	return &uxrom{baseMapper: newBaseMapper(c)}
+}
+
+// CPURead reads a byte from PRG-RAM or the banked PRG-ROM.
+func (m *uxrom) CPURead(address uint16) uint8 {
+	switch {
+	case address >= 0xc000:
+		return m.readPRG(-1, 0x4000, address)
+	case address >= 0x8000:
+		return m.readPRG(int(m.prgBank), 0x4000, address)
+	case address >= 0x6000:
+		return m.readPRGRAM(address)
+	default:
+		return 0
+	}
+}
+
+// PRGOffset returns the offset into PRG-ROM mapped at an address.
+func (m *uxrom) PRGOffset(address uint16) (int, bool) {
+	switch {
+	case address >= 0xc000:
+		return m.prgOffset(-1, 0x4000, address), true
+	case address >= 0x8000:
+		return m.prgOffset(int(m.prgBank), 0x4000, address), true
+	default:
+		return 0, false
+	}
+}
+
+// CPUWrite writes to PRG-RAM or selects the PRG-ROM bank at $8000.
+func (m *uxrom) CPUWrite(address uint16, data uint8) {
+	switch {
+	case address >= 0x8000:
+		m.prgBank = data
+	case address >= 0x6000:
+		m.writePRGRAM(address, data)
+	}
+}
+
+// PPURead reads a byte from the only CHR bank.
+func (m *uxrom) PPURead(address uint16) uint8 {
+	return m.readCHR(0, 0x2000, address)
+}
+
+// PPUWrite writes a byte to the only CHR bank.
+func (m *uxrom) PPUWrite(address uint16, data uint8) {
+	m.writeCHR(0, 0x2000, address, data)
+}
@@ -0,0 +1,44 @@
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUxROM(t *testing.T) {
+	testCases := []struct {
+		name         string
+		bank         uint8
+		expected8000 uint8
+		expectedC000 uint8
+	}{
+		{
+			name:         "power on maps first and last banks",
+			bank:         0x00,
+			expected8000: 0x00,
+			expectedC000: 0x07,
+		},
+		{
+			name:         "bank select switches $8000 only",
+			bank:         0x03,
+			expected8000: 0x03,
+			expectedC000: 0x07,
+		},
+		{
+			name:         "bank numbers wrap around PRG-ROM size",
+			bank:         0x0e,
+			expected8000: 0x06,
+			expectedC000: 0x07,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, _ := attach(t, newROMBuilder(8, 0).mapper(2))
+			b.Write(0xffff, tc.bank)
+
+			assert.Equal(t, tc.expected8000, b.Read(0x8000), "incorrect bank at $8000")
+			assert.Equal(t, tc.expectedC000, b.Read(0xc000), "incorrect bank at $C000")
+		})
+	}
+}
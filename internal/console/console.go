@@ -0,0 +1,214 @@
+package console
+
+import (
+	"github.com/Jac0bDeal/goNES/internal/apu"
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/controller"
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+	"github.com/Jac0bDeal/goNES/internal/ppu"
+)
+
+// NES is a console with a cartridge inserted. It owns the bus and every
+// component attached to it, and clocks them from a master clock divided down
+// as in the console's Region.
+type NES struct {
+	region Region
+	timing timing
+
+	bus   *bus.Bus
+	cpu   *cpu.Mos6502
+	ppu   *ppu.PPU
+	apu   *apu.APU
+	cart  *cartridge.Cartridge
+	ports *controller.Ports
+
+	// Master clock
+	cpuWait int    // master clock cycles until the CPU is next clocked
+	ppuWait int    // master clock cycles until the PPU is next clocked
+	cycles  uint64 // CPU cycles since power on
+
+	audio func(sample float32)
+}
+
+// New constructs and returns a pointer to a NES for a Region with a cartridge
+// inserted and nothing plugged into its controller ports, and powers it on.
+func New(cart *cartridge.Cartridge, region Region) (*NES, error) {
+	if !region.valid() {
+		return nil, &RegionError{Region: region}
+	}
+
+	n := &NES{
+		region: region,
+		timing: timings[region],
+		bus:    bus.NewNESBus(),
+		cpu:    cpu.NewMos6502(cpu.RP2A03),
+		ppu:    ppu.New(cart),
+		cart:   cart,
+	}
+	n.ppu.SetTiming(n.timing.ppu)
+	n.apu = apu.New(n.bus.DMA(), n.timing.apu)
+	n.ports = controller.NewPorts(n.apu)
+
+	if err := n.bus.Attach(n.ppu, bus.PPUStart, bus.PPUEnd); err != nil {
+		return nil, err
+	}
+	if err := n.bus.Attach(n.apu, apu.ChannelsStart, apu.ChannelsEnd); err != nil {
+		return nil, err
+	}
+	if err := n.bus.Attach(n.apu, apu.Status, apu.Status); err != nil {
+		return nil, err
+	}
+	if err := n.bus.Attach(n.ports, controller.PortsStart, controller.PortsEnd); err != nil {
+		return nil, err
+	}
+	if err := n.bus.Attach(cart, bus.CartridgeStart, bus.CartridgeEnd); err != nil {
+		return nil, err
+	}
+	n.cpu.ConnectBus(n.bus)
+
+	n.powerOn()
+	return n, nil
+}
+
+// powerOn resets the CPU and master clock, as happens when the console is
+// switched on.
+func (n *NES) powerOn() {
+	n.cpu.Reset()
+	n.cpuWait = n.timing.cpuDivider
+	n.ppuWait = n.timing.ppuDivider
+	n.cycles = 0
+}
+
+// Region returns the console's Region.
+func (n *NES) Region() Region {
+	return n.region
+}
+
+// Bus returns the CPU bus.
+func (n *NES) Bus() *bus.Bus {
+	return n.bus
+}
+
+// CPU returns the CPU.
+func (n *NES) CPU() *cpu.Mos6502 {
+	return n.cpu
+}
+
+// PPU returns the PPU.
+func (n *NES) PPU() *ppu.PPU {
+	return n.ppu
+}
+
+// APU returns the APU.
+func (n *NES) APU() *apu.APU {
+	return n.apu
+}
+
+// Cartridge returns the inserted cartridge.
+func (n *NES) Cartridge() *cartridge.Cartridge {
+	return n.cart
+}
+
+// Ports returns the controller ports, which peripherals are plugged into.
+func (n *NES) Ports() *controller.Ports {
+	return n.ports
+}
+
+// Cycles returns the number of CPU cycles run since the console was powered
+// on.
+func (n *NES) Cycles() uint64 {
+	return n.cycles
+}
+
+// SetAudioOutput sets a function to call with the APU's output every CPU
+// cycle, at the Region's CPUClockRate. A nil function discards the output.
+func (n *NES) SetAudioOutput(f func(sample float32)) {
+	n.audio = f
+}
+
+// Clock advances the console by one CPU cycle, clocking the PPU for each of
+// its dots that falls due on the master clock before it.
+func (n *NES) Clock() {
+	for {
+		step := n.cpuWait
+		if n.ppuWait < step {
+			step = n.ppuWait
+		}
+		n.cpuWait -= step
+		n.ppuWait -= step
+
+		if n.ppuWait == 0 {
+			n.ppu.Clock()
+			n.ppuWait = n.timing.ppuDivider
+		}
+		if n.cpuWait == 0 {
+			n.clockCPU()
+			n.cpuWait = n.timing.cpuDivider
+			return
+		}
+	}
+}
+
+// clockCPU clocks the CPU and the components clocked alongside it, after
+// driving the CPU's interrupt lines from the devices connected to them.
+func (n *NES) clockCPU() {
+	n.cpu.SetNMI(n.ppu.NMI())
+	n.cpu.SetIRQ(cpu.IRQMapper, n.cart.IRQ())
+	n.cpu.SetIRQ(cpu.IRQFrameCounter, n.apu.FrameIRQ())
+	n.cpu.SetIRQ(cpu.IRQDMC, n.apu.DMCIRQ())
+
+	n.cpu.Clock()
+	n.apu.Clock()
+	n.cart.Clock()
+	n.cycles++
+
+	if n.audio != nil {
+		n.audio(n.apu.Sample())
+	}
+}
+
+// StepInstruction runs the console until the CPU finishes its next
+// instruction or interrupt sequence, including any DMA transfer that halts
+// the CPU before it starts.
+func (n *NES) StepInstruction() {
+	n.Clock()
+	for n.cpu.Complete() && !n.cpu.Jammed() {
+		// the CPU was halted by DMA rather than starting an instruction
+		n.Clock()
+	}
+	for !n.cpu.Complete() {
+		n.Clock()
+	}
+}
+
+// StepFrame runs the console until the PPU starts its next frame.
+func (n *NES) StepFrame() {
+	frame := n.ppu.Frame()
+	for n.ppu.Frame() == frame {
+		n.Clock()
+	}
+}
+
+// Reset presses the console's reset button, which resets the CPU, PPU and APU
+// and signals the cartridge, but leaves RAM as it was.
+func (n *NES) Reset() {
+	n.cpu.Reset()
+	n.ppu.Reset()
+	n.apu.Reset()
+	n.cart.Reset()
+}
+
+// PowerCycle switches the console off and on again, returning every component
+// to its power on state. Internal RAM and the cartridge's RAM keep their
+// contents, which are unpredictable after power on on real hardware.
+func (n *NES) PowerCycle() {
+	n.cart.PowerCycle()
+	*n.bus.DMA() = *bus.NewDMA(n.bus)
+	*n.ppu = *ppu.New(n.cart)
+	n.ppu.SetTiming(n.timing.ppu)
+	*n.apu = *apu.New(n.bus.DMA(), n.timing.apu)
+	n.cpu.PowerCycle()
+
+	n.powerOn()
+}
@@ -0,0 +1,260 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/controller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProgram enables NMIs and loops, counting NMIs in X.
+var testProgram = map[uint16][]byte{
+	0xc000: {
+		0x78,             // SEI
+		0xad, 0x00, 0xd0, // LDA $D000
+		0x8d, 0x00, 0x20, // STA $2000
+		0x4c, 0x07, 0xc0, // JMP $C007
+	},
+	0xc100: {
+		0xe8, // INX
+		0x40, // RTI
+	},
+	0xd000: {0x80},
+	0xfffa: {0x00, 0xc1, 0x00, 0xc0, 0x00, 0xc1},
+}
+
+// newTestNES creates a NES for a Region running testProgram from an NROM
+// cartridge.
+func newTestNES(t *testing.T, region Region) *NES {
+	t.Helper()
+	prg := make([]byte, 0x4000)
+	for address, data := range testProgram {
+		copy(prg[address-0xc000:], data)
+	}
+	rom := append([]byte{'N', 'E', 'S', 0x1a, 0x01, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, prg...)
+	rom = append(rom, make([]byte, 0x2000)...)
+	cart, err := cartridge.New(rom)
+	require.NoError(t, err)
+
+	n, err := New(cart, region)
+	require.NoError(t, err)
+	return n
+}
+
+// dots returns the number of dots the PPU has drawn in the current frame.
+func dots(n *NES) int {
+	return n.PPU().Scanline()*341 + n.PPU().Dot()
+}
+
+func TestNew(t *testing.T) {
+	n := newTestNES(t, PAL)
+
+	assert.Equal(t, PAL, n.Region())
+	assert.Equal(t, uint64(0), n.Cycles())
+	assert.Equal(t, uint16(0xc000), n.CPU().GetProgramCounter(), "CPU not reset")
+
+	_, err := New(n.Cartridge(), Region(3))
+	assert.EqualError(t, err, "console: unknown region Region(3)")
+}
+
+func TestNES_Clock(t *testing.T) {
+	testCases := []struct {
+		name         string
+		region       Region
+		cycles       int
+		expectedDots int
+	}{
+		{
+			name:         "NTSC runs 3 dots per CPU cycle",
+			region:       NTSC,
+			cycles:       10,
+			expectedDots: 30,
+		},
+		{
+			name:         "PAL runs 3.2 dots per CPU cycle",
+			region:       PAL,
+			cycles:       10,
+			expectedDots: 32,
+		},
+		{
+			name:         "PAL dots fall unevenly",
+			region:       PAL,
+			cycles:       3,
+			expectedDots: 9,
+		},
+		{
+			name:         "Dendy runs 3 dots per CPU cycle",
+			region:       Dendy,
+			cycles:       10,
+			expectedDots: 30,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := newTestNES(t, tc.region)
+			for i := 0; i < tc.cycles; i++ {
+				n.Clock()
+			}
+
+			assert.Equal(t, uint64(tc.cycles), n.Cycles())
+			assert.Equal(t, tc.expectedDots, dots(n))
+		})
+	}
+}
+
+func TestNES_FrameIRQ(t *testing.T) {
+	testCases := []struct {
+		region         Region
+		expectedCycles uint64
+	}{
+		{region: NTSC, expectedCycles: 29828},
+		{region: PAL, expectedCycles: 33252},
+		{region: Dendy, expectedCycles: 29828},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.region.String(), func(t *testing.T) {
+			n := newTestNES(t, tc.region)
+
+			for !n.APU().FrameIRQ() {
+				n.Clock()
+			}
+
+			assert.Equal(t, tc.expectedCycles, n.Cycles())
+		})
+	}
+}
+
+func TestNES_StepFrame(t *testing.T) {
+	testCases := []struct {
+		name           string
+		region         Region
+		expectedCycles uint64
+	}{
+		{
+			name:           "NTSC",
+			region:         NTSC,
+			expectedCycles: 89342,
+		},
+		{
+			name:           "PAL",
+			region:         PAL,
+			expectedCycles: 99743,
+		},
+		{
+			name:           "Dendy",
+			region:         Dendy,
+			expectedCycles: 106392,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := newTestNES(t, tc.region)
+			for i := 0; i < 3; i++ {
+				n.StepFrame()
+			}
+
+			assert.Equal(t, uint64(3), n.PPU().Frame())
+			assert.Equal(t, tc.expectedCycles, n.Cycles())
+			assert.Equal(t, uint8(3), n.CPU().GetX(), "NMIs not delivered")
+		})
+	}
+}
+
+func TestNES_StepInstruction(t *testing.T) {
+	n := newTestNES(t, NTSC)
+
+	expected := []struct {
+		pc     uint16
+		cycles uint64
+	}{
+		{pc: 0xc000, cycles: 8}, // reset sequence
+		{pc: 0xc001, cycles: 10},
+		{pc: 0xc004, cycles: 14},
+		{pc: 0xc007, cycles: 18},
+		{pc: 0xc007, cycles: 21},
+	}
+	for _, e := range expected {
+		n.StepInstruction()
+
+		assert.Equal(t, e.pc, n.CPU().GetProgramCounter())
+		assert.Equal(t, e.cycles, n.Cycles())
+	}
+}
+
+func TestNES_StepInstruction_DMA(t *testing.T) {
+	n := newTestNES(t, NTSC)
+	n.StepInstruction()
+	n.Bus().Write(0x4014, 0x02)
+
+	start := n.Cycles()
+	n.StepInstruction()
+
+	assert.Equal(t, uint16(0xc001), n.CPU().GetProgramCounter(), "instruction not run after DMA")
+	assert.Equal(t, uint64(513+2), n.Cycles()-start)
+}
+
+func TestNES_Reset(t *testing.T) {
+	n := newTestNES(t, NTSC)
+	n.StepFrame()
+	n.StepFrame()
+	n.Bus().Write(0x0000, 0x5a)
+
+	n.Reset()
+	n.StepInstruction()
+
+	assert.Equal(t, uint16(0xc000), n.CPU().GetProgramCounter())
+	assert.Equal(t, uint64(0), n.PPU().Frame())
+	assert.Equal(t, uint8(0x5a), n.Bus().Read(0x0000), "RAM cleared")
+}
+
+func TestNES_PowerCycle(t *testing.T) {
+	n := newTestNES(t, PAL)
+	joypad := controller.NewJoypad(nil)
+	n.Ports().Connect(controller.Port1, joypad)
+	c := n.CPU()
+	n.StepFrame()
+	n.StepFrame()
+
+	n.PowerCycle()
+
+	assert.Equal(t, uint64(0), n.Cycles())
+	assert.Equal(t, uint64(0), n.PPU().Frame())
+	assert.Equal(t, joypad, n.Ports().Device(controller.Port1), "peripherals unplugged")
+
+	for i := 0; i < 3; i++ {
+		n.StepFrame()
+	}
+	assert.Equal(t, uint64(99743), n.Cycles(), "not clocked for the region")
+	assert.Equal(t, uint8(3), n.CPU().GetX())
+	assert.Same(t, c, n.CPU(), "CPU replaced rather than reset in place")
+}
+
+func TestNES_SetAudioOutput(t *testing.T) {
+	n := newTestNES(t, NTSC)
+	samples := 0
+	n.SetAudioOutput(func(float32) { samples++ })
+
+	n.StepFrame()
+
+	assert.Equal(t, n.Cycles(), uint64(samples))
+}
+
+func TestNES_Controllers(t *testing.T) {
+	n := newTestNES(t, NTSC)
+	n.Ports().Connect(controller.Port2, controller.NewJoypad(controller.InputFunc(func() controller.Buttons {
+		return controller.B
+	})))
+
+	b := n.Bus()
+	b.Write(controller.PortsStart, 1)
+	b.Write(controller.PortsStart, 0)
+	// the high byte of the address is usually left on the data bus
+	b.Write(0x0000, 0x40)
+	b.Read(0x0000)
+
+	assert.Equal(t, uint8(0x40), b.Read(controller.PortsEnd))
+	assert.Equal(t, uint8(0x41), b.Read(controller.PortsEnd))
+	assert.Equal(t, uint8(0x40), b.Read(controller.PortsStart), "empty port")
+}
@@ -0,0 +1,99 @@
+package console
+
+import (
+	"fmt"
+
+	"github.com/Jac0bDeal/goNES/internal/apu"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/ppu"
+)
+
+// Region is the television standard a console is built for, which sets its
+// master clock, how the CPU and PPU clocks are divided from it, the PPU's frame
+// timing and the APU's frame counter and timer periods.
+type Region uint8
+
+// Regions.
+const (
+	NTSC  Region = iota // NTSC consoles sold in North America and Japan.
+	PAL                 // PAL consoles sold in Europe and Australia.
+	Dendy               // Dendy is the PAL Famiclone sold in Russia.
+)
+
+// timing is the clocking of a Region.
+type timing struct {
+	masterClock float64 // master clock rate in Hz
+	cpuDivider  int     // master clock cycles per CPU cycle
+	ppuDivider  int     // master clock cycles per PPU dot
+	ppu         ppu.Timing
+	apu         apu.Timing
+}
+
+// timings holds the clocking of each Region. The PPU runs 3 dots per CPU cycle
+// on NTSC and Dendy, and 3.2 on PAL. The Dendy's APU counts the same CPU cycles
+// as the NTSC 2A03.
+var timings = [...]timing{
+	NTSC:  {masterClock: 236250000.0 / 11, cpuDivider: 12, ppuDivider: 4, ppu: ppu.NTSCTiming, apu: apu.NTSCTiming},
+	PAL:   {masterClock: 26601712.5, cpuDivider: 16, ppuDivider: 5, ppu: ppu.PALTiming, apu: apu.PALTiming},
+	Dendy: {masterClock: 26601712.5, cpuDivider: 15, ppuDivider: 5, ppu: ppu.DendyTiming, apu: apu.NTSCTiming},
+}
+
+// RegionError is returned when a console is built for an unknown Region.
+type RegionError struct {
+	Region Region
+}
+
+func (e *RegionError) Error() string {
+	return fmt.Sprintf("console: unknown region %s", e.Region)
+}
+
+// RegionOf returns the Region to run a cartridge targeting a timing in.
+// Multi-region cartridges are run on NTSC.
+func RegionOf(t cartridge.Timing) Region {
+	switch t {
+	case cartridge.PAL:
+		return PAL
+	case cartridge.Dendy:
+		return Dendy
+	default:
+		return NTSC
+	}
+}
+
+// valid returns whether r is a known Region.
+func (r Region) valid() bool {
+	return int(r) < len(timings)
+}
+
+// String returns the name of the Region.
+func (r Region) String() string {
+	switch r {
+	case NTSC:
+		return "NTSC"
+	case PAL:
+		return "PAL"
+	case Dendy:
+		return "Dendy"
+	default:
+		return fmt.Sprintf("Region(%d)", uint8(r))
+	}
+}
+
+// CPUClockRate returns the rate at which the CPU, and so the APU, is clocked
+// in Hz.
+func (r Region) CPUClockRate() float64 {
+	t := timings[r]
+	return t.masterClock / float64(t.cpuDivider)
+}
+
+// FrameRate returns the number of frames the PPU draws per second while
+// rendering.
+func (r Region) FrameRate() float64 {
+	t := timings[r]
+	dots := float64(ppu.DotsPerScanline * t.ppu.ScanlinesPerFrame)
+	if t.ppu.SkipOddDot {
+		// every other frame is a dot short
+		dots -= 0.5
+	}
+	return t.masterClock / float64(t.ppuDivider) / dots
+}
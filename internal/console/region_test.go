@@ -0,0 +1,43 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionOf(t *testing.T) {
+	testCases := []struct {
+		timing   cartridge.Timing
+		expected Region
+	}{
+		{timing: cartridge.NTSC, expected: NTSC},
+		{timing: cartridge.PAL, expected: PAL},
+		{timing: cartridge.MultiRegion, expected: NTSC},
+		{timing: cartridge.Dendy, expected: Dendy},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.timing.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, RegionOf(tc.timing))
+		})
+	}
+}
+
+func TestRegion(t *testing.T) {
+	testCases := []struct {
+		region               Region
+		expectedCPUClockRate float64
+		expectedFrameRate    float64
+	}{
+		{region: NTSC, expectedCPUClockRate: 1789773, expectedFrameRate: 60.0988},
+		{region: PAL, expectedCPUClockRate: 1662607, expectedFrameRate: 50.0070},
+		{region: Dendy, expectedCPUClockRate: 1773448, expectedFrameRate: 50.0070},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.region.String(), func(t *testing.T) {
+			assert.InDelta(t, tc.expectedCPUClockRate, tc.region.CPUClockRate(), 1)
+			assert.InDelta(t, tc.expectedFrameRate, tc.region.FrameRate(), 0.0001)
+		})
+	}
+}
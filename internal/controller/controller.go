@@ -0,0 +1,92 @@
+package controller
+
+// Buttons is the state of a standard controller's buttons, one bit per button
+// in the order they are shifted out of the controller.
+type Buttons uint8
+
+// Standard controller buttons.
+const (
+	A Buttons = 1 << iota
+	B
+	Select
+	Start
+	Up
+	Down
+	Left
+	Right
+)
+
+// InputProvider is the source of a controller's input, such as a keyboard
+// frontend, a recorded movie, a script or a network peer. Buttons is called
+// each time the console latches the controller's state.
+type InputProvider interface {
+	Buttons() Buttons
+}
+
+// InputFunc adapts a function to an InputProvider.
+type InputFunc func() Buttons
+
+// Buttons returns f().
+func (f InputFunc) Buttons() Buttons {
+	return f()
+}
+
+// Joypad is the standard NES controller. While the strobe line is high it
+// continuously latches its buttons into a shift register, which is shifted out
+// a bit at a time by reads once the strobe goes low. After all eight buttons
+// have been read, it returns 1.
+type Joypad struct {
+	input  InputProvider
+	strobe bool
+	shift  uint8
+}
+
+// NewJoypad constructs and returns a pointer to a Joypad reading its buttons
+// from input. A nil input presses no buttons.
+func NewJoypad(input InputProvider) *Joypad {
+	return &Joypad{input: input}
+}
+
+// buttons returns the current state of the buttons.
+func (j *Joypad) buttons() uint8 {
+	if j.input == nil {
+		return 0
+	}
+	return uint8(j.input.Buttons())
+}
+
+// latch loads the shift register with the current state of the buttons.
+func (j *Joypad) latch() {
+	j.shift = j.buttons()
+}
+
+// Write sets the strobe line from bit 0 of data.
+func (j *Joypad) Write(data uint8) {
+	j.strobe = data&0x01 > 0
+	if j.strobe {
+		j.latch()
+	}
+}
+
+// Read returns the next button in bit 0, shifting the register unless the
+// strobe is high, in which case it returns the state of A.
+func (j *Joypad) Read() uint8 {
+	if j.strobe {
+		j.latch()
+		return j.shift & 0x01
+	}
+	bit := j.shift & 0x01
+	// official controllers shift in 1s behind the buttons
+	j.shift = j.shift>>1 | 0x80
+	return bit
+}
+
+// Peek returns the next button in bit 0 without shifting the register. While
+// the strobe is high it returns the live state of A, as Read would, without
+// latching it.
+func (j *Joypad) Peek() uint8 {
+	if j.strobe {
+		return j.buttons() & 0x01
+	}
+	return j.shift & 0x01
+}
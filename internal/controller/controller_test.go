@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readDevice reads n values from a Device.
+func readDevice(d Device, n int) []uint8 {
+	data := make([]uint8, n)
+	for i := range data {
+		data[i] = d.Read()
+	}
+	return data
+}
+
+func TestJoypad_Read(t *testing.T) {
+	testCases := []struct {
+		name     string
+		buttons  Buttons
+		expected []uint8
+	}{
+		{
+			name:     "no buttons",
+			buttons:  0,
+			expected: []uint8{0, 0, 0, 0, 0, 0, 0, 0, 1, 1},
+		},
+		{
+			name:     "A and Start",
+			buttons:  A | Start,
+			expected: []uint8{1, 0, 0, 1, 0, 0, 0, 0, 1, 1},
+		},
+		{
+			name:     "directions",
+			buttons:  Up | Left,
+			expected: []uint8{0, 0, 0, 0, 1, 0, 1, 0, 1, 1},
+		},
+		{
+			name:     "all buttons",
+			buttons:  0xff,
+			expected: []uint8{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := NewJoypad(InputFunc(func() Buttons { return tc.buttons }))
+			j.Write(1)
+			j.Write(0)
+
+			assert.Equal(t, tc.expected, readDevice(j, len(tc.expected)))
+		})
+	}
+}
+
+func TestJoypad_Strobe(t *testing.T) {
+	buttons := A | B
+	j := NewJoypad(InputFunc(func() Buttons { return buttons }))
+
+	j.Write(1)
+	assert.Equal(t, []uint8{1, 1, 1}, readDevice(j, 3), "strobe high returns A")
+
+	buttons = B
+	assert.Equal(t, uint8(0), j.Read(), "strobe high latches continuously")
+
+	j.Write(0)
+	buttons = A
+	assert.Equal(t, []uint8{0, 1, 0}, readDevice(j, 3), "strobe low holds the latched buttons")
+}
+
+func TestJoypad_Peek(t *testing.T) {
+	j := NewJoypad(InputFunc(func() Buttons { return B }))
+	j.Write(1)
+	j.Write(0)
+
+	assert.Equal(t, uint8(0), j.Peek())
+	assert.Equal(t, uint8(0), j.Peek(), "peek shifted")
+	j.Read()
+	assert.Equal(t, uint8(1), j.Peek())
+}
+
+func TestJoypad_Peek_Strobe(t *testing.T) {
+	buttons := B
+	j := NewJoypad(InputFunc(func() Buttons { return buttons }))
+	j.Write(1)
+
+	buttons = A
+	assert.Equal(t, uint8(1), j.Peek(), "peek returns the live A button")
+	assert.Equal(t, uint8(0), j.shift&0x01, "peek latched the buttons")
+	assert.Equal(t, j.Read(), j.Peek())
+
+	assert.Equal(t, uint8(0), NewJoypad(nil).Peek())
+	n := NewJoypad(nil)
+	n.Write(1)
+	assert.Equal(t, uint8(0), n.Peek())
+}
+
+func TestJoypad_NilInput(t *testing.T) {
+	j := NewJoypad(nil)
+	j.Write(1)
+	j.Write(0)
+
+	assert.Equal(t, []uint8{0, 0, 0, 0, 0, 0, 0, 0, 1}, readDevice(j, 9))
+}
@@ -0,0 +1,88 @@
+package controller
+
+// Four Score signatures, shifted out of each port after the two controllers
+// plugged into it. In the order they are read, $4016 returns 0, 0, 0, 1, 0, 0,
+// 0, 0 and $4017 returns 0, 0, 1, 0, 0, 0, 0, 0.
+const (
+	fourScoreSignature1 = 0x08
+	fourScoreSignature2 = 0x04
+)
+
+// FourScore is the NES Four Score adapter, which plugs into both controller
+// ports and lets four standard controllers be read through them. Each port
+// shifts out 24 bits: its first controller, the controller it shares the port
+// with, then a signature identifying the adapter. Players 1 and 3 are read from
+// $4016, and players 2 and 4 from $4017.
+type FourScore struct {
+	ports [2]fourScorePort
+}
+
+// NewFourScore constructs and returns a pointer to a FourScore reading the
+// buttons of players 1-4 from inputs. A nil input presses no buttons.
+func NewFourScore(inputs [4]InputProvider) *FourScore {
+	return &FourScore{
+		ports: [2]fourScorePort{
+			{joypads: [2]*Joypad{NewJoypad(inputs[0]), NewJoypad(inputs[2])}, signature: fourScoreSignature1},
+			{joypads: [2]*Joypad{NewJoypad(inputs[1]), NewJoypad(inputs[3])}, signature: fourScoreSignature2},
+		},
+	}
+}
+
+// Port returns the Device to plug into a controller port.
+func (f *FourScore) Port(port Port) Device {
+	return &f.ports[port]
+}
+
+// fourScorePort is the half of the Four Score plugged into one controller port.
+type fourScorePort struct {
+	joypads   [2]*Joypad
+	signature uint8
+	strobe    bool
+	shift     uint32
+}
+
+// bits returns both controllers' current buttons followed by the signature, in
+// the order they are shifted out.
+func (p *fourScorePort) bits() uint32 {
+	bits := uint32(p.signature) << 16
+	for i, j := range p.joypads {
+		bits |= uint32(j.buttons()) << (8 * i)
+	}
+	return bits
+}
+
+// latch loads the shift register with both controllers' buttons and the
+// signature.
+func (p *fourScorePort) latch() {
+	p.shift = p.bits()
+}
+
+// Write sets the strobe line from bit 0 of data.
+func (p *fourScorePort) Write(data uint8) {
+	p.strobe = data&0x01 > 0
+	if p.strobe {
+		p.latch()
+	}
+}
+
+// Read returns the next bit in bit 0, shifting the register unless the strobe
+// is high. After all 24 bits have been read, it returns 1.
+func (p *fourScorePort) Read() uint8 {
+	if p.strobe {
+		p.latch()
+		return uint8(p.shift & 0x01)
+	}
+	bit := uint8(p.shift & 0x01)
+	p.shift = p.shift>>1 | 1<<23
+	return bit
+}
+
+// Peek returns the next bit in bit 0 without shifting the register. While the
+// strobe is high it returns the live state of the first controller's A, as Read
+// would, without latching it.
+func (p *fourScorePort) Peek() uint8 {
+	if p.strobe {
+		return uint8(p.bits() & 0x01)
+	}
+	return uint8(p.shift & 0x01)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixed returns an InputProvider that always presses buttons.
+func fixed(buttons Buttons) InputProvider {
+	return InputFunc(func() Buttons { return buttons })
+}
+
+func TestFourScore(t *testing.T) {
+	f := NewFourScore([4]InputProvider{fixed(A), fixed(B), fixed(Select), nil})
+
+	testCases := []struct {
+		name     string
+		port     Port
+		expected []uint8
+	}{
+		{
+			name: "players 1 and 3 on port 1",
+			port: Port1,
+			expected: []uint8{
+				1, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 1, 0, 0, 0, 0, 0,
+				0, 0, 0, 1, 0, 0, 0, 0,
+				1, 1,
+			},
+		},
+		{
+			name: "players 2 and 4 on port 2",
+			port: Port2,
+			expected: []uint8{
+				0, 1, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 1, 0, 0, 0, 0, 0,
+				1, 1,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := f.Port(tc.port)
+			d.Write(1)
+			d.Write(0)
+
+			assert.Equal(t, tc.expected, readDevice(d, len(tc.expected)))
+		})
+	}
+}
+
+func TestFourScore_Strobe(t *testing.T) {
+	d := NewFourScore([4]InputProvider{fixed(A)}).Port(Port1)
+	d.Write(1)
+
+	assert.Equal(t, []uint8{1, 1, 1}, readDevice(d, 3))
+	assert.Equal(t, uint8(1), d.Peek())
+}
+
+func TestFourScore_Peek_Strobe(t *testing.T) {
+	buttons := B
+	p := NewFourScore([4]InputProvider{InputFunc(func() Buttons { return buttons })}).Port(Port1)
+	p.Write(1)
+
+	buttons = A
+	assert.Equal(t, uint8(1), p.Peek(), "peek returns the live A button")
+	assert.Equal(t, uint32(0), p.(*fourScorePort).shift&0x01, "peek latched the buttons")
+	assert.Equal(t, p.Read(), p.Peek())
+}
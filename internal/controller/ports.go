@@ -0,0 +1,107 @@
+package controller
+
+// Register addresses of the two controller ports.
+const (
+	PortsStart uint16 = 0x4016
+	PortsEnd   uint16 = 0x4017
+)
+
+// drivenBits are the data lines driven by reads of the controller ports. Bits
+// 0-4 come from the ports and expansion port, and bits 5-7 are open bus.
+const drivenBits = 0x1f
+
+// Port identifies one of the two controller ports.
+type Port int
+
+// Controller ports.
+const (
+	Port1 Port = iota
+	Port2
+)
+
+// Device is a peripheral plugged into a controller port.
+type Device interface {
+	// Write is called with each value written to $4016, whose bit 0 is the
+	// strobe line shared by both ports.
+	Write(data uint8)
+	// Read returns the port's data lines in bits 0-4, including any side
+	// effects the read has on the device.
+	Read() uint8
+	// Peek returns the port's data lines without mutating the device's state.
+	Peek() uint8
+}
+
+// FrameCounter is written to by writes to $4017, which go to the APU's frame
+// counter rather than to the controller ports.
+type FrameCounter interface {
+	Write(address uint16, data uint8)
+}
+
+// Ports is the pair of controller ports at $4016 and $4017, attached to the
+// bus as a single bus.PartialDevice. Since $4017 is only a controller port when
+// read, writes to it are passed on to the APU's frame counter.
+type Ports struct {
+	devices      [2]Device
+	frameCounter FrameCounter
+}
+
+// NewPorts constructs and returns a pointer to a Ports with nothing plugged
+// in, passing writes to $4017 to frameCounter if it is not nil.
+func NewPorts(frameCounter FrameCounter) *Ports {
+	return &Ports{frameCounter: frameCounter}
+}
+
+// Connect plugs a Device into a port, replacing whatever was there. A nil
+// Device unplugs the port.
+func (p *Ports) Connect(port Port, d Device) {
+	p.devices[port] = d
+}
+
+// Device returns the Device plugged into a port, or nil if it is empty.
+func (p *Ports) Device(port Port) Device {
+	return p.devices[port]
+}
+
+// Read reads the port at the address.
+func (p *Ports) Read(address uint16) uint8 {
+	if d := p.devices[address-PortsStart]; d != nil {
+		return d.Read() & drivenBits
+	}
+	return 0
+}
+
+// Peek reads the port at the address without side effects.
+func (p *Ports) Peek(address uint16) uint8 {
+	if d := p.devices[address-PortsStart]; d != nil {
+		return d.Peek() & drivenBits
+	}
+	return 0
+}
+
+// DrivenBits returns the bits driven by reads of the ports. The rest are open
+// bus, which usually leaves $40 from the high byte of the address in them.
+func (p *Ports) DrivenBits(address uint16) uint8 {
+	return drivenBits
+}
+
+// Internal returns false, as the ports are read from the controllers over the
+// external data bus.
+func (p *Ports) Internal(uint16) bool {
+	return false
+}
+
+// Write writes to the strobe of both ports at $4016, or to the APU's frame
+// counter at $4017.
+func (p *Ports) Write(address uint16, data uint8) {
+	if address == PortsEnd {
+		if p.frameCounter != nil {
+			p.frameCounter.Write(address, data)
+		}
+		return
+	}
+	for _, d := range p.devices {
+		if d != nil {
+			d.Write(data)
+		}
+	}
+}
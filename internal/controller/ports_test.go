@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frameCounterRecorder records writes to the APU's frame counter.
+type frameCounterRecorder struct {
+	writes []uint8
+}
+
+func (r *frameCounterRecorder) Write(address uint16, data uint8) {
+	if address == PortsEnd {
+		r.writes = append(r.writes, data)
+	}
+}
+
+// noisyDevice drives every data line high.
+type noisyDevice struct{}
+
+func (noisyDevice) Write(uint8) {}
+func (noisyDevice) Read() uint8 { return 0xff }
+func (noisyDevice) Peek() uint8 { return 0xff }
+
+func TestPorts(t *testing.T) {
+	fc := &frameCounterRecorder{}
+	p := NewPorts(fc)
+	p.Connect(Port1, NewJoypad(InputFunc(func() Buttons { return A | Select })))
+	p.Connect(Port2, NewJoypad(InputFunc(func() Buttons { return B })))
+	b := bus.NewNESBus()
+	require.NoError(t, b.Attach(p, PortsStart, PortsEnd))
+
+	b.Write(PortsStart, 1)
+	b.Write(PortsStart, 0)
+	b.Write(PortsEnd, 0x40)
+	assert.Equal(t, []uint8{0x40}, fc.writes, "$4017 write not passed to frame counter")
+
+	var port1, port2 []uint8
+	for i := 0; i < 4; i++ {
+		port1 = append(port1, b.Read(PortsStart))
+		port2 = append(port2, b.Read(PortsEnd))
+	}
+	assert.Equal(t, []uint8{0x41, 0x40, 0x41, 0x40}, port1, "$4016 reads")
+	assert.Equal(t, []uint8{0x40, 0x41, 0x40, 0x40}, port2, "$4017 reads")
+}
+
+func TestPorts_OpenBus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		device   Device
+		latched  uint8
+		expected uint8
+	}{
+		{
+			name:     "empty port",
+			latched:  0x40,
+			expected: 0x40,
+		},
+		{
+			name:     "empty port clears the driven bits",
+			latched:  0xff,
+			expected: 0xe0,
+		},
+		{
+			name:     "upper bits are open bus",
+			device:   noisyDevice{},
+			latched:  0x00,
+			expected: 0x1f,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPorts(nil)
+			p.Connect(Port1, tc.device)
+			b := bus.NewNESBus()
+			require.NoError(t, b.Attach(p, PortsStart, PortsEnd))
+			b.Write(0x0000, tc.latched)
+			b.Read(0x0000)
+
+			assert.Equal(t, tc.expected, b.Peek(PortsStart))
+			assert.Equal(t, tc.expected, b.Read(PortsStart))
+		})
+	}
+}
+
+func TestPorts_Connect(t *testing.T) {
+	p := NewPorts(nil)
+	j := NewJoypad(nil)
+	p.Connect(Port2, j)
+
+	assert.Nil(t, p.Device(Port1))
+	assert.Equal(t, j, p.Device(Port2))
+
+	p.Connect(Port2, nil)
+	assert.Nil(t, p.Device(Port2))
+	p.Write(PortsStart, 1)
+	p.Write(PortsEnd, 0)
+}
@@ -0,0 +1,88 @@
+package controller
+
+// PowerPadButtons is the state of the Power Pad's 12 buttons, with button n in
+// bit n-1.
+type PowerPadButtons uint16
+
+// PowerPadInput is the source of a Power Pad's input.
+type PowerPadInput interface {
+	PowerPad() PowerPadButtons
+}
+
+// powerPadD3 and powerPadD4 are the buttons shifted out on D3 and D4, in the
+// order they are read.
+var (
+	powerPadD3 = [8]uint{2, 1, 5, 9, 6, 10, 11, 7}
+	powerPadD4 = [4]uint{4, 3, 12, 8}
+)
+
+// PowerPad is the Power Pad (Family Trainer) mat. While the strobe is high it
+// latches its 12 buttons into two shift registers, which reads shift out on D3
+// and D4. After their buttons have been read, both return 1.
+type PowerPad struct {
+	input  PowerPadInput
+	strobe bool
+	d3     uint8
+	d4     uint8
+}
+
+// NewPowerPad constructs and returns a pointer to a PowerPad reading its
+// buttons from input. A nil input presses no buttons.
+func NewPowerPad(input PowerPadInput) *PowerPad {
+	return &PowerPad{input: input}
+}
+
+// registers returns the current state of the buttons in the order D3 and D4
+// shift them out.
+func (p *PowerPad) registers() (d3 uint8, d4 uint8) {
+	var buttons PowerPadButtons
+	if p.input != nil {
+		buttons = p.input.PowerPad()
+	}
+
+	d4 = 0xf0
+	for i, n := range powerPadD3 {
+		d3 |= uint8(buttons>>(n-1)&0x01) << i
+	}
+	for i, n := range powerPadD4 {
+		d4 |= uint8(buttons>>(n-1)&0x01) << i
+	}
+	return d3, d4
+}
+
+// latch loads the shift registers with the current state of the buttons.
+func (p *PowerPad) latch() {
+	p.d3, p.d4 = p.registers()
+}
+
+// Write sets the strobe line from bit 0 of data.
+func (p *PowerPad) Write(data uint8) {
+	p.strobe = data&0x01 > 0
+	if p.strobe {
+		p.latch()
+	}
+}
+
+// Read returns the next buttons in D3 and D4, shifting the registers unless the
+// strobe is high.
+func (p *PowerPad) Read() uint8 {
+	if p.strobe {
+		p.latch()
+		return p.Peek()
+	}
+	data := p.Peek()
+	p.d3 = p.d3>>1 | 0x80
+	p.d4 = p.d4>>1 | 0x80
+	return data
+}
+
+// Peek returns the next buttons in D3 and D4 without shifting the registers.
+// While the strobe is high it returns the live state of the first buttons, as
+// Read would, without latching them.
+func (p *PowerPad) Peek() uint8 {
+	d3, d4 := p.d3, p.d4
+	if p.strobe {
+		d3, d4 = p.registers()
+	}
+	return (d3&0x01)<<3 | (d4&0x01)<<4
+}
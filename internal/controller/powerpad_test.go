@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// powerPadInput presses fixed Power Pad buttons.
+type powerPadInput PowerPadButtons
+
+func (p powerPadInput) PowerPad() PowerPadButtons { return PowerPadButtons(p) }
+
+func TestPowerPad(t *testing.T) {
+	testCases := []struct {
+		name     string
+		buttons  PowerPadButtons
+		expected []uint8
+	}{
+		{
+			name:     "no buttons",
+			buttons:  0,
+			expected: []uint8{0x00, 0x00, 0x00, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18},
+		},
+		{
+			name:     "button 1",
+			buttons:  1 << 0,
+			expected: []uint8{0x00, 0x08, 0x00, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18},
+		},
+		{
+			name:     "buttons 7 and 8",
+			buttons:  1<<6 | 1<<7,
+			expected: []uint8{0x00, 0x00, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18, 0x18},
+		},
+		{
+			name:     "button 12",
+			buttons:  1 << 11,
+			expected: []uint8{0x00, 0x00, 0x10, 0x00, 0x10, 0x10, 0x10, 0x10, 0x18},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPowerPad(powerPadInput(tc.buttons))
+			p.Write(1)
+			p.Write(0)
+
+			assert.Equal(t, tc.expected, readDevice(p, len(tc.expected)))
+		})
+	}
+}
+
+func TestPowerPad_NilInput(t *testing.T) {
+	p := NewPowerPad(nil)
+	p.Write(1)
+
+	assert.Equal(t, uint8(0), p.Read())
+}
+
+func TestPowerPad_Peek_Strobe(t *testing.T) {
+	buttons := powerPadInput(0)
+	p := NewPowerPad(&buttons)
+	p.Write(1)
+
+	// buttons 2 and 4 are the first read on D3 and D4
+	buttons = 1<<1 | 1<<3
+	assert.Equal(t, uint8(0x18), p.Peek(), "peek returns the live buttons")
+	assert.Equal(t, uint8(0), p.d3&0x01, "peek latched the buttons")
+	assert.Equal(t, p.Read(), p.Peek())
+}
@@ -0,0 +1,86 @@
+package controller
+
+// Vaus data lines.
+const (
+	vausPosition uint8 = 0x08 // D3, the paddle's position shifted out inverted
+	vausFire     uint8 = 0x10 // D4, set while the fire button is pressed
+)
+
+// VausInput is the source of a Vaus controller's input.
+type VausInput interface {
+	// Vaus returns the paddle's position and whether the fire button is
+	// pressed. The controller that came with Arkanoid ranges from about 98 at
+	// its left to 242 at its right.
+	Vaus() (position uint8, fire bool)
+}
+
+// Vaus is the paddle controller that came with Arkanoid. While the strobe is
+// high it latches the paddle's position, which reads shift out inverted on D3,
+// most significant bit first. The fire button is read directly on D4.
+type Vaus struct {
+	input  VausInput
+	strobe bool
+	shift  uint8
+}
+
+// NewVaus constructs and returns a pointer to a Vaus reading its position and
+// fire button from input. A nil input leaves the paddle at 0 with the button
+// released.
+func NewVaus(input VausInput) *Vaus {
+	return &Vaus{input: input}
+}
+
+// position returns the current position of the paddle, inverted as it is
+// shifted out.
+func (v *Vaus) position() uint8 {
+	var position uint8
+	if v.input != nil {
+		position, _ = v.input.Vaus()
+	}
+	return ^position
+}
+
+// latch loads the shift register with the inverted position of the paddle.
+func (v *Vaus) latch() {
+	v.shift = v.position()
+}
+
+// Write sets the strobe line from bit 0 of data.
+func (v *Vaus) Write(data uint8) {
+	v.strobe = data&0x01 > 0
+	if v.strobe {
+		v.latch()
+	}
+}
+
+// Read returns the next bit of the position in D3 and the fire button in D4,
+// shifting the register unless the strobe is high.
+func (v *Vaus) Read() uint8 {
+	if v.strobe {
+		v.latch()
+		return v.Peek()
+	}
+	data := v.Peek()
+	v.shift <<= 1
+	return data
+}
+
+// Peek returns the next bit of the position in D3 and the fire button in D4
+// without shifting the register. While the strobe is high it returns the live
+// position, as Read would, without latching it.
+func (v *Vaus) Peek() uint8 {
+	shift := v.shift
+	if v.strobe {
+		shift = v.position()
+	}
+	var data uint8
+	if shift&0x80 > 0 {
+		data |= vausPosition
+	}
+	if v.input != nil {
+		if _, fire := v.input.Vaus(); fire {
+			data |= vausFire
+		}
+	}
+	return data
+}
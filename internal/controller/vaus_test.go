@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// vausInput is a VausInput with a fixed position and button.
+type vausInput struct {
+	position uint8
+	fire     bool
+}
+
+func (v vausInput) Vaus() (uint8, bool) { return v.position, v.fire }
+
+func TestVaus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    vausInput
+		expected []uint8
+	}{
+		{
+			name:     "position shifted out inverted",
+			input:    vausInput{position: 0xa5},
+			expected: []uint8{0x00, 0x08, 0x00, 0x08, 0x08, 0x00, 0x08, 0x00, 0x00},
+		},
+		{
+			name:     "fire button",
+			input:    vausInput{position: 0xff, fire: true},
+			expected: []uint8{0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewVaus(tc.input)
+			v.Write(1)
+			v.Write(0)
+
+			assert.Equal(t, tc.expected, readDevice(v, len(tc.expected)))
+		})
+	}
+}
+
+func TestVaus_NilInput(t *testing.T) {
+	v := NewVaus(nil)
+	v.Write(1)
+	v.Write(0)
+
+	assert.Equal(t, []uint8{0x08, 0x08}, readDevice(v, 2))
+}
+
+func TestVaus_Peek_Strobe(t *testing.T) {
+	input := vausInput{position: 0xff}
+	v := NewVaus(&input)
+	v.Write(1)
+
+	input.position = 0x00
+	assert.Equal(t, vausPosition, v.Peek(), "peek returns the live position")
+	assert.Equal(t, uint8(0), v.shift&0x80, "peek latched the position")
+	assert.Equal(t, v.Read(), v.Peek())
+}
@@ -0,0 +1,97 @@
+package controller
+
+import "github.com/Jac0bDeal/goNES/internal/ppu"
+
+// Zapper data lines.
+const (
+	zapperNoLight uint8 = 0x08 // D3, clear while the sensor sees light
+	zapperTrigger uint8 = 0x10 // D4, set while the trigger is pulled
+)
+
+// Zapper light sensing parameters.
+const (
+	// zapperBrightness is the luma at or above which a pixel is bright enough
+	// for the sensor to see.
+	zapperBrightness = 0x80
+	// zapperHold is the number of scanlines the sensor keeps seeing light
+	// after the beam has drawn the pixel it is aimed at.
+	zapperHold = 20
+)
+
+// ZapperInput is the source of a Zapper's aim and trigger.
+type ZapperInput interface {
+	// Aim returns the pixel the Zapper is pointed at and whether its trigger
+	// is pulled. A position outside the picture aims away from the screen.
+	Aim() (x int, y int, trigger bool)
+}
+
+// Screen is the picture a Zapper is pointed at, which is the PPU's.
+type Screen interface {
+	// FrameBuffer returns the picture as palette indices in rows of ppu.Width
+	// pixels.
+	FrameBuffer() []uint8
+	// Scanline returns the scanline being drawn.
+	Scanline() int
+	// Dot returns the dot within the scanline being drawn.
+	Dot() int
+}
+
+// Zapper is the NES light gun. Its photodiode sees light for a short time
+// after the beam draws a bright pixel where it is aimed, which it reports on
+// D3 along with its trigger on D4. It ignores the strobe.
+type Zapper struct {
+	input  ZapperInput
+	screen Screen
+}
+
+// NewZapper constructs and returns a pointer to a Zapper aimed at screen,
+// reading its aim and trigger from input. A nil input aims away from the
+// screen with the trigger released.
+func NewZapper(input ZapperInput, screen Screen) *Zapper {
+	return &Zapper{input: input, screen: screen}
+}
+
+// lightSensed returns whether the sensor sees light at a pixel, which it does
+// if the pixel is bright and the beam drew it within the last zapperHold
+// scanlines.
+func (z *Zapper) lightSensed(x int, y int) bool {
+	if x < 0 || x >= ppu.Width || y < 0 || y >= ppu.Height {
+		return false
+	}
+
+	drawn := y*ppu.DotsPerScanline + x + 1
+	beam := z.screen.Scanline()*ppu.DotsPerScanline + z.screen.Dot()
+	if beam < drawn || beam-drawn >= zapperHold*ppu.DotsPerScanline {
+		return false
+	}
+
+	c := ppu.Palette[z.screen.FrameBuffer()[y*ppu.Width+x]&0x3f]
+	r, g, b, _ := c.RGBA()
+	luma := (299*r + 587*g + 114*b) / 1000 >> 8
+	return luma >= zapperBrightness
+}
+
+// Write does nothing, as the Zapper has no use for the strobe.
+func (z *Zapper) Write(data uint8) {}
+
+// Read returns the state of the sensor and trigger.
+func (z *Zapper) Read() uint8 {
+	return z.Peek()
+}
+
+// Peek returns the state of the sensor and trigger.
+func (z *Zapper) Peek() uint8 {
+	if z.input == nil {
+		return zapperNoLight
+	}
+
+	x, y, trigger := z.input.Aim()
+	var data uint8
+	if !z.lightSensed(x, y) {
+		data |= zapperNoLight
+	}
+	if trigger {
+		data |= zapperTrigger
+	}
+	return data
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+// testScreen is a Screen with a fixed picture and beam position.
+type testScreen struct {
+	pixels   [ppu.Width * ppu.Height]uint8
+	scanline int
+	dot      int
+}
+
+func (s *testScreen) FrameBuffer() []uint8 { return s.pixels[:] }
+func (s *testScreen) Scanline() int        { return s.scanline }
+func (s *testScreen) Dot() int             { return s.dot }
+
+// aim is a ZapperInput aimed at a fixed pixel.
+type aim struct {
+	x, y    int
+	trigger bool
+}
+
+func (a aim) Aim() (int, int, bool) { return a.x, a.y, a.trigger }
+
+func TestZapper(t *testing.T) {
+	testCases := []struct {
+		name     string
+		aim      aim
+		colour   uint8
+		scanline int
+		dot      int
+		expected uint8
+	}{
+		{
+			name:     "bright pixel just drawn",
+			aim:      aim{x: 100, y: 50},
+			colour:   0x30,
+			scanline: 50,
+			dot:      101,
+			expected: 0x00,
+		},
+		{
+			name:     "bright pixel not drawn yet",
+			aim:      aim{x: 100, y: 50},
+			colour:   0x30,
+			scanline: 50,
+			dot:      100,
+			expected: zapperNoLight,
+		},
+		{
+			name:     "bright pixel drawn a while ago",
+			aim:      aim{x: 100, y: 50},
+			colour:   0x30,
+			scanline: 50 + zapperHold,
+			dot:      101,
+			expected: zapperNoLight,
+		},
+		{
+			name:     "dark pixel",
+			aim:      aim{x: 100, y: 50},
+			colour:   0x0f,
+			scanline: 60,
+			dot:      0,
+			expected: zapperNoLight,
+		},
+		{
+			name:     "aimed away from the screen",
+			aim:      aim{x: -1, y: 50, trigger: true},
+			colour:   0x30,
+			scanline: 60,
+			dot:      0,
+			expected: zapperNoLight | zapperTrigger,
+		},
+		{
+			name:     "trigger pulled",
+			aim:      aim{x: 100, y: 50, trigger: true},
+			colour:   0x30,
+			scanline: 60,
+			dot:      0,
+			expected: zapperTrigger,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &testScreen{scanline: tc.scanline, dot: tc.dot}
+			s.pixels[tc.aim.y*ppu.Width+100] = tc.colour
+			z := NewZapper(tc.aim, s)
+			z.Write(1)
+
+			assert.Equal(t, tc.expected, z.Peek())
+			assert.Equal(t, tc.expected, z.Read())
+		})
+	}
+}
+
+func TestZapper_NilInput(t *testing.T) {
+	z := NewZapper(nil, &testScreen{})
+
+	assert.Equal(t, zapperNoLight, z.Read())
+}
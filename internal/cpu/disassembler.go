@@ -0,0 +1,226 @@
+package cpu
+
+import (
+	"fmt"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+)
+
+// AddressMode is the way an instruction addresses its operand.
+type AddressMode string
+
+// Address Modes
+const (
+	Implied                 AddressMode = imp // Implied takes no operand, or works on the accumulator.
+	Immediate               AddressMode = imm // Immediate takes the byte after the opcode as its operand.
+	ZeroPage                AddressMode = zp0 // ZeroPage addresses page 0x00.
+	ZeroPageX               AddressMode = zpx // ZeroPageX addresses page 0x00 offset by the X register.
+	ZeroPageY               AddressMode = zpy // ZeroPageY addresses page 0x00 offset by the Y register.
+	Relative                AddressMode = rel // Relative is the signed offset of a branch.
+	Absolute                AddressMode = abs // Absolute addresses a full 16-bit address.
+	AbsoluteX               AddressMode = abx // AbsoluteX addresses a 16-bit address offset by the X register.
+	AbsoluteY               AddressMode = aby // AbsoluteY addresses a 16-bit address offset by the Y register.
+	Indirect                AddressMode = ind // Indirect jumps to the address held at a 16-bit address.
+	IndirectX               AddressMode = izx // IndirectX addresses the address held at a zero page pointer offset by the X register.
+	IndirectY               AddressMode = izy // IndirectY addresses the address held at a zero page pointer, offset by the Y register.
+	ZeroPageIndirect        AddressMode = izp // ZeroPageIndirect addresses the address held at a zero page pointer, on the 65C02.
+	AbsoluteIndexedIndirect AddressMode = iax // AbsoluteIndexedIndirect jumps to the address held at a 16-bit address offset by the X register, on the 65C02.
+	ZeroPageRelative        AddressMode = zpr // ZeroPageRelative tests a zero page byte then branches, on the R65C02.
+)
+
+// Size returns the number of bytes an instruction in the address mode takes,
+// including its opcode.
+func (m AddressMode) Size() int {
+	switch m {
+	case Implied:
+		return 1
+	case Absolute, AbsoluteX, AbsoluteY, Indirect, AbsoluteIndexedIndirect, ZeroPageRelative:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// Instruction is an instruction decoded from memory.
+type Instruction struct {
+	Address   uint16      // Address is where the opcode is held.
+	Bytes     []byte      // Bytes are the opcode followed by the operand.
+	Mnemonic  string      // Mnemonic is the name of the operation.
+	Mode      AddressMode // Mode is the address mode of the operand.
+	Operand   uint16      // Operand is the value, address or branch offset following the opcode.
+	Target    uint16      // Target is the branch target, or the address of the zero page and absolute modes.
+	HasTarget bool        // HasTarget is whether Target is known without running the instruction.
+	Label     string      // Label names the address of the operand, or the branch target, and is written in its place when set.
+	Comment   string      // Comment is the comment on Address, if any.
+	Cycles    uint8       // Cycles is the number of cycles taken, not counting page crosses or taken branches.
+	Official  bool        // Official is whether the instruction is documented, rather than unofficial.
+}
+
+// Accumulator returns whether the instruction works on the accumulator, which
+// the Implied mode shifts, rotates, increments and decrements do.
+func (in Instruction) Accumulator() bool {
+	if in.Mode != Implied {
+		return false
+	}
+	switch in.Mnemonic {
+	case asl, lsr, rol, ror, inc, dec:
+		return true
+	default:
+		return false
+	}
+}
+
+// Opcode is an entry of the opcode table of a Variant.
+type Opcode struct {
+	Mnemonic string
+	Mode     AddressMode
+	Cycles   uint8 // Cycles is the number of cycles taken, not counting page crosses or taken branches.
+	Official bool
+}
+
+// Opcodes returns the opcode table of a Variant, indexed by opcode. It is the
+// table the CPU runs and the Disassembler decodes with, so that tools building
+// instructions, such as an assembler, agree with both.
+func Opcodes(variant Variant) [256]Opcode {
+	var opcodes [256]Opcode
+	for i, entry := range NewMos6502(variant).lookup {
+		opcodes[i] = Opcode{
+			Mnemonic: entry.operation,
+			Mode:     AddressMode(entry.addressMode),
+			Cycles:   entry.cycles,
+			Official: entry.official,
+		}
+	}
+	return opcodes
+}
+
+// Labeler names addresses for a Disassembler, such as from the symbols of a
+// debug file.
+type Labeler interface {
+	// Label returns the name of the symbol at or covering an address, and the
+	// offset of the address into it.
+	Label(address uint16) (string, int, bool)
+	// Comment returns the comment on an address.
+	Comment(address uint16) (string, bool)
+}
+
+// Disassembler decodes the instructions held in memory as the lookup table of a
+// CPU Variant defines them. Memory is only ever peeked, so disassembling over
+// memory-mapped registers has no side effects.
+type Disassembler struct {
+	mem     bus.Peeker
+	lookup  *mos6502LookupTable
+	labeler Labeler
+}
+
+// NewDisassembler constructs and returns a pointer to a Disassembler decoding
+// memory as a Variant.
+func NewDisassembler(mem bus.Peeker, variant Variant) *Disassembler {
+	return &Disassembler{
+		mem:    mem,
+		lookup: &NewMos6502(variant).lookup,
+	}
+}
+
+// SetLabeler sets the Labeler naming the addresses of decoded instructions, or
+// clears it if nil.
+func (d *Disassembler) SetLabeler(l Labeler) {
+	d.labeler = l
+}
+
+// label returns the name of an address from the Labeler, written as an offset
+// from the symbol covering it if it is not the symbol's first byte, e.g.
+// "buffer+2".
+func (d *Disassembler) label(address uint16) (string, bool) {
+	if d.labeler == nil {
+		return "", false
+	}
+	name, offset, ok := d.labeler.Label(address)
+	if !ok {
+		return "", false
+	}
+	if offset != 0 {
+		name = fmt.Sprintf("%s+%d", name, offset)
+	}
+	return name, true
+}
+
+// symbol returns the name of the symbol starting at an address.
+func (d *Disassembler) symbol(address uint16) (string, bool) {
+	if d.labeler == nil {
+		return "", false
+	}
+	name, offset, ok := d.labeler.Label(address)
+	return name, ok && offset == 0
+}
+
+// comment returns the comment on an address from the Labeler.
+func (d *Disassembler) comment(address uint16) string {
+	if d.labeler == nil {
+		return ""
+	}
+	comment, _ := d.labeler.Comment(address)
+	return comment
+}
+
+// Decode decodes the instruction at an address.
+func (d *Disassembler) Decode(address uint16) Instruction {
+	opcode := d.mem.Peek(address)
+	entry := d.lookup[opcode]
+	in := Instruction{
+		Address:  address,
+		Mnemonic: entry.operation,
+		Mode:     AddressMode(entry.addressMode),
+		Cycles:   entry.cycles,
+		Official: entry.official,
+	}
+
+	in.Bytes = make([]byte, in.Mode.Size())
+	for i := range in.Bytes {
+		in.Bytes[i] = d.mem.Peek(address + uint16(i))
+	}
+	switch len(in.Bytes) {
+	case 2:
+		in.Operand = uint16(in.Bytes[1])
+	case 3:
+		in.Operand = uint16(in.Bytes[2])<<8 | uint16(in.Bytes[1])
+	}
+
+	next := address + uint16(len(in.Bytes))
+	switch in.Mode {
+	case Relative:
+		in.Target = next + uint16(int8(in.Bytes[1]))
+		in.HasTarget = true
+	case ZeroPageRelative:
+		// the operand is the zero page address, followed by the offset
+		in.Operand = uint16(in.Bytes[1])
+		in.Target = next + uint16(int8(in.Bytes[2]))
+		in.HasTarget = true
+	case ZeroPage, Absolute:
+		in.Target = in.Operand
+		in.HasTarget = true
+	}
+
+	switch in.Mode {
+	case Implied, Immediate:
+	case Relative, ZeroPageRelative:
+		in.Label, _ = d.label(in.Target)
+	default:
+		in.Label, _ = d.label(in.Operand)
+	}
+	in.Comment = d.comment(address)
+	return in
+}
+
+// Disassemble decodes the instructions from addressStart up to addressStop, in
+// order. An instruction starting before addressStop is decoded whole, even if
+// it runs past it.
+func (d *Disassembler) Disassemble(addressStart uint16, addressStop uint16) []Instruction {
+	var instructions []Instruction
+	for address := int(addressStart); address < int(addressStop); {
+		in := d.Decode(uint16(address))
+		instructions = append(instructions, in)
+		address += len(in.Bytes)
+	}
+	return instructions
+}
@@ -0,0 +1,282 @@
+package cpu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisassembler_Decode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		variant  Variant
+		program  []byte
+		expected Instruction
+	}{
+		{
+			name:    "implied",
+			program: []byte{0x0a}, // ASL A
+			expected: Instruction{
+				Address:  0x8000,
+				Bytes:    []byte{0x0a},
+				Mnemonic: asl,
+				Mode:     Implied,
+				Cycles:   2,
+				Official: true,
+			},
+		},
+		{
+			name:    "immediate",
+			program: []byte{0xa9, 0x42}, // LDA #$42
+			expected: Instruction{
+				Address:  0x8000,
+				Bytes:    []byte{0xa9, 0x42},
+				Mnemonic: lda,
+				Mode:     Immediate,
+				Operand:  0x42,
+				Cycles:   2,
+				Official: true,
+			},
+		},
+		{
+			name:    "absolute resolves its target",
+			program: []byte{0x20, 0x34, 0x12}, // JSR $1234
+			expected: Instruction{
+				Address:   0x8000,
+				Bytes:     []byte{0x20, 0x34, 0x12},
+				Mnemonic:  jsr,
+				Mode:      Absolute,
+				Operand:   0x1234,
+				Target:    0x1234,
+				HasTarget: true,
+				Cycles:    6,
+				Official:  true,
+			},
+		},
+		{
+			name:    "indexed has no target",
+			program: []byte{0xbd, 0x34, 0x12}, // LDA $1234,X
+			expected: Instruction{
+				Address:  0x8000,
+				Bytes:    []byte{0xbd, 0x34, 0x12},
+				Mnemonic: lda,
+				Mode:     AbsoluteX,
+				Operand:  0x1234,
+				Cycles:   4,
+				Official: true,
+			},
+		},
+		{
+			name:    "forward branch",
+			program: []byte{0xd0, 0x10}, // BNE +16
+			expected: Instruction{
+				Address:   0x8000,
+				Bytes:     []byte{0xd0, 0x10},
+				Mnemonic:  bne,
+				Mode:      Relative,
+				Operand:   0x10,
+				Target:    0x8012,
+				HasTarget: true,
+				Cycles:    2,
+				Official:  true,
+			},
+		},
+		{
+			name:    "backward branch sign extends its offset",
+			program: []byte{0xd0, 0xfe}, // BNE -2
+			expected: Instruction{
+				Address:   0x8000,
+				Bytes:     []byte{0xd0, 0xfe},
+				Mnemonic:  bne,
+				Mode:      Relative,
+				Operand:   0xfe,
+				Target:    0x8000,
+				HasTarget: true,
+				Cycles:    2,
+				Official:  true,
+			},
+		},
+		{
+			name:    "unofficial operation",
+			program: []byte{0xa7, 0x10}, // LAX $10
+			expected: Instruction{
+				Address:   0x8000,
+				Bytes:     []byte{0xa7, 0x10},
+				Mnemonic:  lax,
+				Mode:      ZeroPage,
+				Operand:   0x10,
+				Target:    0x0010,
+				HasTarget: true,
+				Cycles:    3,
+				Official:  false,
+			},
+		},
+		{
+			name:    "unofficial nop",
+			program: []byte{0x1a}, // NOP
+			expected: Instruction{
+				Address:  0x8000,
+				Bytes:    []byte{0x1a},
+				Mnemonic: nop,
+				Mode:     Implied,
+				Cycles:   2,
+				Official: false,
+			},
+		},
+		{
+			name:    "zero page relative",
+			variant: R65C02,
+			program: []byte{0x8f, 0x10, 0xfd}, // BBS0 $10, -3
+			expected: Instruction{
+				Address:   0x8000,
+				Bytes:     []byte{0x8f, 0x10, 0xfd},
+				Mnemonic:  "BBS0",
+				Mode:      ZeroPageRelative,
+				Operand:   0x10,
+				Target:    0x8000,
+				HasTarget: true,
+				Cycles:    5,
+				Official:  true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bus.RAM{}
+			copy(r[0x8000:], tc.program)
+			d := NewDisassembler(bus.NewBus(r), tc.variant)
+
+			assert.Equal(t, tc.expected, d.Decode(0x8000))
+		})
+	}
+}
+
+// testSymbol is a symbol named by a testLabeler.
+type testSymbol struct {
+	name    string
+	size    uint16
+	comment string
+}
+
+// testLabeler is a Labeler naming the symbols it maps from their first address.
+type testLabeler map[uint16]testSymbol
+
+func (l testLabeler) Label(address uint16) (string, int, bool) {
+	for start, s := range l {
+		if address >= start && address-start < s.size {
+			return s.name, int(address - start), true
+		}
+	}
+	return "", 0, false
+}
+
+func (l testLabeler) Comment(address uint16) (string, bool) {
+	s, ok := l[address]
+	return s.comment, ok && s.comment != ""
+}
+
+func TestDisassembler_Decode_Labeler(t *testing.T) {
+	labeler := testLabeler{
+		0x0010: {name: "frame", size: 1},
+		0x0300: {name: "buffer", size: 0x10},
+		0x8000: {name: "reset", size: 1, comment: "entry point"},
+	}
+	testCases := []struct {
+		name            string
+		program         []byte
+		expectedLabel   string
+		expectedComment string
+	}{
+		{
+			name:            "zero page",
+			program:         []byte{0xa5, 0x10}, // LDA $10
+			expectedLabel:   "frame",
+			expectedComment: "entry point",
+		},
+		{
+			name:            "indexed inside a symbol",
+			program:         []byte{0xbd, 0x02, 0x03}, // LDA $0302,X
+			expectedLabel:   "buffer+2",
+			expectedComment: "entry point",
+		},
+		{
+			name:            "branch target",
+			program:         []byte{0xd0, 0xfe}, // BNE -2
+			expectedLabel:   "reset",
+			expectedComment: "entry point",
+		},
+		{
+			name:            "immediate is not an address",
+			program:         []byte{0xa9, 0x10}, // LDA #$10
+			expectedComment: "entry point",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bus.RAM{}
+			copy(r[0x8000:], tc.program)
+			d := NewDisassembler(bus.NewBus(r), RP2A03)
+			d.SetLabeler(labeler)
+
+			in := d.Decode(0x8000)
+
+			assert.Equal(t, tc.expectedLabel, in.Label, "incorrect label")
+			assert.Equal(t, tc.expectedComment, in.Comment, "incorrect comment")
+		})
+	}
+}
+
+func TestDisassembler_Disassemble(t *testing.T) {
+	t.Run("decodes in order", func(t *testing.T) {
+		r := bus.RAM{}
+		copy(r[0x8000:], []byte{0xa2, 0x0a, 0x8e, 0x00, 0x00, 0xea})
+		d := NewDisassembler(bus.NewBus(r), RP2A03)
+
+		instructions := d.Disassemble(0x8000, 0x8006)
+
+		var addresses []uint16
+		for _, in := range instructions {
+			addresses = append(addresses, in.Address)
+		}
+		assert.Equal(t, []uint16{0x8000, 0x8002, 0x8005}, addresses)
+	})
+
+	t.Run("stops at the end of memory", func(t *testing.T) {
+		r := bus.RAM{}
+		r[0xfffe] = 0xea
+		r[0xffff] = 0xea
+		d := NewDisassembler(bus.NewBus(r), RP2A03)
+
+		instructions := d.Disassemble(0xfffe, 0xffff)
+
+		assert.Len(t, instructions, 1)
+	})
+
+	t.Run("instruction running past the end is decoded whole", func(t *testing.T) {
+		r := bus.RAM{}
+		copy(r[0xfffe:], []byte{0xad, 0x02})
+		d := NewDisassembler(bus.NewBus(r), RP2A03)
+
+		instructions := d.Disassemble(0xfffe, 0xffff)
+
+		assert.Equal(t, []byte{0xad, 0x02, 0x00}, instructions[0].Bytes)
+	})
+}
+
+func TestOpcodes(t *testing.T) {
+	for _, variant := range []Variant{RP2A03, NMOS6502, WDC65C02, R65C02} {
+		t.Run(fmt.Sprintf("variant %d", variant), func(t *testing.T) {
+			opcodes := Opcodes(variant)
+			b := bus.NewBus(bus.RAM{})
+			d := NewDisassembler(b, variant)
+
+			for i, opcode := range opcodes {
+				b.Write(0x8000, byte(i))
+				in := d.Decode(0x8000)
+				assert.Equal(t, Opcode{in.Mnemonic, in.Mode, in.Cycles, in.Official}, opcode, "incorrect opcode $%02X", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dataLineLength is the most bytes of data put on one line of a Listing.
+const dataLineLength = 8
+
+// Line is a line of a Listing, holding either an instruction or data.
+type Line struct {
+	Address     uint16
+	Label       string       // Label names the address, if anything refers to it or a symbol names it.
+	Comment     string       // Comment is the comment on the address, if any.
+	Instruction *Instruction // Instruction is the code at the address, or nil if the line is data.
+	Data        []byte       // Data are the bytes of a data line.
+}
+
+// Listing is a disassembly made by following the flow of control.
+type Listing struct {
+	Lines  []Line            // Lines are the lines of the disassembly, in address order.
+	Labels map[uint16]string // Labels are the symbols and generated labels, by address.
+}
+
+// Format writes each line of the listing in the syntax of a Formatter, with its
+// address as a comment. Labels and comments on an address get lines of their
+// own before it.
+func (l Listing) Format(f Formatter) []string {
+	var lines []string
+	for _, line := range l.Lines {
+		if line.Label != "" {
+			lines = append(lines, line.Label+":")
+		}
+		if line.Comment != "" {
+			for _, comment := range strings.Split(line.Comment, "\n") {
+				lines = append(lines, "\t; "+comment)
+			}
+		}
+		var text string
+		if line.Instruction != nil {
+			text = f.Format(*line.Instruction)
+		} else {
+			text = f.FormatData(line.Data)
+		}
+		lines = append(lines, fmt.Sprintf("\t%-24s ; $%04X", text, line.Address))
+	}
+	return lines
+}
+
+// labelKind is the reason an address is labelled. When an address is referred
+// to in more than one way, the lowest kind names it.
+type labelKind byte
+
+const (
+	labelVector labelKind = iota
+	labelEntry
+	labelSubroutine
+	labelJump
+	labelTable
+	labelBranch
+)
+
+// byteKind is what a byte of the followed range was found to hold.
+type byteKind byte
+
+const (
+	byteUnknown byteKind = iota
+	byteOpcode
+	byteOperand
+	byteTable
+)
+
+// follower holds the state of a Follow.
+type follower struct {
+	d          *Disassembler
+	start      uint16
+	end        uint16
+	kinds      []byteKind
+	code       map[uint16]Instruction
+	labels     map[uint16]string
+	labelKinds map[uint16]labelKind
+	pending    []uint16
+}
+
+// Follow disassembles memory from addressStart through addressEnd by following
+// the flow of control from the reset, NMI and IRQ vectors and any other entry
+// points given. Jumps, calls and branches are followed until a return, and jump
+// tables dispatched through RTS are found and followed. Bytes that are never
+// reached are listed as data, and every address referred to is labelled. An end
+// before the start gives an empty Listing.
+func (d *Disassembler) Follow(addressStart uint16, addressEnd uint16, entries ...uint16) Listing {
+	if addressEnd < addressStart {
+		return Listing{Labels: make(map[uint16]string)}
+	}
+	f := &follower{
+		d:          d,
+		start:      addressStart,
+		end:        addressEnd,
+		kinds:      make([]byteKind, int(addressEnd)-int(addressStart)+1),
+		code:       make(map[uint16]Instruction),
+		labels:     make(map[uint16]string),
+		labelKinds: make(map[uint16]labelKind),
+	}
+
+	vectors := []struct {
+		name    string
+		address uint16
+	}{
+		{"reset", 0xfffc},
+		{"nmi", 0xfffa},
+		{"irq", 0xfffe},
+	}
+	for _, v := range vectors {
+		target := uint16(d.mem.Peek(v.address+1))<<8 | uint16(d.mem.Peek(v.address))
+		f.refer(target, labelVector, v.name)
+	}
+	for _, entry := range entries {
+		f.refer(entry, labelEntry, fmt.Sprintf("entry_%04X", entry))
+	}
+
+	for len(f.pending) > 0 {
+		address := f.pending[0]
+		f.pending = f.pending[1:]
+		f.trace(address)
+	}
+	return f.listing()
+}
+
+// inRange returns whether an address is within the followed range.
+func (f *follower) inRange(address uint16) bool {
+	return address >= f.start && address <= f.end
+}
+
+// kind returns what the byte at an address was found to hold.
+func (f *follower) kind(address uint16) byteKind {
+	return f.kinds[address-f.start]
+}
+
+// refer labels an address referred to by an entry point or the code, and
+// queues it to be traced if it is code.
+func (f *follower) refer(address uint16, kind labelKind, name string) {
+	if !f.inRange(address) {
+		return
+	}
+	if current, ok := f.labelKinds[address]; !ok || kind < current {
+		f.labels[address] = name
+		f.labelKinds[address] = kind
+	}
+	if kind != labelTable {
+		f.pending = append(f.pending, address)
+	}
+}
+
+// trace decodes the instructions run from an address, until the flow of
+// control leaves them or reaches code already traced.
+func (f *follower) trace(address uint16) {
+	var block []Instruction
+	for f.inRange(address) && f.kind(address) == byteUnknown {
+		in := f.d.Decode(address)
+		for i := range in.Bytes {
+			next := address + uint16(i)
+			if int(address)+i > int(f.end) || f.kind(next) != byteUnknown {
+				return
+			}
+		}
+		for i := range in.Bytes {
+			f.kinds[address+uint16(i)-f.start] = byteOperand
+		}
+		f.kinds[address-f.start] = byteOpcode
+		f.code[address] = in
+		block = append(block, in)
+
+		switch {
+		case in.Mnemonic == jmp:
+			if in.Mode == Absolute {
+				f.refer(in.Target, labelJump, fmt.Sprintf("loc_%04X", in.Target))
+			}
+			return
+		case in.Mnemonic == jsr:
+			f.refer(in.Target, labelSubroutine, fmt.Sprintf("sub_%04X", in.Target))
+		case in.Mnemonic == bra:
+			f.refer(in.Target, labelBranch, fmt.Sprintf("L%04X", in.Target))
+			return
+		case in.Mode == Relative || in.Mode == ZeroPageRelative:
+			f.refer(in.Target, labelBranch, fmt.Sprintf("L%04X", in.Target))
+		case in.Mnemonic == rts:
+			f.jumpTable(block)
+			return
+		case in.Mnemonic == rti, in.Mnemonic == brk, in.Mnemonic == kil:
+			return
+		}
+		address += uint16(len(in.Bytes))
+		if address == 0 {
+			// ran off the end of memory
+			return
+		}
+	}
+}
+
+// jumpTable finds a jump table dispatched by an RTS, which returns to the
+// address pushed on the stack plus one. The table is found from the usual
+// pattern of loading the high then low byte of an entry with the same index
+// register and pushing each of them, with the bytes either interleaved in one
+// table of addresses or split across two tables.
+func (f *follower) jumpTable(block []Instruction) {
+	if len(block) < 5 {
+		return
+	}
+	pattern := block[len(block)-5 : len(block)-1]
+	loadHigh, pushHigh, loadLow, pushLow := pattern[0], pattern[1], pattern[2], pattern[3]
+	if loadHigh.Mnemonic != lda || pushHigh.Mnemonic != pha || loadLow.Mnemonic != lda || pushLow.Mnemonic != pha {
+		return
+	}
+	if loadHigh.Mode != loadLow.Mode || (loadLow.Mode != AbsoluteX && loadLow.Mode != AbsoluteY) {
+		return
+	}
+
+	low, high := loadLow.Operand, loadHigh.Operand
+	stride, entries := uint16(1), 0x100
+	if high == low+1 {
+		stride, entries = 2, 0x80
+	} else if low < high && int(high-low) < entries {
+		entries = int(high - low)
+	} else if high < low && int(low-high) < entries {
+		entries = int(low - high)
+	}
+
+	f.refer(low, labelTable, fmt.Sprintf("tbl_%04X", low))
+	if stride == 1 {
+		f.refer(high, labelTable, fmt.Sprintf("tbl_%04X", high))
+	}
+	for i := 0; i < entries; i++ {
+		lowAddress := low + uint16(i)*stride
+		highAddress := high + uint16(i)*stride
+		if !f.inRange(lowAddress) || !f.inRange(highAddress) || f.kind(lowAddress) != byteUnknown || f.kind(highAddress) != byteUnknown {
+			return
+		}
+		target := (uint16(f.d.mem.Peek(highAddress))<<8 | uint16(f.d.mem.Peek(lowAddress))) + 1
+		if !f.inRange(target) || f.kind(target) == byteOperand || f.kind(target) == byteTable {
+			return
+		}
+		f.kinds[lowAddress-f.start] = byteTable
+		f.kinds[highAddress-f.start] = byteTable
+		f.refer(target, labelJump, fmt.Sprintf("loc_%04X", target))
+	}
+}
+
+// listing builds the Listing of the followed range, dropping labels that point
+// into the middle of an instruction. Symbols from the Labeler name their
+// addresses in place of generated labels.
+func (f *follower) listing() Listing {
+	listing := Listing{Labels: make(map[uint16]string)}
+	for address, label := range f.labels {
+		if f.kind(address) != byteOperand {
+			listing.Labels[address] = label
+		}
+	}
+	for i := range f.kinds {
+		address := f.start + uint16(i)
+		if name, ok := f.d.symbol(address); ok && f.kind(address) != byteOperand {
+			listing.Labels[address] = name
+		}
+	}
+
+	data := -1 // index of the data line being filled
+	for i := range f.kinds {
+		address := f.start + uint16(i)
+		switch f.kind(address) {
+		case byteOpcode:
+			in := f.code[address]
+			if label, ok := listing.Labels[in.Target]; in.HasTarget && ok {
+				in.Label = label
+			}
+			listing.Lines = append(listing.Lines, Line{Address: address, Label: listing.Labels[address], Comment: in.Comment, Instruction: &in})
+			data = -1
+		case byteOperand:
+		default:
+			label, labelled := listing.Labels[address]
+			if data < 0 || labelled || f.d.comment(address) != "" || len(listing.Lines[data].Data) == dataLineLength {
+				listing.Lines = append(listing.Lines, Line{Address: address, Label: label, Comment: f.d.comment(address)})
+				data = len(listing.Lines) - 1
+			}
+			listing.Lines[data].Data = append(listing.Lines[data].Data, f.d.mem.Peek(address))
+		}
+	}
+	return listing
+}
@@ -0,0 +1,224 @@
+package cpu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+)
+
+// listingLine returns a line of a formatted Listing.
+func listingLine(text string, address uint16) string {
+	return fmt.Sprintf("\t%-24s ; $%04X", text, address)
+}
+
+func TestDisassembler_Follow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		program  []byte
+		vectors  [3]uint16 // NMI, reset and IRQ
+		entries  []uint16
+		labeler  Labeler
+		end      uint16
+		expected []string
+	}{
+		{
+			name: "follows calls, branches and jumps from the vectors",
+			program: []byte{
+				0x20, 0x08, 0x80, // JSR $8008
+				0xd0, 0xfb, // BNE $8000
+				0x4c, 0x00, 0x80, // JMP $8000
+				0x60,             // RTS
+				0x01, 0x02, 0x03, // data
+				0x40, // RTI
+			},
+			vectors: [3]uint16{0x800c, 0x8000, 0x800c},
+			end:     0x800c,
+			expected: []string{
+				"reset:",
+				listingLine("jsr sub_8008", 0x8000),
+				listingLine("bne reset", 0x8003),
+				listingLine("jmp reset", 0x8005),
+				"sub_8008:",
+				listingLine("rts", 0x8008),
+				listingLine(".byte $01,$02,$03", 0x8009),
+				"nmi:",
+				listingLine("rti", 0x800c),
+			},
+		},
+		{
+			name: "unreached bytes are split into lines of data",
+			program: []byte{
+				0x60, // RTS
+				0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09,
+			},
+			vectors: [3]uint16{0x0000, 0x8000, 0x0000},
+			end:     0x800a,
+			expected: []string{
+				"reset:",
+				listingLine("rts", 0x8000),
+				listingLine(".byte $00,$01,$02,$03,$04,$05,$06,$07", 0x8001),
+				listingLine(".byte $08,$09", 0x8009),
+			},
+		},
+		{
+			name: "entry points are followed",
+			program: []byte{
+				0xff,       // data
+				0xa9, 0x01, // LDA #$01
+				0x00, // BRK
+			},
+			vectors: [3]uint16{0x0000, 0x0000, 0x0000},
+			entries: []uint16{0x8001},
+			end:     0x8003,
+			expected: []string{
+				listingLine(".byte $FF", 0x8000),
+				"entry_8001:",
+				listingLine("lda #$01", 0x8001),
+				listingLine("brk", 0x8003),
+			},
+		},
+		{
+			name: "split jump table dispatched by rts",
+			program: []byte{
+				0xbd, 0x12, 0x80, // LDA $8012,X
+				0x48,             // PHA
+				0xbd, 0x10, 0x80, // LDA $8010,X
+				0x48, // PHA
+				0x60, // RTS
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x13, 0x14, // low bytes
+				0x80, 0x80, // high bytes
+				0xea, // NOP
+				0x60, // RTS
+			},
+			vectors: [3]uint16{0x0000, 0x8000, 0x0000},
+			end:     0x8015,
+			expected: []string{
+				"reset:",
+				listingLine("lda $8012,x", 0x8000),
+				listingLine("pha", 0x8003),
+				listingLine("lda $8010,x", 0x8004),
+				listingLine("pha", 0x8007),
+				listingLine("rts", 0x8008),
+				listingLine(".byte $00,$00,$00,$00,$00,$00,$00", 0x8009),
+				"tbl_8010:",
+				listingLine(".byte $13,$14", 0x8010),
+				"tbl_8012:",
+				listingLine(".byte $80,$80", 0x8012),
+				"loc_8014:",
+				listingLine("nop", 0x8014),
+				"loc_8015:",
+				listingLine("rts", 0x8015),
+			},
+		},
+		{
+			name: "interleaved jump table dispatched by rts",
+			program: []byte{
+				0xbd, 0x0d, 0x80, // LDA $800D,X
+				0x48,             // PHA
+				0xbd, 0x0c, 0x80, // LDA $800C,X
+				0x48,             // PHA
+				0x60,             // RTS
+				0x00, 0x00, 0x00, // data
+				0x0f, 0x80, // $8010 - 1
+				0x10, 0x80, // $8011 - 1
+				0xea, // NOP
+				0x60, // RTS
+			},
+			vectors: [3]uint16{0x0000, 0x8000, 0x0000},
+			end:     0x8011,
+			expected: []string{
+				"reset:",
+				listingLine("lda $800D,x", 0x8000),
+				listingLine("pha", 0x8003),
+				listingLine("lda $800C,x", 0x8004),
+				listingLine("pha", 0x8007),
+				listingLine("rts", 0x8008),
+				listingLine(".byte $00,$00,$00", 0x8009),
+				"tbl_800C:",
+				listingLine(".byte $0F,$80,$10,$80", 0x800c),
+				"loc_8010:",
+				listingLine("nop", 0x8010),
+				"loc_8011:",
+				listingLine("rts", 0x8011),
+			},
+		},
+		{
+			name: "labels inside an instruction are dropped",
+			program: []byte{
+				0x2c, 0xa9, 0x01, // BIT $01A9
+				0xf0, 0xfc, // BEQ $8001
+				0x60, // RTS
+			},
+			vectors: [3]uint16{0x0000, 0x8000, 0x0000},
+			end:     0x8005,
+			expected: []string{
+				"reset:",
+				listingLine("bit $01A9", 0x8000),
+				listingLine("beq $8001", 0x8003),
+				listingLine("rts", 0x8005),
+			},
+		},
+		{
+			name: "symbols name their addresses in place of generated labels",
+			program: []byte{
+				0x20, 0x06, 0x80, // JSR $8006
+				0x4c, 0x00, 0x80, // JMP $8000
+				0x85, 0x10, // STA $10
+				0x60,       // RTS
+				0x01, 0x02, // data
+			},
+			vectors: [3]uint16{0x0000, 0x8000, 0x0000},
+			labeler: testLabeler{
+				0x0010: {name: "frame", size: 1},
+				0x8000: {name: "main", size: 1, comment: "loops forever"},
+				0x8006: {name: "tick", size: 1},
+				0x8009: {name: "table", size: 2, comment: "first line\nsecond line"},
+			},
+			end: 0x800a,
+			expected: []string{
+				"main:",
+				"\t; loops forever",
+				listingLine("jsr tick", 0x8000),
+				listingLine("jmp main", 0x8003),
+				"tick:",
+				listingLine("sta frame", 0x8006),
+				listingLine("rts", 0x8008),
+				"table:",
+				"\t; first line",
+				"\t; second line",
+				listingLine(".byte $01,$02", 0x8009),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bus.RAM{}
+			copy(r[0x8000:], tc.program)
+			for i, vector := range tc.vectors {
+				r[0xfffa+2*i] = byte(vector)
+				r[0xfffb+2*i] = byte(vector >> 8)
+			}
+			d := NewDisassembler(bus.NewBus(r), RP2A03)
+			d.SetLabeler(tc.labeler)
+
+			listing := d.Follow(0x8000, tc.end, tc.entries...)
+
+			assert.Equal(t, tc.expected, listing.Format(CA65Formatter{}))
+		})
+	}
+}
+
+func TestDisassembler_Follow_EndBeforeStart(t *testing.T) {
+	r := bus.RAM{}
+	r[0xfffc], r[0xfffd] = 0x00, 0x90
+	d := NewDisassembler(bus.NewBus(r), RP2A03)
+
+	listing := d.Follow(0x9000, 0x8000)
+
+	assert.Empty(t, listing.Lines)
+	assert.Empty(t, listing.Labels)
+	assert.Empty(t, listing.Format(CA65Formatter{}))
+}
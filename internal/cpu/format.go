@@ -0,0 +1,218 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Formatter writes a decoded Instruction or data as assembly, without its
+// address.
+type Formatter interface {
+	Format(in Instruction) string
+	FormatData(data []byte) string
+}
+
+// target returns the label of an instruction's operand or branch target, or
+// else the address written with a format.
+func target(in Instruction, format string, address uint16) string {
+	if in.Label != "" {
+		return in.Label
+	}
+	return fmt.Sprintf(format, address)
+}
+
+// formatBytes writes data as a list of hexadecimal bytes following a directive.
+func formatBytes(directive string, format string, separator string, data []byte) string {
+	values := make([]string, len(data))
+	for i, b := range data {
+		values[i] = fmt.Sprintf(format, b)
+	}
+	return directive + " " + strings.Join(values, separator)
+}
+
+const (
+	impAssemblyString = "{IMP}"
+	immAssemblyFmt    = "#$%X {IMM}"
+	zp0AssemblyFmt    = "%s {ZP0}"
+	zpxAssemblyFmt    = "%s, X {ZPX}"
+	zpyAssemblyFmt    = "%s, Y {ZPY}"
+	izxAssemblyFmt    = "(%s, X) {IZX}"
+	izyAssemblyFmt    = "(%s), Y {IZY}"
+	absAssemblyFmt    = "%s {ABS}"
+	abxAssemblyFmt    = "%s, X {ABX}"
+	abyAssemblyFmt    = "%s, Y {ABY}"
+	indAssemblyFmt    = "(%s) {IND}"
+	relAssemblyFmt    = "$%X [%s] {REL}"
+	izpAssemblyFmt    = "(%s) {IZP}"
+	iaxAssemblyFmt    = "(%s, X) {IAX}"
+	zprAssemblyFmt    = "$%X, $%X [%s] {ZPR}"
+)
+
+// LegacyFormatter formats instructions as the disassembler always has, naming
+// the address mode after the operand, e.g. "LDA $2002 {ABS}".
+type LegacyFormatter struct{}
+
+// Format writes an instruction in the legacy style.
+func (LegacyFormatter) Format(in Instruction) string {
+	address := target(in, "$%X", in.Operand)
+	var operand string
+	switch in.Mode {
+	case Implied:
+		operand = impAssemblyString
+	case Immediate:
+		operand = fmt.Sprintf(immAssemblyFmt, in.Operand)
+	case ZeroPage:
+		operand = fmt.Sprintf(zp0AssemblyFmt, address)
+	case ZeroPageX:
+		operand = fmt.Sprintf(zpxAssemblyFmt, address)
+	case ZeroPageY:
+		operand = fmt.Sprintf(zpyAssemblyFmt, address)
+	case IndirectX:
+		operand = fmt.Sprintf(izxAssemblyFmt, address)
+	case IndirectY:
+		operand = fmt.Sprintf(izyAssemblyFmt, address)
+	case Absolute:
+		operand = fmt.Sprintf(absAssemblyFmt, address)
+	case AbsoluteX:
+		operand = fmt.Sprintf(abxAssemblyFmt, address)
+	case AbsoluteY:
+		operand = fmt.Sprintf(abyAssemblyFmt, address)
+	case Indirect:
+		operand = fmt.Sprintf(indAssemblyFmt, address)
+	case Relative:
+		operand = fmt.Sprintf(relAssemblyFmt, in.Operand, target(in, "$%X", in.Target))
+	case ZeroPageIndirect:
+		operand = fmt.Sprintf(izpAssemblyFmt, address)
+	case AbsoluteIndexedIndirect:
+		operand = fmt.Sprintf(iaxAssemblyFmt, address)
+	case ZeroPageRelative:
+		operand = fmt.Sprintf(zprAssemblyFmt, in.Operand, in.Bytes[2], target(in, "$%X", in.Target))
+	default:
+		operand = "ERROR"
+	}
+	return in.Mnemonic + " " + operand
+}
+
+// FormatData writes data in the legacy style.
+func (LegacyFormatter) FormatData(data []byte) string {
+	return formatBytes(".BYTE", "$%X", ", ", data) + " {DATA}"
+}
+
+// CA65Formatter formats instructions in the syntax of the ca65 assembler, e.g.
+// "lda a:$0002". Absolute addresses in page 0x00 are written with the "a:"
+// prefix, so that ca65 does not assemble them as zero page, and unofficial
+// operations use the names ca65 gives them under .setcpu "6502X".
+type CA65Formatter struct{}
+
+// Format writes an instruction in ca65 syntax.
+func (CA65Formatter) Format(in Instruction) string {
+	mnemonic := strings.ToLower(in.Mnemonic)
+	switch in.Mnemonic {
+	case kil:
+		mnemonic = "jam"
+	case xaa:
+		mnemonic = "ane"
+	}
+
+	var forceAbsolute string
+	if in.Operand < 0x0100 {
+		forceAbsolute = "a:"
+	}
+
+	var operand string
+	switch in.Mode {
+	case Implied:
+		if in.Accumulator() {
+			operand = "a"
+		}
+	case Immediate:
+		operand = fmt.Sprintf("#$%02X", in.Operand)
+	case ZeroPage:
+		operand = target(in, "$%02X", in.Operand)
+	case ZeroPageX:
+		operand = target(in, "$%02X", in.Operand) + ",x"
+	case ZeroPageY:
+		operand = target(in, "$%02X", in.Operand) + ",y"
+	case IndirectX:
+		operand = "(" + target(in, "$%02X", in.Operand) + ",x)"
+	case IndirectY:
+		operand = "(" + target(in, "$%02X", in.Operand) + "),y"
+	case Absolute:
+		operand = forceAbsolute + target(in, "$%04X", in.Operand)
+	case AbsoluteX:
+		operand = forceAbsolute + target(in, "$%04X", in.Operand) + ",x"
+	case AbsoluteY:
+		operand = forceAbsolute + target(in, "$%04X", in.Operand) + ",y"
+	case Indirect:
+		operand = "(" + target(in, "$%04X", in.Operand) + ")"
+	case Relative:
+		operand = target(in, "$%04X", in.Target)
+	case ZeroPageIndirect:
+		operand = "(" + target(in, "$%02X", in.Operand) + ")"
+	case AbsoluteIndexedIndirect:
+		operand = "(" + target(in, "$%04X", in.Operand) + ",x)"
+	case ZeroPageRelative:
+		operand = fmt.Sprintf("$%02X, %s", in.Operand, target(in, "$%04X", in.Target))
+	}
+	if operand == "" {
+		return mnemonic
+	}
+	return mnemonic + " " + operand
+}
+
+// FormatData writes data in ca65 syntax.
+func (CA65Formatter) FormatData(data []byte) string {
+	return formatBytes(".byte", "$%02X", ",", data)
+}
+
+// NESASMFormatter formats instructions in the syntax of the NESASM assembler,
+// e.g. "LDA [$10],Y". Zero page addresses are written with the "<" prefix, and
+// as NESASM only knows the official instructions of the NMOS 6502, the rest are
+// written as data.
+type NESASMFormatter struct{}
+
+// Format writes an instruction in NESASM syntax.
+func (NESASMFormatter) Format(in Instruction) string {
+	if !in.Official || in.Mode == ZeroPageIndirect || in.Mode == AbsoluteIndexedIndirect || in.Mode == ZeroPageRelative {
+		return NESASMFormatter{}.FormatData(in.Bytes)
+	}
+
+	var operand string
+	switch in.Mode {
+	case Implied:
+		if in.Accumulator() {
+			operand = "A"
+		}
+	case Immediate:
+		operand = fmt.Sprintf("#$%02X", in.Operand)
+	case ZeroPage:
+		operand = "<" + target(in, "$%02X", in.Operand)
+	case ZeroPageX:
+		operand = "<" + target(in, "$%02X", in.Operand) + ",X"
+	case ZeroPageY:
+		operand = "<" + target(in, "$%02X", in.Operand) + ",Y"
+	case IndirectX:
+		operand = "[" + target(in, "$%02X", in.Operand) + ",X]"
+	case IndirectY:
+		operand = "[" + target(in, "$%02X", in.Operand) + "],Y"
+	case Absolute:
+		operand = target(in, "$%04X", in.Operand)
+	case AbsoluteX:
+		operand = target(in, "$%04X", in.Operand) + ",X"
+	case AbsoluteY:
+		operand = target(in, "$%04X", in.Operand) + ",Y"
+	case Indirect:
+		operand = "[" + target(in, "$%04X", in.Operand) + "]"
+	case Relative:
+		operand = target(in, "$%04X", in.Target)
+	}
+	if operand == "" {
+		return in.Mnemonic
+	}
+	return in.Mnemonic + " " + operand
+}
+
+// FormatData writes data in NESASM syntax.
+func (NESASMFormatter) FormatData(data []byte) string {
+	return formatBytes(".db", "$%02X", ",", data)
+}
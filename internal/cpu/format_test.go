@@ -0,0 +1,167 @@
+package cpu
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		variant Variant
+		program []byte
+		labeler Labeler
+
+		expectedLegacy string
+		expectedCA65   string
+		expectedNESASM string
+	}{
+		{
+			name:           "implied",
+			program:        []byte{0xea},
+			expectedLegacy: "NOP {IMP}",
+			expectedCA65:   "nop",
+			expectedNESASM: "NOP",
+		},
+		{
+			name:           "accumulator",
+			program:        []byte{0x4a},
+			expectedLegacy: "LSR {IMP}",
+			expectedCA65:   "lsr a",
+			expectedNESASM: "LSR A",
+		},
+		{
+			name:           "immediate",
+			program:        []byte{0xa9, 0x0a},
+			expectedLegacy: "LDA #$A {IMM}",
+			expectedCA65:   "lda #$0A",
+			expectedNESASM: "LDA #$0A",
+		},
+		{
+			name:           "zero page",
+			program:        []byte{0x85, 0x10},
+			expectedLegacy: "STA $10 {ZP0}",
+			expectedCA65:   "sta $10",
+			expectedNESASM: "STA <$10",
+		},
+		{
+			name:           "zero page y",
+			program:        []byte{0xb6, 0x10},
+			expectedLegacy: "LDX $10, Y {ZPY}",
+			expectedCA65:   "ldx $10,y",
+			expectedNESASM: "LDX <$10,Y",
+		},
+		{
+			name:           "absolute",
+			program:        []byte{0xad, 0x02, 0x20},
+			expectedLegacy: "LDA $2002 {ABS}",
+			expectedCA65:   "lda $2002",
+			expectedNESASM: "LDA $2002",
+		},
+		{
+			name:           "absolute in page 0x00",
+			program:        []byte{0xbd, 0x10, 0x00},
+			expectedLegacy: "LDA $10, X {ABX}",
+			expectedCA65:   "lda a:$0010,x",
+			expectedNESASM: "LDA $0010,X",
+		},
+		{
+			name:           "indirect",
+			program:        []byte{0x6c, 0xfc, 0xff},
+			expectedLegacy: "JMP ($FFFC) {IND}",
+			expectedCA65:   "jmp ($FFFC)",
+			expectedNESASM: "JMP [$FFFC]",
+		},
+		{
+			name:           "indirect x",
+			program:        []byte{0xa1, 0x10},
+			expectedLegacy: "LDA ($10, X) {IZX}",
+			expectedCA65:   "lda ($10,x)",
+			expectedNESASM: "LDA [$10,X]",
+		},
+		{
+			name:           "indirect y",
+			program:        []byte{0xb1, 0x10},
+			expectedLegacy: "LDA ($10), Y {IZY}",
+			expectedCA65:   "lda ($10),y",
+			expectedNESASM: "LDA [$10],Y",
+		},
+		{
+			name:           "relative",
+			program:        []byte{0x10, 0xfe},
+			expectedLegacy: "BPL $FE [$8000] {REL}",
+			expectedCA65:   "bpl $8000",
+			expectedNESASM: "BPL $8000",
+		},
+		{
+			name:           "unofficial",
+			program:        []byte{0x02},
+			expectedLegacy: "KIL {IMP}",
+			expectedCA65:   "jam",
+			expectedNESASM: ".db $02",
+		},
+		{
+			name:           "zero page relative",
+			variant:        R65C02,
+			program:        []byte{0x0f, 0x10, 0x02},
+			expectedLegacy: "BBR0 $10, $2 [$8005] {ZPR}",
+			expectedCA65:   "bbr0 $10, $8005",
+			expectedNESASM: ".db $0F,$10,$02",
+		},
+		{
+			name:           "labelled zero page x",
+			program:        []byte{0xb5, 0x10},
+			labeler:        testLabeler{0x0010: {name: "frame", size: 1}},
+			expectedLegacy: "LDA frame, X {ZPX}",
+			expectedCA65:   "lda frame,x",
+			expectedNESASM: "LDA <frame,X",
+		},
+		{
+			name:           "labelled indirect y",
+			program:        []byte{0xb1, 0x10},
+			labeler:        testLabeler{0x0010: {name: "pointer", size: 2}},
+			expectedLegacy: "LDA (pointer), Y {IZY}",
+			expectedCA65:   "lda (pointer),y",
+			expectedNESASM: "LDA [pointer],Y",
+		},
+		{
+			name:           "labelled absolute in page 0x00",
+			program:        []byte{0x9d, 0x11, 0x00},
+			labeler:        testLabeler{0x0010: {name: "pointer", size: 2}},
+			expectedLegacy: "STA pointer+1, X {ABX}",
+			expectedCA65:   "sta a:pointer+1,x",
+			expectedNESASM: "STA pointer+1,X",
+		},
+		{
+			name:           "labelled indirect",
+			program:        []byte{0x6c, 0x00, 0x03},
+			labeler:        testLabeler{0x0300: {name: "vector", size: 2}},
+			expectedLegacy: "JMP (vector) {IND}",
+			expectedCA65:   "jmp (vector)",
+			expectedNESASM: "JMP [vector]",
+		},
+		{
+			name:           "labelled relative",
+			program:        []byte{0x10, 0xfe},
+			labeler:        testLabeler{0x8000: {name: "wait", size: 1}},
+			expectedLegacy: "BPL $FE [wait] {REL}",
+			expectedCA65:   "bpl wait",
+			expectedNESASM: "BPL wait",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bus.RAM{}
+			copy(r[0x8000:], tc.program)
+			d := NewDisassembler(bus.NewBus(r), tc.variant)
+			d.SetLabeler(tc.labeler)
+			in := d.Decode(0x8000)
+
+			assert.Equal(t, tc.expectedLegacy, LegacyFormatter{}.Format(in), "incorrect legacy format")
+			assert.Equal(t, tc.expectedCA65, CA65Formatter{}.Format(in), "incorrect ca65 format")
+			assert.Equal(t, tc.expectedNESASM, NESASMFormatter{}.Format(in), "incorrect NESASM format")
+		})
+	}
+}
@@ -8,6 +8,9 @@ const (
 	abs = "ABS"; abx = "ABX"; aby = "ABY"
 	ind = "IND"; izx = "IZX"; izy = "IZY"
 
+	// 65C02 address modes
+	izp = "IZP"; iax = "IAX"; zpr = "ZPR"
+
 	// operations
 	adc = "ADC"; and = "AND"; asl = "ASL"
 	bcc = "BCC"; bcs = "BCS"; beq = "BEQ"; bit = "BIT"; bmi = "BMI"; bne = "BNE"; bpl = "BPL"; brk = "BRK";  bvc = "BVC"; bvs = "BVS"
@@ -23,52 +26,301 @@ const (
 	rol = "ROL"; ror = "ROR"; rti = "RTI"; rts = "RTS"
 	sbc = "SBC"; sec = "SEC"; sed = "SED"; sei = "SEI"; sta = "STA"; stx = "STX"; sty = "STY"
 	tax = "TAX"; tay = "TAY"; tsx = "TSX"; txa = "TXA"; txs = "TXS"; tya = "TYA"
-	xxx = "???"
+
+	// unofficial operations
+	alr = "ALR"; anc = "ANC"; arr = "ARR"; axs = "AXS"
+	dcp = "DCP"
+	isc = "ISC"
+	kil = "KIL"
+	las = "LAS"; lax = "LAX"
+	rla = "RLA"; rra = "RRA"
+	sax = "SAX"; sha = "SHA"; shx = "SHX"; shy = "SHY"; slo = "SLO"; sre = "SRE"
+	tas = "TAS"
+	xaa = "XAA"
+
+	// 65C02 operations
+	bra = "BRA"
+	phx = "PHX"; phy = "PHY"; plx = "PLX"; ply = "PLY"
+	stz = "STZ"
+	trb = "TRB"; tsb = "TSB"
+
+	// Rockwell operations, which have the bit they work on appended
+	bbr = "BBR"; bbs = "BBS"
+	rmb = "RMB"
+	smb = "SMB"
 )
 
+// access is the way an instruction accesses the memory at its effective
+// address, which decides the cycles it takes after the address is worked out.
+type access byte
+
 const (
-	assemblyInstructionFmt = "$%X: %s %s"
-	impAssemblyString      = " {IMP}"
-	immAssemblyFmt         = "#$%X {IMM}"
-	zp0AssemblyFmt         = "$%X {ZP0}"
-	zpxAssemblyFmt         = "$%X, X {ZPX}"
-	zpyAssemblyFmt         = "$%X, Y {ZPY}"
-	izxAssemblyFmt         = "($%X, X) {IZX}"
-	izyAssemblyFmt         = "($%X), Y {IZY}"
-	absAssemblyFmt         = "$%X {ABS}"
-	abxAssemblyFmt         = "$%X, X {ABX}"
-	abyAssemblyFmt         = "$%X, Y {ABY}"
-	indAssemblyFmt         = "($%X) {IND}"
-	relAssemblyFmt         = "$%X [$%X] {REL}"
+	// accessRead instructions read their operand, and indexed reads skip
+	// the cycle fixing up the address if no page is crossed.
+	accessRead access = iota
+	// accessWrite instructions write to their effective address.
+	accessWrite
+	// accessReadModifyWrite instructions read their operand, write it back
+	// unchanged while modifying it, then write the result.
+	accessReadModifyWrite
 )
 
+// microOp is a single cycle of an instruction or interrupt sequence, which
+// makes exactly the Bus access the 6502 makes on that cycle.
+type microOp struct {
+	run   func()
+	write bool // the cycle writes to the Bus, so DMA cannot halt the CPU on it
+}
+
 type instruction struct {
-	operation      string
-	addressMode    string
-	performOp      func() uint8
-	setAddressMode func() uint8
-	cycles         uint8
+	operation     string
+	addressMode   string
+	performOp     func()
+	cycles        uint8
+	access        access
+	pageCrossOnly bool      // the cycle fixing up an indexed address is skipped if no page is crossed
+	official      bool      // the instruction is documented by the maker of the chip
+	steps         []microOp // the cycles after the opcode is fetched
 }
 
 type mos6502LookupTable [256]instruction
 
+// definition is an entry of the lookup table before its access and cycles are
+// worked out.
+type definition struct {
+	operation   string
+	addressMode string
+	performOp   func()
+	cycles      uint8
+}
+
 func buildMos502LookupTable(c *Mos6502) mos6502LookupTable {
-	return mos6502LookupTable{
-		{brk, imm, c.brk, c.imm, 7}, {ora, izx, c.ora, c.izx, 6}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 3}, {ora, zp0, c.ora, c.zp0, 3}, {asl, zp0, c.asl, c.zp0, 5}, {xxx, imp, c.xxx, c.imp, 5}, {php, imp, c.php, c.imp, 3}, {ora, imm, c.ora, c.imm, 2}, {asl, imp, c.asl, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.nop, c.imp, 4}, {ora, abs, c.ora, c.abs, 4}, {asl, abs, c.asl, c.abs, 6}, {xxx, imp, c.xxx, c.imp, 6},
-		{bpl, rel, c.bpl, c.rel, 2}, {ora, izy, c.ora, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 4}, {ora, zpx, c.ora, c.zpx, 4}, {asl, zpx, c.asl, c.zpx, 6}, {xxx, imp, c.xxx, c.imp, 6}, {clc, imp, c.clc, c.imp, 2}, {ora, aby, c.ora, c.aby, 4}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 7}, {xxx, imp, c.nop, c.imp, 4}, {ora, abx, c.ora, c.abx, 4}, {asl, abx, c.asl, c.abx, 7}, {xxx, imp, c.xxx, c.imp, 7},
-		{jsr, abs, c.jsr, c.abs, 6}, {and, izx, c.and, c.izx, 6}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {bit, zp0, c.bit, c.zp0, 3}, {and, zp0, c.and, c.zp0, 3}, {rol, zp0, c.rol, c.zp0, 5}, {xxx, imp, c.xxx, c.imp, 5}, {plp, imp, c.plp, c.imp, 4}, {and, imm, c.and, c.imm, 2}, {rol, imp, c.rol, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {bit, abs, c.bit, c.abs, 4}, {and, abs, c.and, c.abs, 4}, {rol, abs, c.rol, c.abs, 6}, {xxx, imp, c.xxx, c.imp, 6},
-		{bmi, rel, c.bmi, c.rel, 2}, {and, izy, c.and, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 4}, {and, zpx, c.and, c.zpx, 4}, {rol, zpx, c.rol, c.zpx, 6}, {xxx, imp, c.xxx, c.imp, 6}, {sec, imp, c.sec, c.imp, 2}, {and, aby, c.and, c.aby, 4}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 7}, {xxx, imp, c.nop, c.imp, 4}, {and, abx, c.and, c.abx, 4}, {rol, abx, c.rol, c.abx, 7}, {xxx, imp, c.xxx, c.imp, 7},
-		{rti, imp, c.rti, c.imp, 6}, {eor, izx, c.eor, c.izx, 6}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 3}, {eor, zp0, c.eor, c.zp0, 3}, {lsr, zp0, c.lsr, c.zp0, 5}, {xxx, imp, c.xxx, c.imp, 5}, {pha, imp, c.pha, c.imp, 3}, {eor, imm, c.eor, c.imm, 2}, {lsr, imp, c.lsr, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {jmp, abs, c.jmp, c.abs, 3}, {eor, abs, c.eor, c.abs, 4}, {lsr, abs, c.lsr, c.abs, 6}, {xxx, imp, c.xxx, c.imp, 6},
-		{bvc, rel, c.bvc, c.rel, 2}, {eor, izy, c.eor, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 4}, {eor, zpx, c.eor, c.zpx, 4}, {lsr, zpx, c.lsr, c.zpx, 6}, {xxx, imp, c.xxx, c.imp, 6}, {cli, imp, c.cli, c.imp, 2}, {eor, aby, c.eor, c.aby, 4}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 7}, {xxx, imp, c.nop, c.imp, 4}, {eor, abx, c.eor, c.abx, 4}, {lsr, abx, c.lsr, c.abx, 7}, {xxx, imp, c.xxx, c.imp, 7},
-		{rts, imp, c.rts, c.imp, 6}, {adc, izx, c.adc, c.izx, 6}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 3}, {adc, zp0, c.adc, c.zp0, 3}, {ror, zp0, c.ror, c.zp0, 5}, {xxx, imp, c.xxx, c.imp, 5}, {pla, imp, c.pla, c.imp, 4}, {adc, imm, c.adc, c.imm, 2}, {ror, imp, c.ror, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {jmp, ind, c.jmp, c.ind, 5}, {adc, abs, c.adc, c.abs, 4}, {ror, abs, c.ror, c.abs, 6}, {xxx, imp, c.xxx, c.imp, 6},
-		{bvs, rel, c.bvs, c.rel, 2}, {adc, izy, c.adc, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 4}, {adc, zpx, c.adc, c.zpx, 4}, {ror, zpx, c.ror, c.zpx, 6}, {xxx, imp, c.xxx, c.imp, 6}, {sei, imp, c.sei, c.imp, 2}, {adc, aby, c.adc, c.aby, 4}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 7}, {xxx, imp, c.nop, c.imp, 4}, {adc, abx, c.adc, c.abx, 4}, {ror, abx, c.ror, c.abx, 7}, {xxx, imp, c.xxx, c.imp, 7},
-		{xxx, imp, c.nop, c.imp, 2}, {sta, izx, c.sta, c.izx, 6}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 6}, {sty, zp0, c.sty, c.zp0, 3}, {sta, zp0, c.sta, c.zp0, 3}, {stx, zp0, c.stx, c.zp0, 3}, {xxx, imp, c.xxx, c.imp, 3}, {dey, imp, c.dey, c.imp, 2}, {xxx, imp, c.nop, c.imp, 2}, {txa, imp, c.txa, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {sty, abs, c.sty, c.abs, 4}, {sta, abs, c.sta, c.abs, 4}, {stx, abs, c.stx, c.abs, 4}, {xxx, imp, c.xxx, c.imp, 4},
-		{bcc, rel, c.bcc, c.rel, 2}, {sta, izy, c.sta, c.izy, 6}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 6}, {sty, zpx, c.sty, c.zpx, 4}, {sta, zpx, c.sta, c.zpx, 4}, {stx, zpy, c.stx, c.zpy, 4}, {xxx, imp, c.xxx, c.imp, 4}, {tya, imp, c.tya, c.imp, 2}, {sta, aby, c.sta, c.aby, 5}, {txs, imp, c.txs, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 5}, {xxx, imp, c.nop, c.imp, 5}, {sta, abx, c.sta, c.abx, 5}, {xxx, imp, c.xxx, c.imp, 5}, {xxx, imp, c.xxx, c.imp, 5},
-		{ldy, imm, c.ldy, c.imm, 2}, {lda, izx, c.lda, c.izx, 6}, {ldx, imm, c.ldx, c.imm, 2}, {xxx, imp, c.xxx, c.imp, 6}, {ldy, zp0, c.ldy, c.zp0, 3}, {lda, zp0, c.lda, c.zp0, 3}, {ldx, zp0, c.ldx, c.zp0, 3}, {xxx, imp, c.xxx, c.imp, 3}, {tay, imp, c.tay, c.imp, 2}, {lda, imm, c.lda, c.imm, 2}, {tax, imp, c.tax, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {ldy, abs, c.ldy, c.abs, 4}, {lda, abs, c.lda, c.abs, 4}, {ldx, abs, c.ldx, c.abs, 4}, {xxx, imp, c.xxx, c.imp, 4},
-		{bcs, rel, c.bcs, c.rel, 2}, {lda, izy, c.lda, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 5}, {ldy, zpx, c.ldy, c.zpx, 4}, {lda, zpx, c.lda, c.zpx, 4}, {ldx, zpy, c.ldx, c.zpy, 4}, {xxx, imp, c.xxx, c.imp, 4}, {clv, imp, c.clv, c.imp, 2}, {lda, aby, c.lda, c.aby, 4}, {tsx, imp, c.tsx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 4}, {ldy, abx, c.ldy, c.abx, 4}, {lda, abx, c.lda, c.abx, 4}, {ldx, aby, c.ldx, c.aby, 4}, {xxx, imp, c.xxx, c.imp, 4},
-		{cpy, imm, c.cpy, c.imm, 2}, {cmp, izx, c.cmp, c.izx, 6}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {cpy, zp0, c.cpy, c.zp0, 3}, {cmp, zp0, c.cmp, c.zp0, 3}, {dec, zp0, c.dec, c.zp0, 5}, {xxx, imp, c.xxx, c.imp, 5}, {iny, imp, c.iny, c.imp, 2}, {cmp, imm, c.cmp, c.imm, 2}, {dex, imp, c.dex, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 2}, {cpy, abs, c.cpy, c.abs, 4}, {cmp, abs, c.cmp, c.abs, 4}, {dec, abs, c.dec, c.abs, 6}, {xxx, imp, c.xxx, c.imp, 6},
-		{bne, rel, c.bne, c.rel, 2}, {cmp, izy, c.cmp, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 4}, {cmp, zpx, c.cmp, c.zpx, 4}, {dec, zpx, c.dec, c.zpx, 6}, {xxx, imp, c.xxx, c.imp, 6}, {cld, imp, c.cld, c.imp, 2}, {cmp, aby, c.cmp, c.aby, 4}, {nop, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 7}, {xxx, imp, c.nop, c.imp, 4}, {cmp, abx, c.cmp, c.abx, 4}, {dec, abx, c.dec, c.abx, 7}, {xxx, imp, c.xxx, c.imp, 7},
-		{cpx, imm, c.cpx, c.imm, 2}, {sbc, izx, c.sbc, c.izx, 6}, {xxx, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {cpx, zp0, c.cpx, c.zp0, 3}, {sbc, zp0, c.sbc, c.zp0, 3}, {inc, zp0, c.inc, c.zp0, 5}, {xxx, imp, c.xxx, c.imp, 5}, {inx, imp, c.inx, c.imp, 2}, {sbc, imm, c.sbc, c.imm, 2}, {nop, imp, c.nop, c.imp, 2}, {xxx, imp, c.sbc, c.imp, 2}, {cpx, abs, c.cpx, c.abs, 4}, {sbc, abs, c.sbc, c.abs, 4}, {inc, abs, c.inc, c.abs, 6}, {xxx, imp, c.xxx, c.imp, 6},
-		{beq, rel, c.beq, c.rel, 2}, {sbc, izy, c.sbc, c.izy, 5}, {xxx, imp, c.xxx, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 8}, {xxx, imp, c.nop, c.imp, 4}, {sbc, zpx, c.sbc, c.zpx, 4}, {inc, zpx, c.inc, c.zpx, 6}, {xxx, imp, c.xxx, c.imp, 6}, {sed, imp, c.sed, c.imp, 2}, {sbc, aby, c.sbc, c.aby, 4}, {nop, imp, c.nop, c.imp, 2}, {xxx, imp, c.xxx, c.imp, 7}, {xxx, imp, c.nop, c.imp, 4}, {sbc, abx, c.sbc, c.abx, 4}, {inc, abx, c.inc, c.abx, 7}, {xxx, imp, c.xxx, c.imp, 7},
+	definitions := [256]definition{
+		{brk, imp, c.brk, 7}, {ora, izx, c.ora, 6}, {kil, imp, c.kil, 2}, {slo, izx, c.slo, 8}, {nop, zp0, c.nop, 3}, {ora, zp0, c.ora, 3}, {asl, zp0, c.asl, 5}, {slo, zp0, c.slo, 5}, {php, imp, c.php, 3}, {ora, imm, c.ora, 2}, {asl, imp, c.asl, 2}, {anc, imm, c.anc, 2}, {nop, abs, c.nop, 4}, {ora, abs, c.ora, 4}, {asl, abs, c.asl, 6}, {slo, abs, c.slo, 6},
+		{bpl, rel, c.bpl, 2}, {ora, izy, c.ora, 5}, {kil, imp, c.kil, 2}, {slo, izy, c.slo, 8}, {nop, zpx, c.nop, 4}, {ora, zpx, c.ora, 4}, {asl, zpx, c.asl, 6}, {slo, zpx, c.slo, 6}, {clc, imp, c.clc, 2}, {ora, aby, c.ora, 4}, {nop, imp, c.nop, 2}, {slo, aby, c.slo, 7}, {nop, abx, c.nop, 4}, {ora, abx, c.ora, 4}, {asl, abx, c.asl, 7}, {slo, abx, c.slo, 7},
+		{jsr, abs, c.jsr, 6}, {and, izx, c.and, 6}, {kil, imp, c.kil, 2}, {rla, izx, c.rla, 8}, {bit, zp0, c.bit, 3}, {and, zp0, c.and, 3}, {rol, zp0, c.rol, 5}, {rla, zp0, c.rla, 5}, {plp, imp, c.plp, 4}, {and, imm, c.and, 2}, {rol, imp, c.rol, 2}, {anc, imm, c.anc, 2}, {bit, abs, c.bit, 4}, {and, abs, c.and, 4}, {rol, abs, c.rol, 6}, {rla, abs, c.rla, 6},
+		{bmi, rel, c.bmi, 2}, {and, izy, c.and, 5}, {kil, imp, c.kil, 2}, {rla, izy, c.rla, 8}, {nop, zpx, c.nop, 4}, {and, zpx, c.and, 4}, {rol, zpx, c.rol, 6}, {rla, zpx, c.rla, 6}, {sec, imp, c.sec, 2}, {and, aby, c.and, 4}, {nop, imp, c.nop, 2}, {rla, aby, c.rla, 7}, {nop, abx, c.nop, 4}, {and, abx, c.and, 4}, {rol, abx, c.rol, 7}, {rla, abx, c.rla, 7},
+		{rti, imp, c.rti, 6}, {eor, izx, c.eor, 6}, {kil, imp, c.kil, 2}, {sre, izx, c.sre, 8}, {nop, zp0, c.nop, 3}, {eor, zp0, c.eor, 3}, {lsr, zp0, c.lsr, 5}, {sre, zp0, c.sre, 5}, {pha, imp, c.pha, 3}, {eor, imm, c.eor, 2}, {lsr, imp, c.lsr, 2}, {alr, imm, c.alr, 2}, {jmp, abs, c.jmp, 3}, {eor, abs, c.eor, 4}, {lsr, abs, c.lsr, 6}, {sre, abs, c.sre, 6},
+		{bvc, rel, c.bvc, 2}, {eor, izy, c.eor, 5}, {kil, imp, c.kil, 2}, {sre, izy, c.sre, 8}, {nop, zpx, c.nop, 4}, {eor, zpx, c.eor, 4}, {lsr, zpx, c.lsr, 6}, {sre, zpx, c.sre, 6}, {cli, imp, c.cli, 2}, {eor, aby, c.eor, 4}, {nop, imp, c.nop, 2}, {sre, aby, c.sre, 7}, {nop, abx, c.nop, 4}, {eor, abx, c.eor, 4}, {lsr, abx, c.lsr, 7}, {sre, abx, c.sre, 7},
+		{rts, imp, c.rts, 6}, {adc, izx, c.adc, 6}, {kil, imp, c.kil, 2}, {rra, izx, c.rra, 8}, {nop, zp0, c.nop, 3}, {adc, zp0, c.adc, 3}, {ror, zp0, c.ror, 5}, {rra, zp0, c.rra, 5}, {pla, imp, c.pla, 4}, {adc, imm, c.adc, 2}, {ror, imp, c.ror, 2}, {arr, imm, c.arr, 2}, {jmp, ind, c.jmp, 5}, {adc, abs, c.adc, 4}, {ror, abs, c.ror, 6}, {rra, abs, c.rra, 6},
+		{bvs, rel, c.bvs, 2}, {adc, izy, c.adc, 5}, {kil, imp, c.kil, 2}, {rra, izy, c.rra, 8}, {nop, zpx, c.nop, 4}, {adc, zpx, c.adc, 4}, {ror, zpx, c.ror, 6}, {rra, zpx, c.rra, 6}, {sei, imp, c.sei, 2}, {adc, aby, c.adc, 4}, {nop, imp, c.nop, 2}, {rra, aby, c.rra, 7}, {nop, abx, c.nop, 4}, {adc, abx, c.adc, 4}, {ror, abx, c.ror, 7}, {rra, abx, c.rra, 7},
+		{nop, imm, c.nop, 2}, {sta, izx, c.sta, 6}, {nop, imm, c.nop, 2}, {sax, izx, c.sax, 6}, {sty, zp0, c.sty, 3}, {sta, zp0, c.sta, 3}, {stx, zp0, c.stx, 3}, {sax, zp0, c.sax, 3}, {dey, imp, c.dey, 2}, {nop, imm, c.nop, 2}, {txa, imp, c.txa, 2}, {xaa, imm, c.xaa, 2}, {sty, abs, c.sty, 4}, {sta, abs, c.sta, 4}, {stx, abs, c.stx, 4}, {sax, abs, c.sax, 4},
+		{bcc, rel, c.bcc, 2}, {sta, izy, c.sta, 6}, {kil, imp, c.kil, 2}, {sha, izy, c.sha, 6}, {sty, zpx, c.sty, 4}, {sta, zpx, c.sta, 4}, {stx, zpy, c.stx, 4}, {sax, zpy, c.sax, 4}, {tya, imp, c.tya, 2}, {sta, aby, c.sta, 5}, {txs, imp, c.txs, 2}, {tas, aby, c.tas, 5}, {shy, abx, c.shy, 5}, {sta, abx, c.sta, 5}, {shx, aby, c.shx, 5}, {sha, aby, c.sha, 5},
+		{ldy, imm, c.ldy, 2}, {lda, izx, c.lda, 6}, {ldx, imm, c.ldx, 2}, {lax, izx, c.lax, 6}, {ldy, zp0, c.ldy, 3}, {lda, zp0, c.lda, 3}, {ldx, zp0, c.ldx, 3}, {lax, zp0, c.lax, 3}, {tay, imp, c.tay, 2}, {lda, imm, c.lda, 2}, {tax, imp, c.tax, 2}, {lax, imm, c.lax, 2}, {ldy, abs, c.ldy, 4}, {lda, abs, c.lda, 4}, {ldx, abs, c.ldx, 4}, {lax, abs, c.lax, 4},
+		{bcs, rel, c.bcs, 2}, {lda, izy, c.lda, 5}, {kil, imp, c.kil, 2}, {lax, izy, c.lax, 5}, {ldy, zpx, c.ldy, 4}, {lda, zpx, c.lda, 4}, {ldx, zpy, c.ldx, 4}, {lax, zpy, c.lax, 4}, {clv, imp, c.clv, 2}, {lda, aby, c.lda, 4}, {tsx, imp, c.tsx, 2}, {las, aby, c.las, 4}, {ldy, abx, c.ldy, 4}, {lda, abx, c.lda, 4}, {ldx, aby, c.ldx, 4}, {lax, aby, c.lax, 4},
+		{cpy, imm, c.cpy, 2}, {cmp, izx, c.cmp, 6}, {nop, imm, c.nop, 2}, {dcp, izx, c.dcp, 8}, {cpy, zp0, c.cpy, 3}, {cmp, zp0, c.cmp, 3}, {dec, zp0, c.dec, 5}, {dcp, zp0, c.dcp, 5}, {iny, imp, c.iny, 2}, {cmp, imm, c.cmp, 2}, {dex, imp, c.dex, 2}, {axs, imm, c.axs, 2}, {cpy, abs, c.cpy, 4}, {cmp, abs, c.cmp, 4}, {dec, abs, c.dec, 6}, {dcp, abs, c.dcp, 6},
+		{bne, rel, c.bne, 2}, {cmp, izy, c.cmp, 5}, {kil, imp, c.kil, 2}, {dcp, izy, c.dcp, 8}, {nop, zpx, c.nop, 4}, {cmp, zpx, c.cmp, 4}, {dec, zpx, c.dec, 6}, {dcp, zpx, c.dcp, 6}, {cld, imp, c.cld, 2}, {cmp, aby, c.cmp, 4}, {nop, imp, c.nop, 2}, {dcp, aby, c.dcp, 7}, {nop, abx, c.nop, 4}, {cmp, abx, c.cmp, 4}, {dec, abx, c.dec, 7}, {dcp, abx, c.dcp, 7},
+		{cpx, imm, c.cpx, 2}, {sbc, izx, c.sbc, 6}, {nop, imm, c.nop, 2}, {isc, izx, c.isc, 8}, {cpx, zp0, c.cpx, 3}, {sbc, zp0, c.sbc, 3}, {inc, zp0, c.inc, 5}, {isc, zp0, c.isc, 5}, {inx, imp, c.inx, 2}, {sbc, imm, c.sbc, 2}, {nop, imp, c.nop, 2}, {sbc, imm, c.sbc, 2}, {cpx, abs, c.cpx, 4}, {sbc, abs, c.sbc, 4}, {inc, abs, c.inc, 6}, {isc, abs, c.isc, 6},
+		{beq, rel, c.beq, 2}, {sbc, izy, c.sbc, 5}, {kil, imp, c.kil, 2}, {isc, izy, c.isc, 8}, {nop, zpx, c.nop, 4}, {sbc, zpx, c.sbc, 4}, {inc, zpx, c.inc, 6}, {isc, zpx, c.isc, 6}, {sed, imp, c.sed, 2}, {sbc, aby, c.sbc, 4}, {nop, imp, c.nop, 2}, {isc, aby, c.isc, 7}, {nop, abx, c.nop, 4}, {sbc, abx, c.sbc, 4}, {inc, abx, c.inc, 7}, {isc, abx, c.isc, 7},
+	}
+	if c.cmos() {
+		c.define65C02(&definitions)
+	}
+
+	var table mos6502LookupTable
+	for i, d := range definitions {
+		table[i] = instruction{
+			operation:   d.operation,
+			addressMode: d.addressMode,
+			performOp:   d.performOp,
+			cycles:      d.cycles,
+			access:      accessOf(d.operation),
+			official:    officialOf(byte(i), d.operation),
+		}
+		table[i].pageCrossOnly = c.pageCrossOnly(table[i])
+		table[i].steps = c.microOps(table[i])
+	}
+	return table
+}
+
+// define65C02 replaces the definitions of the NMOS 6502 with those of the
+// 65C02. The unofficial operations are all gone, leaving the opcodes not given
+// new instructions as NOPs of set lengths.
+func (c *Mos6502) define65C02(definitions *[256]definition) {
+	for opcode := 0x03; opcode < 0x100; opcode += 0x04 {
+		definitions[opcode] = definition{nop, imp, c.nop, 1}
+	}
+	if c.variant == R65C02 {
+		for b := byte(0); b < 8; b++ {
+			digit := string('0' + b)
+			definitions[0x07|b<<4] = definition{rmb + digit, zp0, c.rmb(b), 5}
+			definitions[0x87|b<<4] = definition{smb + digit, zp0, c.smb(b), 5}
+			definitions[0x0f|b<<4] = definition{bbr + digit, zpr, c.bbr(b), 5}
+			definitions[0x8f|b<<4] = definition{bbs + digit, zpr, c.bbs(b), 5}
+		}
+	}
+
+	changes := map[byte]definition{
+		0x02: {nop, imm, c.nop, 2}, 0x22: {nop, imm, c.nop, 2}, 0x42: {nop, imm, c.nop, 2}, 0x62: {nop, imm, c.nop, 2},
+		0x12: {ora, izp, c.ora, 5}, 0x32: {and, izp, c.and, 5}, 0x52: {eor, izp, c.eor, 5}, 0x72: {adc, izp, c.adc, 5},
+		0x92: {sta, izp, c.sta, 5}, 0xb2: {lda, izp, c.lda, 5}, 0xd2: {cmp, izp, c.cmp, 5}, 0xf2: {sbc, izp, c.sbc, 5},
+		0x04: {tsb, zp0, c.tsb, 5}, 0x14: {trb, zp0, c.trb, 5}, 0x34: {bit, zpx, c.bit, 4}, 0x64: {stz, zp0, c.stz, 3}, 0x74: {stz, zpx, c.stz, 4},
+		0x0c: {tsb, abs, c.tsb, 6}, 0x1c: {trb, abs, c.trb, 6}, 0x3c: {bit, abx, c.bit, 4}, 0x9c: {stz, abs, c.stz, 4}, 0x9e: {stz, abx, c.stz, 5},
+		0x5c: {nop, abs, c.nop, 8}, 0xdc: {nop, abs, c.nop, 4}, 0xfc: {nop, abs, c.nop, 4},
+		0x6c: {jmp, ind, c.jmp, 6}, 0x7c: {jmp, iax, c.jmp, 6},
+		0x1a: {inc, imp, c.inc, 2}, 0x3a: {dec, imp, c.dec, 2},
+		0x5a: {phy, imp, c.phy, 3}, 0x7a: {ply, imp, c.ply, 4}, 0xda: {phx, imp, c.phx, 3}, 0xfa: {plx, imp, c.plx, 4},
+		0x80: {bra, rel, c.bra, 2}, 0x89: {bit, imm, c.bit, 2},
+		0x1e: {asl, abx, c.asl, 6}, 0x3e: {rol, abx, c.rol, 6}, 0x5e: {lsr, abx, c.lsr, 6}, 0x7e: {ror, abx, c.ror, 6},
+	}
+	for opcode, d := range changes {
+		definitions[opcode] = d
+	}
+}
+
+// pageCrossOnly returns whether an instruction skips the cycle fixing up an
+// indexed address when no page is crossed. Reads always do, as do the 65C02's
+// shifts and rotates.
+func (c *Mos6502) pageCrossOnly(in instruction) bool {
+	switch {
+	case in.access == accessRead:
+		return true
+	case c.cmos() && in.access == accessReadModifyWrite:
+		return in.operation != inc && in.operation != dec
+	default:
+		return false
+	}
+}
+
+// accessOf returns the way an operation accesses memory. The bit number of the
+// Rockwell operations is ignored.
+func accessOf(operation string) access {
+	switch operation[:3] {
+	case sta, stx, sty, stz, sax, sha, shx, shy, tas:
+		return accessWrite
+	case asl, lsr, rol, ror, inc, dec, tsb, trb, rmb, smb, slo, rla, sre, rra, dcp, isc:
+		return accessReadModifyWrite
+	default:
+		return accessRead
+	}
+}
+
+// officialOf returns whether the instruction at an opcode is documented, rather
+// than an unofficial operation or a NOP at an undefined opcode.
+func officialOf(opcode byte, operation string) bool {
+	switch operation {
+	case alr, anc, arr, axs, dcp, isc, kil, las, lax, rla, rra, sax, sha, shx, shy, slo, sre, tas, xaa:
+		return false
+	case nop:
+		return opcode == 0xea
+	case sbc:
+		return opcode != 0xeb
+	default:
+		return true
+	}
+}
+
+// microOps builds the cycles of an instruction that follow the opcode fetch.
+// Most are made of the cycles of the address mode followed by those of the
+// access, but the stack and jump instructions have their own sequences.
+func (c *Mos6502) microOps(in instruction) []microOp {
+	op := in.performOp
+	if in.operation == nop && in.cycles == 1 {
+		// the 65C02's single byte NOPs are done once the opcode is fetched
+		return nil
+	}
+
+	switch in.operation {
+	case brk:
+		return []microOp{{run: op}, {run: c.pushPCH, write: true}, {run: c.pushPCL, write: true}, {run: c.pushStatus, write: true}, {run: c.readVectorLow}, {run: c.readVectorHigh}}
+	case jsr:
+		return []microOp{{run: c.readAddressLow}, {run: c.readStack}, {run: c.pushPCH, write: true}, {run: c.pushPCL, write: true}, {run: op}}
+	case rts:
+		return []microOp{{run: c.readPC}, {run: c.readStack}, {run: c.pullPCL}, {run: c.pullPCH}, {run: op}}
+	case rti:
+		return []microOp{{run: c.readPC}, {run: c.readStack}, {run: op}, {run: c.pullPCL}, {run: c.pullPCH}}
+	case pha, php, phx, phy:
+		return []microOp{{run: c.readPC}, {run: op, write: true}}
+	case pla, plp, plx, ply:
+		return []microOp{{run: c.readPC}, {run: c.readStack}, {run: op}}
+	case jmp:
+		// the jump happens as the last byte of the address is read
+		steps := c.addressMicroOps(in.addressMode)
+		last := steps[len(steps)-1].run
+		steps[len(steps)-1].run = func() {
+			last()
+			op()
+		}
+		return steps
+	}
+
+	switch in.addressMode {
+	case imp:
+		return []microOp{{run: func() {
+			c.readPC()
+			c.imp()
+			op()
+		}}}
+	case imm:
+		return []microOp{{run: func() {
+			c.imm()
+			op()
+		}}}
+	case rel:
+		return c.branchMicroOps(op)
+	case zpr:
+		// the byte tested is read twice before the offset
+		steps := []microOp{{run: c.readAddressLow}, {run: c.readOperand}, {run: c.readOperand}}
+		return append(steps, c.branchMicroOps(op)...)
+	}
+
+	steps := c.addressMicroOps(in.addressMode)
+	switch in.access {
+	case accessWrite:
+		steps = append(steps, microOp{run: op, write: true})
+	case accessReadModifyWrite:
+		steps = append(steps, microOp{run: c.readOperand}, microOp{run: c.writeOperand, write: true}, microOp{run: op, write: true})
+	default:
+		steps = append(steps, microOp{run: op})
+	}
+
+	// the 65C02's eight cycle NOP idles after reading its operand
+	for in.operation == nop && len(steps)+1 < int(in.cycles) {
+		steps = append(steps, microOp{})
+	}
+	return steps
+}
+
+// branchMicroOps builds the cycles of a branch, from reading its offset.
+func (c *Mos6502) branchMicroOps(op func()) []microOp {
+	return []microOp{{run: func() {
+		c.rel()
+		c.branching = false
+		op()
+		c.startBranch()
+	}}, {run: c.branchTaken}, {run: c.branchFixup}}
+}
+
+// addressMicroOps builds the cycles spent working out the effective address in
+// an address mode that takes an operand from memory.
+func (c *Mos6502) addressMicroOps(mode string) []microOp {
+	switch mode {
+	case zp0:
+		return []microOp{{run: c.readAddressLow}}
+	case zpx:
+		return []microOp{{run: c.readAddressLow}, {run: c.zpx}}
+	case zpy:
+		return []microOp{{run: c.readAddressLow}, {run: c.zpy}}
+	case abs:
+		return []microOp{{run: c.readAddressLow}, {run: c.readAddressHigh}}
+	case abx:
+		return []microOp{{run: c.readAddressLow}, {run: c.abx}, {run: c.fixAddress}}
+	case aby:
+		return []microOp{{run: c.readAddressLow}, {run: c.aby}, {run: c.fixAddress}}
+	case ind:
+		if c.cmos() {
+			return []microOp{{run: c.readAddressLow}, {run: c.readAddressHigh}, {run: c.readLastByte}, {run: c.readIndirectLow}, {run: c.ind}}
+		}
+		return []microOp{{run: c.readAddressLow}, {run: c.readAddressHigh}, {run: c.readIndirectLow}, {run: c.ind}}
+	case iax:
+		return []microOp{{run: c.readAddressLow}, {run: c.readAddressHigh}, {run: c.iax}, {run: c.readIndirectLow}, {run: c.ind}}
+	case izx:
+		return []microOp{{run: c.readPointer}, {run: c.izx}, {run: c.readPointerLow}, {run: c.readPointerHigh}}
+	case izy:
+		return []microOp{{run: c.readPointer}, {run: c.readPointerLow}, {run: c.izy}, {run: c.fixAddress}}
+	case izp:
+		return []microOp{{run: c.readPointer}, {run: c.readPointerLow}, {run: c.readPointerHigh}}
+	default:
+		return nil
 	}
 }
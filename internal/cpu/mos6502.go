@@ -1,9 +1,6 @@
 package cpu
 
 import (
-	"fmt"
-	"math/bits"
-
 	"github.com/Jac0bDeal/goNES/internal/bus"
 )
 
@@ -24,6 +21,32 @@ const (
 	N                  // N is the Negative flag.
 )
 
+// IRQSource identifies one of the devices sharing the CPU's IRQ line.
+type IRQSource byte
+
+// IRQ Sources
+const (
+	IRQMapper       IRQSource = 1 << iota // IRQMapper is the cartridge mapper, e.g. the MMC3 scanline counter.
+	IRQFrameCounter                       // IRQFrameCounter is the APU frame counter.
+	IRQDMC                                // IRQDMC is the APU delta modulation channel.
+	IRQExternal                           // IRQExternal is any other device on the expansion port.
+)
+
+// Variant is a version of the 6502 that the CPU behaves as.
+type Variant byte
+
+// Variants
+const (
+	RP2A03   Variant = iota // RP2A03 is the NES's 2A03, whose decimal mode is disabled.
+	NMOS6502                // NMOS6502 is the original NMOS 6502, as in the Apple II and C64.
+	WDC65C02                // WDC65C02 is the CMOS 65C02, which adds instructions and fixes the NMOS 6502's bugs.
+	R65C02                  // R65C02 is Rockwell's 65C02, which adds the bit instructions to the 65C02.
+)
+
+// resetSteps are the cycles of the reset sequence, which are spent idle as the
+// CPU is reset straight away.
+var resetSteps [8]microOp
+
 // Mos6502 represents a Mos 6502 CPU.
 type Mos6502 struct {
 	// Core registers
@@ -37,23 +60,60 @@ type Mos6502 struct {
 	// Bus
 	bus *bus.Bus
 
+	variant Variant
+
 	// Internal Vars
 	fetchedData     byte
 	temp            word
 	addressAbsolute word
 	addressRelative word
 	opcode          byte
-	cycles          byte
 	clockCount      uint32
 
+	// Micro-op Sequencing
+	steps       []microOp // cycles of the current instruction or interrupt sequence
+	step        int       // index of the next cycle in steps
+	pageCrossed bool      // indexing or branching crossed a page
+	branching   bool      // the branch condition of the current instruction held
+	pointer     byte      // zero page pointer of the indirect address modes
+	vector      word      // interrupt vector read by the current sequence
+	breaking    bool      // the current interrupt sequence was started by BRK
+
+	// Interrupt Lines
+	irq        IRQSource // sources currently asserting the IRQ line
+	nmi        bool      // level of the NMI line
+	nmiEdge    bool      // NMI line has been asserted and the NMI not yet serviced
+	polling    bool      // interrupts are polled after each cycle
+	noPoll     bool      // the poll after the current cycle is skipped
+	nmiPolled  bool      // NMI found by the poll after the last cycle
+	irqPolled  bool      // IRQ found by the poll after the last cycle
+	nmiPending bool      // NMI found by the poll before the last cycle
+	irqPending bool      // IRQ found by the poll before the last cycle
+
+	// jammed is set by the KIL opcodes, which stop the CPU until it is reset
+	jammed bool
+
 	// OpCode Lookup Table
 	lookup mos6502LookupTable
+
+	// interruptSteps are the cycles of the IRQ and NMI sequences after the
+	// first
+	interruptSteps []microOp
 }
 
-// NewMos6502 constructs and returns a pointer to an instance of Mos6502.
-func NewMos6502() *Mos6502 {
-	cpu := &Mos6502{}
+// NewMos6502 constructs and returns a pointer to an instance of Mos6502
+// behaving as a Variant.
+func NewMos6502(variant Variant) *Mos6502 {
+	cpu := &Mos6502{variant: variant}
 	cpu.lookup = buildMos502LookupTable(cpu)
+	cpu.interruptSteps = []microOp{
+		{run: cpu.readPC},
+		{run: cpu.pushPCH, write: true},
+		{run: cpu.pushPCL, write: true},
+		{run: cpu.pushStatus, write: true},
+		{run: cpu.readVectorLow},
+		{run: cpu.readVectorHigh},
+	}
 	return cpu
 }
 
@@ -66,86 +126,17 @@ func (cpu *Mos6502) ConnectBus(b *bus.Bus) {
 // Convenience Methods /////////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Disassemble builds a map of assembly strings for a given range of addresses.
-func (cpu *Mos6502) Disassemble(addressStart uint16, addressStop uint16) map[uint16]string {
-	address := addressStart
-	assemblyLines := make(map[uint16]string)
-	for address < addressStop {
-		lineAddress := address
-
-		opcode := cpu.bus.ReadByteOnly(address)
-		instruction := cpu.lookup[opcode]
-		address++
-
-		var instructionString string
-		switch instruction.addressMode {
-		case imp:
-			instructionString = impAssemblyString
-		case imm:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(immAssemblyFmt, value)
-		case zp0:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(zp0AssemblyFmt, value)
-		case zpx:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(zpxAssemblyFmt, value)
-		case zpy:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(zpyAssemblyFmt, value)
-		case izx:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(izxAssemblyFmt, value)
-		case izy:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(izyAssemblyFmt, value)
-		case abs:
-			lowByte := cpu.bus.ReadByteOnly(address)
-			address++
-			highByte := cpu.bus.ReadByteOnly(address)
-			address++
-			value := (uint16(highByte) << 8) | uint16(lowByte)
-			instructionString = fmt.Sprintf(absAssemblyFmt, value)
-		case abx:
-			lowByte := cpu.bus.ReadByteOnly(address)
-			address++
-			highByte := cpu.bus.ReadByteOnly(address)
-			address++
-			value := (uint16(highByte) << 8) | uint16(lowByte)
-			instructionString = fmt.Sprintf(abxAssemblyFmt, value)
-		case aby:
-			lowByte := cpu.bus.ReadByteOnly(address)
-			address++
-			highByte := cpu.bus.ReadByteOnly(address)
-			address++
-			value := (uint16(highByte) << 8) | uint16(lowByte)
-			instructionString = fmt.Sprintf(abyAssemblyFmt, value)
-		case ind:
-			lowByte := cpu.bus.ReadByteOnly(address)
-			address++
-			highByte := cpu.bus.ReadByteOnly(address)
-			address++
-			value := (uint16(highByte) << 8) | uint16(lowByte)
-			instructionString = fmt.Sprintf(indAssemblyFmt, value)
-		case rel:
-			value := cpu.bus.ReadByteOnly(address)
-			address++
-			instructionString = fmt.Sprintf(relAssemblyFmt, value, address+uint16(value))
-		default:
-			instructionString = "ERROR"
-		}
-
-		assemblyString := fmt.Sprintf(assemblyInstructionFmt, lineAddress, instruction.operation, instructionString)
-		assemblyLines[lineAddress] = assemblyString
-	}
+// Disassembler returns a Disassembler decoding the memory on the CPU's Bus as
+// the CPU would.
+func (cpu *Mos6502) Disassembler() *Disassembler {
+	return &Disassembler{mem: cpu.bus, lookup: &cpu.lookup}
+}
 
-	return assemblyLines
+// Disassemble decodes the instructions in a given range of addresses, in order.
+// Memory is only ever peeked, so disassembling over memory-mapped registers has
+// no side effects.
+func (cpu *Mos6502) Disassemble(addressStart uint16, addressStop uint16) []Instruction {
+	return cpu.Disassembler().Disassemble(addressStart, addressStop)
 }
 
 // GetAccumulator returns the current value of the Accumulator Register.
@@ -181,6 +172,23 @@ func (cpu *Mos6502) GetStatusFlag(f Flag) byte {
 	return 0
 }
 
+// Jammed returns whether the CPU has executed one of the KIL opcodes, which
+// lock it up until it is reset.
+func (cpu *Mos6502) Jammed() bool {
+	return cpu.jammed
+}
+
+// Complete returns whether the CPU has finished its current instruction or
+// interrupt sequence, so that the next Clock starts another.
+func (cpu *Mos6502) Complete() bool {
+	return cpu.step == len(cpu.steps)
+}
+
+// finish ends the current instruction early, skipping its remaining cycles.
+func (cpu *Mos6502) finish() {
+	cpu.steps = cpu.steps[:cpu.step]
+}
+
 // setStatusFlag sets or clears specific bit on the CPU status register.
 func (cpu *Mos6502) setStatusFlag(f Flag, v bool) {
 	if v {
@@ -192,10 +200,12 @@ func (cpu *Mos6502) setStatusFlag(f Flag, v bool) {
 
 // fetch reads the data used by the instruction into the internal
 // fetchedData variable. For instructions using the Implied address
-// mode, there is no data needed so it is skipped here. It also returns
-// the fetched data for convenience.
+// mode, there is no data needed so it is skipped here, as it is for
+// read-modify-write instructions, which read their data on an earlier
+// cycle. It also returns the fetched data for convenience.
 func (cpu *Mos6502) fetch() byte {
-	if !(cpu.lookup[cpu.opcode].addressMode == imp) {
+	instruction := cpu.lookup[cpu.opcode]
+	if instruction.addressMode != imp && instruction.access != accessReadModifyWrite {
 		cpu.fetchedData = cpu.read(cpu.addressAbsolute)
 	}
 	return cpu.fetchedData
@@ -215,28 +225,112 @@ func (cpu *Mos6502) write(address word, data byte) {
 // External Event Signals //////////////////////////////////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Clock is the clock signal.
+// Clock is the clock signal. Each cycle makes the single Bus access the 6502
+// makes on that cycle of the current instruction, including the dummy reads and
+// writes of the address modes and read-modify-write instructions. Between
+// instructions, an interrupt sequence is started instead if the interrupt lines
+// were found active when polled before the last cycle of the previous
+// instruction. While the Bus's DMA unit has a transfer in progress, the CPU is
+// halted on its next read cycle. Once jammed, the CPU does nothing on any cycle.
 func (cpu *Mos6502) Clock() {
-	// if current instruction complete, read and execute next instruction
-	if cpu.cycles == 0 {
-		opcode := cpu.read(cpu.pc)
-		instruction := cpu.lookup[opcode]
+	if cpu.jammed {
+		cpu.clockCount++
+		return
+	}
 
-		cpu.setStatusFlag(U, true)
-		cpu.pc++
+	if cpu.Complete() {
+		if cpu.halted() {
+			return
+		}
+		switch {
+		case cpu.nmiPending:
+			cpu.interrupt(0xfffa)
+		case cpu.irqPending:
+			cpu.interrupt(0xfffe)
+		default:
+			cpu.fetchOpcode()
+		}
+	} else {
+		next := cpu.steps[cpu.step]
+		if !next.write && cpu.halted() {
+			return
+		}
+		cpu.step++
+		if next.run != nil {
+			next.run()
+		}
+	}
 
-		cpu.cycles = instruction.cycles
-		additionalCycleAddr := instruction.setAddressMode()
-		additionalCycleOp := instruction.performOp()
-		cpu.cycles += additionalCycleAddr & additionalCycleOp
+	cpu.clockCount++
+	cpu.poll()
+}
 
-		cpu.setStatusFlag(U, true)
+// halted returns whether the Bus's DMA unit halts the CPU on the current cycle,
+// which is then spent idle. It must only be called on read cycles.
+func (cpu *Mos6502) halted() bool {
+	if dma := cpu.bus.DMA(); dma != nil && dma.Clock(cpu.clockCount) {
+		cpu.clockCount++
+		return true
 	}
+	return false
+}
+
+// fetchOpcode reads the opcode at the program counter, starting the cycles of
+// its instruction.
+func (cpu *Mos6502) fetchOpcode() {
+	cpu.opcode = cpu.read(cpu.pc)
+	cpu.pc++
+	cpu.setStatusFlag(U, true)
+
+	cpu.steps = cpu.lookup[cpu.opcode].steps
+	cpu.step = 0
 
-	cpu.cycles--
+	// BRK runs the interrupt sequence, during which interrupts are not polled
+	cpu.polling = cpu.lookup[cpu.opcode].operation != brk
+	if !cpu.polling {
+		cpu.clearPolls()
+	}
+}
+
+// poll samples the interrupt lines after a cycle. The interrupt sequence, if
+// any, following an instruction is decided by the poll before its last cycle,
+// so the I flag seen is the one from before CLI, SEI and PLP change it.
+func (cpu *Mos6502) poll() {
+	if !cpu.polling {
+		return
+	}
+	if cpu.noPoll {
+		cpu.noPoll = false
+		return
+	}
+	cpu.nmiPending = cpu.nmiPolled
+	cpu.irqPending = cpu.irqPolled
+	cpu.nmiPolled = cpu.nmiEdge
+	cpu.irqPolled = cpu.irq != 0 && cpu.GetStatusFlag(I) == 0
+}
+
+// clearPolls forgets the interrupts found by previous polls.
+func (cpu *Mos6502) clearPolls() {
+	cpu.nmiPending = false
+	cpu.irqPending = false
+	cpu.nmiPolled = false
+	cpu.irqPolled = false
+}
+
+// PowerCycle returns the CPU to its power on state, keeping its Variant and
+// Bus. Reset must be called after it to read the reset vector.
+func (cpu *Mos6502) PowerCycle() {
+	// the instruction tables are bound to the CPU, so they are kept
+	*cpu = Mos6502{
+		bus:            cpu.bus,
+		variant:        cpu.variant,
+		lookup:         cpu.lookup,
+		interruptSteps: cpu.interruptSteps,
+	}
 }
 
-// Reset signals the cpu to reset to a known state.
+// Reset signals the cpu to reset to a known state. The reset vector is read
+// straight away, and the 8 cycles of the reset sequence are spent idle.
 func (cpu *Mos6502) Reset() {
 	cpu.addressAbsolute = 0xfffc
 	lowByte := cpu.read(cpu.addressAbsolute)
@@ -254,55 +348,239 @@ func (cpu *Mos6502) Reset() {
 	cpu.addressAbsolute = 0x0000
 	cpu.fetchedData = 0x00
 
-	cpu.cycles = 8
+	cpu.nmiEdge = false
+	cpu.polling = false
+	cpu.noPoll = false
+	cpu.clearPolls()
+	cpu.jammed = false
+
+	cpu.steps = resetSteps[:]
+	cpu.step = 0
+}
+
+// SetIRQ asserts or releases the IRQ line on behalf of a source. The line is
+// level-triggered and shared: it stays asserted until every source asserting it
+// has released it. It is polled before the last cycle of each instruction, and
+// if asserted while the Interrupt Disable (I) flag is 0 the CPU services it once
+// that instruction completes. Sources must keep the line asserted until the
+// interrupt handler acknowledges them.
+func (cpu *Mos6502) SetIRQ(source IRQSource, asserted bool) {
+	if asserted {
+		cpu.irq |= source
+	} else {
+		cpu.irq &^= source
+	}
+}
+
+// SetNMI sets the level of the NMI line. The line is edge-triggered: asserting
+// a released line latches a single NMI, which is serviced after the instruction
+// during which it is polled, and the line must be released and asserted again to
+// cause another. An NMI arriving early in a BRK or IRQ sequence hijacks it,
+// sending it to the NMI vector instead.
+func (cpu *Mos6502) SetNMI(asserted bool) {
+	if asserted && !cpu.nmi {
+		cpu.nmiEdge = true
+	}
+	cpu.nmi = asserted
+}
+
+// interrupt starts the IRQ or NMI sequence, which pushes the program counter
+// and status register to the stack and jumps to the address held at the passed
+// interrupt vector. Its first cycle reads the opcode that is not executed.
+func (cpu *Mos6502) interrupt(vector word) {
+	cpu.readPC()
+
+	cpu.vector = vector
+	cpu.breaking = false
+	if vector == 0xfffa {
+		cpu.nmiEdge = false
+	}
+
+	cpu.steps = cpu.interruptSteps
+	cpu.step = 0
+	cpu.polling = false
+	cpu.clearPolls()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Micro-ops ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// readPC is a dummy read of the byte at the program counter, made by
+// instructions that have no operand to read on their second cycle.
+func (cpu *Mos6502) readPC() {
+	cpu.read(cpu.pc)
+}
+
+// readStack is a dummy read of the top of the stack, made while the stack
+// pointer is being incremented.
+func (cpu *Mos6502) readStack() {
+	cpu.read(0x0100 + word(cpu.stkp))
+}
+
+// readAddressLow reads the low byte of an address following the opcode.
+func (cpu *Mos6502) readAddressLow() {
+	cpu.addressAbsolute = word(cpu.read(cpu.pc))
+	cpu.pc++
 }
 
-// InterruptRequest is the interrupt request signal. Requires the Interrupt
-// Disable (I) flag to be set to 0 or else nothing happens. The currently running
-// instruction is allowed to complete before the Interrupt Request does its thing.
-func (cpu *Mos6502) InterruptRequest() {
-	if cpu.GetStatusFlag(I) == 0 {
-		cpu.write(0x0100+word(cpu.stkp), byte((cpu.pc>>8)&0x00ff))
-		cpu.stkp--
-		cpu.write(0x0100+word(cpu.stkp), byte(cpu.pc&0x00ff))
-		cpu.stkp--
+// readAddressHigh reads the high byte of an address following the opcode.
+func (cpu *Mos6502) readAddressHigh() {
+	cpu.addressAbsolute |= word(cpu.read(cpu.pc)) << 8
+	cpu.pc++
+}
 
-		cpu.setStatusFlag(B, false)
-		cpu.setStatusFlag(U, true)
-		cpu.setStatusFlag(I, true)
-		cpu.write(0x0100+word(cpu.stkp), cpu.status)
-		cpu.stkp--
+// readPointer reads the zero page pointer of the indirect address modes.
+func (cpu *Mos6502) readPointer() {
+	cpu.pointer = cpu.read(cpu.pc)
+	cpu.pc++
+}
 
-		cpu.addressAbsolute = 0xfffe
-		lowByte := cpu.read(cpu.addressAbsolute)
-		highByte := cpu.read(cpu.addressAbsolute + 1)
-		cpu.pc = (word(highByte) << 8) | word(lowByte)
+// readPointerLow reads the low byte of the address held at the pointer.
+func (cpu *Mos6502) readPointerLow() {
+	cpu.addressAbsolute = word(cpu.read(word(cpu.pointer)))
+}
 
-		cpu.cycles = 7
+// readPointerHigh reads the high byte of the address held at the pointer,
+// wrapping around page 0x00.
+func (cpu *Mos6502) readPointerHigh() {
+	cpu.addressAbsolute |= word(cpu.read(word(cpu.pointer+1))) << 8
+}
+
+// readIndirectLow reads the low byte of the address pointed to by the
+// Indirect address mode.
+func (cpu *Mos6502) readIndirectLow() {
+	cpu.temp = word(cpu.read(cpu.addressAbsolute))
+}
+
+// readLastByte is a dummy read of the last byte of the instruction, which the
+// 65C02 makes in place of the NMOS 6502's reads from invalid addresses.
+func (cpu *Mos6502) readLastByte() {
+	cpu.read(cpu.pc - 1)
+}
+
+// fixAddress is the extra cycle of the indexed address modes. The high byte of
+// the address is fixed up while the address before the fix is read, so a dummy
+// read is made from the wrong page when indexing crossed one.
+func (cpu *Mos6502) fixAddress() {
+	switch {
+	case cpu.pageCrossed && cpu.cmos():
+		cpu.readLastByte()
+	case cpu.pageCrossed:
+		cpu.read(cpu.addressAbsolute - 0x0100)
+	default:
+		cpu.read(cpu.addressAbsolute)
 	}
 }
 
-// NonMaskableInterrupt is the non-maskable interrupt request signal, which
-// cannot be ignored. It has the same behavior as the normal Interrupt Request
-// but reads 0xfffa to set the program counter.
-func (cpu *Mos6502) NonMaskableInterrupt() {
+// readOperand reads the data a read-modify-write instruction modifies.
+func (cpu *Mos6502) readOperand() {
+	cpu.fetchedData = cpu.read(cpu.addressAbsolute)
+}
+
+// writeOperand is the dummy write of a read-modify-write instruction, which
+// writes the unmodified data back while modifying it. The 65C02 reads the data
+// again instead.
+func (cpu *Mos6502) writeOperand() {
+	if cpu.cmos() {
+		cpu.read(cpu.addressAbsolute)
+		return
+	}
+	cpu.write(cpu.addressAbsolute, cpu.fetchedData)
+}
+
+// pushPCH pushes the high byte of the program counter to the stack.
+func (cpu *Mos6502) pushPCH() {
 	cpu.write(0x0100+word(cpu.stkp), byte((cpu.pc>>8)&0x00ff))
 	cpu.stkp--
+}
+
+// pushPCL pushes the low byte of the program counter to the stack.
+func (cpu *Mos6502) pushPCL() {
 	cpu.write(0x0100+word(cpu.stkp), byte(cpu.pc&0x00ff))
 	cpu.stkp--
+}
 
-	cpu.setStatusFlag(B, false)
+// pullPCL pulls the low byte of the program counter off the stack.
+func (cpu *Mos6502) pullPCL() {
+	cpu.stkp++
+	cpu.addressAbsolute = word(cpu.read(0x0100 + word(cpu.stkp)))
+}
+
+// pullPCH pulls the high byte of the program counter off the stack, then sets
+// the program counter.
+func (cpu *Mos6502) pullPCH() {
+	cpu.stkp++
+	cpu.pc = word(cpu.read(0x0100+word(cpu.stkp)))<<8 | cpu.addressAbsolute
+}
+
+// pushStatus pushes the status register of a BRK or interrupt sequence to the
+// stack, then sets the I flag. The B flag is only set in the pushed status when
+// the sequence was started by BRK. An NMI arriving before now hijacks a BRK or
+// IRQ sequence, sending it to the NMI vector, though the B flag is still pushed.
+// The 65C02 also clears the D flag.
+func (cpu *Mos6502) pushStatus() {
+	if cpu.vector == 0xfffe && cpu.nmiEdge {
+		cpu.nmiEdge = false
+		cpu.vector = 0xfffa
+	}
+
+	// the status is pushed before I is set, so that returning from the
+	// handler re-enables interrupts
+	cpu.setStatusFlag(B, cpu.breaking)
 	cpu.setStatusFlag(U, true)
-	cpu.setStatusFlag(I, true)
 	cpu.write(0x0100+word(cpu.stkp), cpu.status)
 	cpu.stkp--
+	cpu.setStatusFlag(B, false)
+	cpu.setStatusFlag(I, true)
+	if cpu.cmos() {
+		cpu.setStatusFlag(D, false)
+	}
+}
 
-	cpu.addressAbsolute = 0xfffa
-	lowByte := cpu.read(cpu.addressAbsolute)
-	highByte := cpu.read(cpu.addressAbsolute + 1)
-	cpu.pc = (word(highByte) << 8) | word(lowByte)
+// readVectorLow reads the low byte of the address held at the interrupt
+// vector.
+func (cpu *Mos6502) readVectorLow() {
+	cpu.addressAbsolute = word(cpu.read(cpu.vector))
+}
+
+// readVectorHigh reads the high byte of the address held at the interrupt
+// vector, then jumps to the address.
+func (cpu *Mos6502) readVectorHigh() {
+	cpu.pc = word(cpu.read(cpu.vector+1))<<8 | cpu.addressAbsolute
+}
 
-	cpu.cycles = 8
+// startBranch follows the cycle reading the offset of a branch, ending the
+// instruction if it is not taken. Interrupts are not polled again before the
+// extra cycle of a taken branch that stays on the same page, which delays them
+// by an instruction.
+func (cpu *Mos6502) startBranch() {
+	if !cpu.branching {
+		cpu.finish()
+		return
+	}
+	from := cpu.addressAbsolute - cpu.addressRelative
+	cpu.pageCrossed = (cpu.addressAbsolute & 0xff00) != (from & 0xff00)
+	if !cpu.pageCrossed {
+		cpu.noPoll = true
+	}
+}
+
+// branchTaken is the extra cycle of a taken branch, which reads the opcode
+// after the branch while the low byte of the program counter is changed. If
+// this stays on the same page, the branch is done.
+func (cpu *Mos6502) branchTaken() {
+	cpu.read(cpu.addressAbsolute - cpu.addressRelative)
+	if !cpu.pageCrossed {
+		cpu.finish()
+	}
+}
+
+// branchFixup is the extra cycle of a taken branch crossing a page, which reads
+// from the wrong page while the high byte of the program counter is fixed.
+func (cpu *Mos6502) branchFixup() {
+	from := cpu.addressAbsolute - cpu.addressRelative
+	cpu.read((from & 0xff00) | (cpu.addressAbsolute & 0x00ff))
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -313,54 +591,37 @@ func (cpu *Mos6502) NonMaskableInterrupt() {
 // On original hardware, signals there is no additional data needed for instruction.
 // Code implementation will be used to fetch data from accumulator into
 // internal variable.
-func (cpu *Mos6502) imp() uint8 {
+func (cpu *Mos6502) imp() {
 	cpu.fetchedData = cpu.a
-	return 0
 }
 
 // imm is the Immediate address mode.
-// Signals the instruction needs the next byte after the program counter
-// as a value, so we point the point the read address at the next byte.
-func (cpu *Mos6502) imm() uint8 {
-	cpu.addressAbsolute = cpu.pc + 1
-	return 0
-}
-
-// zp0 is the Zero Page address mode.
-// Allows for reduction in program byte usage by absolutely addressing a
-// location in the first address block (0x00 - 0xff), saving a byte of
-// usage in the process.
-func (cpu *Mos6502) zp0() uint8 {
-	b := cpu.read(cpu.pc)
+// Signals the instruction needs the next byte after the opcode as a value,
+// so we point the read address at that byte and step over it.
+func (cpu *Mos6502) imm() {
+	cpu.addressAbsolute = cpu.pc
 	cpu.pc++
-	cpu.addressAbsolute = word(b) & 0x00ff
-	return 0
 }
 
-// zpx is the Zero Page w/ X Offset address mode.
-// Essentially the same as Zero Page addressing, but with an additional
-// offset of the read byte by the value in the X register.
-func (cpu *Mos6502) zpx() uint8 {
-	b := cpu.read(cpu.pc)
-	cpu.pc++
-	cpu.addressAbsolute = word(b+cpu.x) & 0x00ff
-	return 0
+// zpx is the cycle of the Zero Page w/ X Offset address mode adding the X
+// register to the zero page address, which is read while it happens. The
+// result wraps around page 0x00.
+func (cpu *Mos6502) zpx() {
+	cpu.read(cpu.addressAbsolute)
+	cpu.addressAbsolute = word(byte(cpu.addressAbsolute) + cpu.x)
 }
 
-// zpy is the Zero Page w/ Y Offset address mode.
-// The same as Zero Page w/ X offset addressing, but offsetting with the
-// Y register instead.
-func (cpu *Mos6502) zpy() uint8 {
-	b := cpu.read(cpu.pc)
-	cpu.pc++
-	cpu.addressAbsolute = word(b+cpu.y) & 0x00ff
-	return 0
+// zpy is the cycle of the Zero Page w/ Y Offset address mode adding the Y
+// register to the zero page address.
+func (cpu *Mos6502) zpy() {
+	cpu.read(cpu.addressAbsolute)
+	cpu.addressAbsolute = word(byte(cpu.addressAbsolute) + cpu.y)
 }
 
 // rel is the Relative address mode.
 // Exclusive to branch operations, the instruction is addressed within the
 // -128 to +127 range of the branched instruction.
-func (cpu *Mos6502) rel() uint8 {
+func (cpu *Mos6502) rel() {
 	b := cpu.read(cpu.pc)
 	cpu.pc++
 	if b&0x80 > 0 {
@@ -368,128 +629,75 @@ func (cpu *Mos6502) rel() uint8 {
 	} else {
 		cpu.addressRelative = word(b)
 	}
-	return 0
 }
 
-// abs is the Absolute address mode.
-// Read and load a full 16-bit address.
-func (cpu *Mos6502) abs() uint8 {
-	lowByte := cpu.read(cpu.pc)
-	cpu.pc++
-
-	highByte := cpu.read(cpu.pc)
-	cpu.pc++
-
-	cpu.addressAbsolute = (word(highByte) << 8) | word(lowByte)
-
-	return 0
+// abx is the cycle of the Absolute w/ X Offset address mode reading the high
+// byte of the address and adding the X register to it.
+func (cpu *Mos6502) abx() {
+	cpu.indexAbsolute(cpu.x)
 }
 
-// abx is the Absolute w/ X Offset address mode.
-// Same as Absolute addressing, but the read address is offset by the
-// value of the X register. If this results in a page change, then
-// an additional clock cycle is required and returned.
-func (cpu *Mos6502) abx() uint8 {
-	lowByte := cpu.read(cpu.pc)
-	cpu.pc++
-
-	highByte := cpu.read(cpu.pc)
-	cpu.pc++
-
-	a := ((word(highByte) << 8) | word(lowByte)) + word(cpu.x)
-
-	cpu.addressAbsolute = a
-
-	if (a & 0xff00) != (word(highByte) << 8) {
-		return 1
-	}
-
-	return 0
+// aby is the cycle of the Absolute w/ Y Offset address mode reading the high
+// byte of the address and adding the Y register to it.
+func (cpu *Mos6502) aby() {
+	cpu.indexAbsolute(cpu.y)
 }
 
-// abx is the Absolute w/ Y Offset address mode.
-// Same as Absolute w/ X Offset addressing, but offsetting with the
-// Y register instead.
-func (cpu *Mos6502) aby() uint8 {
-	lowByte := cpu.read(cpu.pc)
-	cpu.pc++
-
+// indexAbsolute is a convenience method containing the common logic of abx
+// and aby.
+func (cpu *Mos6502) indexAbsolute(index byte) {
 	highByte := cpu.read(cpu.pc)
 	cpu.pc++
+	cpu.index(word(highByte)<<8|cpu.addressAbsolute, index)
+}
 
-	a := ((word(highByte) << 8) | word(lowByte)) + word(cpu.y)
-
-	cpu.addressAbsolute = a
-
-	if (a & 0xff00) != (word(highByte) << 8) {
-		return 1
+// index offsets a base address by an index register. If this results in a page
+// change, then the cycle fixing up the address is required, which read
+// instructions and the 65C02's shifts and rotates otherwise skip.
+func (cpu *Mos6502) index(base word, index byte) {
+	cpu.addressAbsolute = base + word(index)
+	cpu.pageCrossed = (cpu.addressAbsolute & 0xff00) != (base & 0xff00)
+	if !cpu.pageCrossed && cpu.lookup[cpu.opcode].pageCrossOnly {
+		cpu.step++
 	}
-
-	return 0
 }
 
-// ind is the Indirect address mode.
-// The address in the program counter is used to read an address from the
-// Bus and references that address instead (kinda like a pointer). A bug in
-// the original hardware is included here for accuracy, where a page boundary
-// is crossed if the low byte read is 0xff. But instead of reading the high
-// byte from the next page, the bug causes the start of the same page to be
-// read instead and resulting in an invalid address.
-func (cpu *Mos6502) ind() uint8 {
-	lowByte := cpu.read(cpu.pc)
-	cpu.pc++
-
-	highByte := cpu.read(cpu.pc)
-	cpu.pc++
-
-	pointer := (word(highByte) << 8) | word(lowByte)
-
-	var a word
-	if lowByte == 0xff {
-		a = (word(cpu.read(pointer&0xFF00)) << 8) | word(cpu.read(pointer))
-	} else {
-		a = (word(cpu.read(pointer+1)) << 8) | word(cpu.read(pointer))
+// ind is the last cycle of the Indirect address mode, reading the high byte of
+// the address pointed to. A bug in the original hardware is included here for
+// accuracy, where a page boundary is crossed if the low byte of the pointer is
+// 0xff. But instead of reading the high byte from the next page, the bug causes
+// the start of the same page to be read instead and resulting in an invalid
+// address. The 65C02 fixes the bug.
+func (cpu *Mos6502) ind() {
+	pointer := cpu.addressAbsolute
+	next := (pointer & 0xff00) | ((pointer + 1) & 0x00ff)
+	if cpu.cmos() {
+		next = pointer + 1
 	}
-	cpu.addressAbsolute = a
-
-	return 0
+	highByte := cpu.read(next)
+	cpu.addressAbsolute = (word(highByte) << 8) | cpu.temp
 }
 
-// izx is the Indirect X address mode.
-// The 8-bit address read from the Bus is offset by the byte in the X
-// register to reference an address in page 0x00.
-func (cpu *Mos6502) izx() uint8 {
-	pa := word(cpu.read(cpu.pc) + cpu.x)
-	cpu.pc++
-
-	lowByte := cpu.read(pa & 0x00ff)
-	highByte := cpu.read((pa + 1) & 0x00ff)
-
-	cpu.addressAbsolute = (word(highByte) << 8) | word(lowByte)
-
-	return 0
+// iax is the cycle of the 65C02's Absolute Indexed Indirect address mode adding
+// the X register to the address of the pointer, while the last byte of the
+// instruction is read again.
+func (cpu *Mos6502) iax() {
+	cpu.readLastByte()
+	cpu.addressAbsolute += word(cpu.x)
 }
 
-// izy is the Indirect Y address mode.
-// The 8-bit address read from the Bus is used to address a location
-// in page 0x00, where a full 16-bit address is read and offset by the
-// value in the Y register. If this results in a page change, then an
-// additional clock cycle is required and returned.
-func (cpu *Mos6502) izy() uint8 {
-	pa := word(cpu.read(cpu.pc))
-	cpu.pc++
-
-	lowByte := cpu.read(pa & 0x00ff)
-	highByte := cpu.read((pa + 1) & 0x00ff)
-
-	a := ((word(highByte) << 8) | word(lowByte)) + word(cpu.y)
-	cpu.addressAbsolute = a
-
-	if (a & 0xFF00) != (word(highByte) << 8) {
-		return 1
-	}
+// izx is the cycle of the Indirect X address mode adding the X register to the
+// pointer, which is read while it happens. The result wraps around page 0x00.
+func (cpu *Mos6502) izx() {
+	cpu.read(word(cpu.pointer))
+	cpu.pointer += cpu.x
+}
 
-	return 0
+// izy is the cycle of the Indirect Y address mode reading the high byte of
+// the address held at the pointer and adding the Y register to it.
+func (cpu *Mos6502) izy() {
+	highByte := cpu.read(word(cpu.pointer + 1))
+	cpu.index(word(highByte)<<8|cpu.addressAbsolute, cpu.y)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -497,31 +705,66 @@ func (cpu *Mos6502) izy() uint8 {
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // adc is addition with carry in operation.
-func (cpu *Mos6502) adc() uint8 {
-	cpu.fetch()
+func (cpu *Mos6502) adc() {
+	cpu.add(cpu.fetch())
+}
 
-	cpu.temp = word(cpu.a) + word(cpu.fetchedData) + word(cpu.GetStatusFlag(C))
+// add is a convenience method containing the logic of adc, adding a value and
+// the carry to the accumulator.
+func (cpu *Mos6502) add(value byte) {
+	if cpu.decimalMode() {
+		cpu.addDecimal(value)
+		return
+	}
+
+	cpu.temp = word(cpu.a) + word(value) + word(cpu.GetStatusFlag(C))
 
 	cpu.setStatusFlag(C, cpu.temp > 255)
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0)
-	cpu.setStatusFlag(V, ^(word(cpu.a)^word(cpu.fetchedData))&(word(cpu.a)^cpu.temp)&0x0080 > 0)
+	cpu.setStatusFlag(V, ^(word(cpu.a)^word(value))&(word(cpu.a)^cpu.temp)&0x0080 > 0)
 	cpu.setStatusFlag(N, cpu.temp&0x0080 > 0)
 
 	cpu.a = byte(cpu.temp & 0x00ff)
-	return 1
+}
+
+// addDecimal adds a value and the carry to the accumulator as binary-coded
+// decimal. As on the NMOS 6502, the Z flag is set from the binary sum, and the
+// N and V flags from the sum before its high digit is adjusted. The 65C02 sets
+// N and Z from the result instead.
+func (cpu *Mos6502) addDecimal(value byte) {
+	carry := word(cpu.GetStatusFlag(C))
+	binary := word(cpu.a) + word(value) + carry
+
+	low := word(cpu.a&0x0f) + word(value&0x0f) + carry
+	if low >= 0x0a {
+		low = ((low + 0x06) & 0x0f) + 0x10
+	}
+	cpu.temp = word(cpu.a&0xf0) + word(value&0xf0) + low
+
+	cpu.setStatusFlag(Z, (binary&0x00ff) == 0)
+	cpu.setStatusFlag(V, ^(word(cpu.a)^word(value))&(word(cpu.a)^cpu.temp)&0x0080 > 0)
+	cpu.setStatusFlag(N, cpu.temp&0x0080 > 0)
+	if cpu.temp >= 0xa0 {
+		cpu.temp += 0x60
+	}
+	cpu.setStatusFlag(C, cpu.temp > 255)
+
+	cpu.a = byte(cpu.temp & 0x00ff)
+	if cpu.cmos() {
+		cpu.setDecimalResultFlags()
+	}
 }
 
 // and performs a bitwise AND on the value in the Accumulator and fetched data.
-func (cpu *Mos6502) and() uint8 {
+func (cpu *Mos6502) and() {
 	cpu.fetch()
 	cpu.a = cpu.a & cpu.fetchedData
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, cpu.a&0x80 > 0)
-	return 1
 }
 
 // asl performs an arithmetic shift left on data at an address in the Bus.
-func (cpu *Mos6502) asl() uint8 {
+func (cpu *Mos6502) asl() {
 	cpu.fetch()
 	cpu.temp = word(cpu.fetchedData) << 1
 	cpu.setStatusFlag(C, (cpu.temp&0xff00) > 0)
@@ -532,288 +775,255 @@ func (cpu *Mos6502) asl() uint8 {
 	} else {
 		cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
 	}
-	return 0
 }
 
-// branch is a convenience method containing the common branching logic.
+// branch is a convenience method containing the common branching logic. A
+// taken branch takes an extra cycle, and another if a page change occurs.
 func (cpu *Mos6502) branch() {
-	cpu.cycles++
 	cpu.addressAbsolute = cpu.pc + cpu.addressRelative
-
-	if (cpu.addressAbsolute & 0xff00) != (cpu.pc & 0xff00) {
-		cpu.cycles++
-	}
 	cpu.pc = cpu.addressAbsolute
+	cpu.branching = true
 }
 
 // bcc is the Branch if Carry Clear operation. If a page change occurs as a
 // result, an extra cycle is required.
-func (cpu *Mos6502) bcc() uint8 {
+func (cpu *Mos6502) bcc() {
 	if cpu.GetStatusFlag(C) == 0 {
 		cpu.branch()
 	}
-	return 0
 }
 
 // bcs is the Branch if Carry set operation. If a page change occurs as a result,
 // an extra cycle is required.
-func (cpu *Mos6502) bcs() uint8 {
+func (cpu *Mos6502) bcs() {
 	if cpu.GetStatusFlag(C) == 1 {
 		cpu.branch()
 	}
-	return 0
 }
 
 // beq is the Branch if Equal operation. Does nothing if Z flag set to false.
 // Adds additional cycle if page change occurs as result.
-func (cpu *Mos6502) beq() uint8 {
+func (cpu *Mos6502) beq() {
 	if cpu.GetStatusFlag(Z) == 1 {
 		cpu.branch()
 	}
-	return 0
 }
 
 // bit is the Test Bits operation.
-func (cpu *Mos6502) bit() uint8 {
+func (cpu *Mos6502) bit() {
 	cpu.fetch()
 	cpu.temp = word(cpu.a & cpu.fetchedData)
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0x00)
+	if cpu.lookup[cpu.opcode].addressMode == imm {
+		// the 65C02's immediate mode only sets Z
+		return
+	}
 	cpu.setStatusFlag(N, (cpu.fetchedData&(1<<7)) > 0)
 	cpu.setStatusFlag(V, (cpu.fetchedData&(1<<6)) > 0)
-	return 0
 }
 
 // bmi is the Branch if Negative operation. Does nothing if N flag set to false.
 // Adds additional cycle if page change occurs as result.
-func (cpu *Mos6502) bmi() uint8 {
+func (cpu *Mos6502) bmi() {
 	if cpu.GetStatusFlag(N) == 1 {
 		cpu.branch()
 	}
-	return 0
 }
 
 // bne is the Branch if Not Equal operation. Does nothing if Z flag set to true.
 // Adds additional cycle if page change occurs as result.
-func (cpu *Mos6502) bne() uint8 {
+func (cpu *Mos6502) bne() {
 	if cpu.GetStatusFlag(Z) == 0 {
 		cpu.branch()
 	}
-	return 0
 }
 
 // bpl is the Branch if Positive operation. Does nothing if N flag set to true.
 // Adds additional cycle if page change occurs as result.
-func (cpu *Mos6502) bpl() uint8 {
+func (cpu *Mos6502) bpl() {
 	if cpu.GetStatusFlag(N) == 0 {
 		cpu.branch()
 	}
-	return 0
 }
 
-// brk is the Break operation. It is used to signal an interrupt from the program.
-func (cpu *Mos6502) brk() uint8 {
+// brk is the Break operation. It is used to signal an interrupt from the program,
+// sharing the IRQ sequence but pushing the status with the B flag set. The byte
+// after the opcode is read and skipped, so the handler returns past it.
+func (cpu *Mos6502) brk() {
+	cpu.read(cpu.pc)
 	cpu.pc++
-
-	cpu.setStatusFlag(I, true)
-	cpu.write(0x0100+word(cpu.stkp), byte((cpu.pc>>8)&0x00ff))
-	cpu.stkp--
-	cpu.write(0x0100+word(cpu.stkp), byte(cpu.pc&0x00ff))
-	cpu.stkp--
-
-	cpu.setStatusFlag(B, true)
-	cpu.write(0x0100+word(cpu.stkp), cpu.status)
-	cpu.stkp--
-	cpu.setStatusFlag(B, false)
-
-	cpu.pc = word(cpu.read(0xfffe)) | (word(cpu.read(0xffff)) << 8)
-	return 0
+	cpu.vector = 0xfffe
+	cpu.breaking = true
 }
 
 // bvc is the Branch if Overflow Clear operation. Does nothing if V set to true.
 // Adds additional cycle if page change occurs as result.
-func (cpu *Mos6502) bvc() uint8 {
+func (cpu *Mos6502) bvc() {
 	if cpu.GetStatusFlag(V) == 0 {
 		cpu.branch()
 	}
-	return 0
 }
 
-func (cpu *Mos6502) bvs() uint8 {
+func (cpu *Mos6502) bvs() {
 	if cpu.GetStatusFlag(V) == 1 {
 		cpu.branch()
 	}
-	return 0
 }
 
 // clc is the Clear Carry Flag operation. It sets the Carry flag to false.
-func (cpu *Mos6502) clc() uint8 {
+func (cpu *Mos6502) clc() {
 	cpu.setStatusFlag(C, false)
-	return 0
 }
 
 // cld is the Clear Decimal Flag operation. It sets the Decimal flag to false.
-func (cpu *Mos6502) cld() uint8 {
+func (cpu *Mos6502) cld() {
 	cpu.setStatusFlag(D, false)
-	return 0
 }
 
 // cli is the Clear Interrupt Flag operation. It sets the Interrupt flag to false.
-func (cpu *Mos6502) cli() uint8 {
+func (cpu *Mos6502) cli() {
 	cpu.setStatusFlag(I, false)
-	return 0
 }
 
 // clv is the Clear Overflow Flag operation. It sets the Overflow flag to false.
-func (cpu *Mos6502) clv() uint8 {
+func (cpu *Mos6502) clv() {
 	cpu.setStatusFlag(V, false)
-	return 0
 }
 
 // compare is a convenience method containing the common logic used by the compare
 // operations.
-func (cpu *Mos6502) compare(registerData byte) {
-	cpu.fetch()
-	cpu.temp = word(registerData) - word(cpu.fetchedData)
-	cpu.setStatusFlag(C, registerData >= cpu.fetchedData)
+func (cpu *Mos6502) compare(registerData byte, data byte) {
+	cpu.temp = word(registerData) - word(data)
+	cpu.setStatusFlag(C, registerData >= data)
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0x0000)
 	cpu.setStatusFlag(N, (cpu.temp&0x0080) > 0)
 }
 
 // cmp is the Compare Accumulator operation. It compares the accumulator to data
 // stored on the Bus, setting the C, N, and Z flags accordingly.
-func (cpu *Mos6502) cmp() uint8 {
-	cpu.compare(cpu.a)
-	return 1
+func (cpu *Mos6502) cmp() {
+	cpu.compare(cpu.a, cpu.fetch())
 }
 
 // cpx is the Compare X Register operation. It compares the X Register to data
 // stored on the Bus, setting the C, N, and Z flags accordingly.
-func (cpu *Mos6502) cpx() uint8 {
-	cpu.compare(cpu.x)
-	return 0
+func (cpu *Mos6502) cpx() {
+	cpu.compare(cpu.x, cpu.fetch())
 }
 
 // cpy is the Compare Y Register operation. It compares the Y Register to data
 // stored on the Bus, setting the C, N, and Z flags accordingly.
-func (cpu *Mos6502) cpy() uint8 {
-	cpu.compare(cpu.y)
-	return 0
+func (cpu *Mos6502) cpy() {
+	cpu.compare(cpu.y, cpu.fetch())
 }
 
-// dec is the Decrement Value at Memory Location operation.
-func (cpu *Mos6502) dec() uint8 {
+// dec is the Decrement Value at Memory Location operation. The 65C02 can also
+// decrement the accumulator.
+func (cpu *Mos6502) dec() {
 	cpu.fetch()
 	cpu.temp = word(cpu.fetchedData) - 1
-	cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0x0000)
 	cpu.setStatusFlag(N, (cpu.temp&0x0080) > 0)
-	return 0
+	if cpu.lookup[cpu.opcode].addressMode == imp {
+		cpu.a = byte(cpu.temp & 0x00ff)
+	} else {
+		cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
+	}
 }
 
 // dex is the Decrement X Register operation.
-func (cpu *Mos6502) dex() uint8 {
+func (cpu *Mos6502) dex() {
 	cpu.x--
 	cpu.setStatusFlag(Z, cpu.x == 0x00)
 	cpu.setStatusFlag(N, (cpu.x&0x80) > 0)
-	return 0
 }
 
 // dey is the Decrement Y Register operation.
-func (cpu *Mos6502) dey() uint8 {
+func (cpu *Mos6502) dey() {
 	cpu.y--
 	cpu.setStatusFlag(Z, cpu.y == 0x00)
 	cpu.setStatusFlag(N, (cpu.y&0x80) > 0)
-	return 0
 }
 
 // eor is the Exclusive Or (XOR) operation.
-func (cpu *Mos6502) eor() uint8 {
+func (cpu *Mos6502) eor() {
 	cpu.fetch()
 	cpu.a = cpu.a ^ cpu.fetchedData
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
-	return 0
 }
 
-// inc is the Increment Value at Memory Location operation.
-func (cpu *Mos6502) inc() uint8 {
+// inc is the Increment Value at Memory Location operation. The 65C02 can also
+// increment the accumulator.
+func (cpu *Mos6502) inc() {
 	cpu.fetch()
 	cpu.temp = word(cpu.fetchedData) + 1
-	cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0x0000)
 	cpu.setStatusFlag(N, (cpu.temp&0x80) > 0)
-	return 0
+	if cpu.lookup[cpu.opcode].addressMode == imp {
+		cpu.a = byte(cpu.temp & 0x00ff)
+	} else {
+		cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
+	}
 }
 
 // inx is the Increment X Register operation.
-func (cpu *Mos6502) inx() uint8 {
+func (cpu *Mos6502) inx() {
 	cpu.x++
 	cpu.setStatusFlag(Z, cpu.x == 0x00)
 	cpu.setStatusFlag(N, (cpu.x&0x80) > 0)
-	return 0
 }
 
 // iny is the Increment Y Register operation.
-func (cpu *Mos6502) iny() uint8 {
+func (cpu *Mos6502) iny() {
 	cpu.y++
 	cpu.setStatusFlag(Z, cpu.y == 0x00)
 	cpu.setStatusFlag(N, (cpu.y&0x80) > 0)
-	return 0
 }
 
 // jmp is the Jump to Location operation.
-func (cpu *Mos6502) jmp() uint8 {
+func (cpu *Mos6502) jmp() {
 	cpu.pc = cpu.addressAbsolute
-	return 0
 }
 
-// jsr is the Jump to Sub-Routine operation. Pushes current program counter value
-// to stack.
-func (cpu *Mos6502) jsr() uint8 {
-	cpu.pc--
-
-	cpu.write(0x0100+word(cpu.stkp), byte((cpu.pc>>8)&0x00ff))
-	cpu.stkp--
-	cpu.write(0x0100+word(cpu.stkp), byte(cpu.pc&0x00ff))
-	cpu.stkp--
-
+// jsr is the Jump to Sub-Routine operation. The program counter, pointing at
+// the high byte of the address, has already been pushed to the stack, so this
+// reads the high byte and jumps.
+func (cpu *Mos6502) jsr() {
+	cpu.addressAbsolute |= word(cpu.read(cpu.pc)) << 8
 	cpu.pc = cpu.addressAbsolute
-	return 0
 }
 
 // lda is the Load Accumulator operation.
-func (cpu *Mos6502) lda() uint8 {
+func (cpu *Mos6502) lda() {
 	cpu.fetch()
 	cpu.a = cpu.fetchedData
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
-	return 1
 }
 
 // lda is the Load X Register operation.
-func (cpu *Mos6502) ldx() uint8 {
+func (cpu *Mos6502) ldx() {
 	cpu.fetch()
 	cpu.x = cpu.fetchedData
 	cpu.setStatusFlag(Z, cpu.x == 0x00)
 	cpu.setStatusFlag(N, (cpu.x&0x80) > 0)
-	return 1
 }
 
 // lda is the Load Y Register operation.
-func (cpu *Mos6502) ldy() uint8 {
+func (cpu *Mos6502) ldy() {
 	cpu.fetch()
 	cpu.y = cpu.fetchedData
 	cpu.setStatusFlag(Z, cpu.y == 0x00)
 	cpu.setStatusFlag(N, (cpu.y&0x80) > 0)
-	return 1
 }
 
 // lsr is the Logical Shift Right operation. Shifts all bits to the right by one,
-// shifting original bit 0 into carry flag and carrying to bit 7.
-func (cpu *Mos6502) lsr() uint8 {
+// shifting original bit 0 into carry flag and 0 into bit 7.
+func (cpu *Mos6502) lsr() {
 	cpu.fetch()
 	cpu.setStatusFlag(C, (cpu.fetchedData&0x0001) > 0)
-	cpu.temp = word(bits.RotateLeft8(cpu.fetchedData, -1))
+	cpu.temp = word(cpu.fetchedData >> 1)
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0x0000)
 	cpu.setStatusFlag(N, (cpu.temp&0x0080) > 0)
 	if cpu.lookup[cpu.opcode].addressMode == imp {
@@ -821,63 +1031,53 @@ func (cpu *Mos6502) lsr() uint8 {
 	} else {
 		cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
 	}
-	return 0
 }
 
-// nop is the No Operation operation. Adds additional cycle in some cases.
-func (cpu *Mos6502) nop() uint8 {
-	switch cpu.opcode {
-	case 0x1c, 0x3c, 0x5c, 0x7c, 0xdc, 0xfc:
-		return 1
-	default:
-		return 0
-	}
+// nop is the No Operation operation. The unofficial NOPs and the 65C02's NOPs
+// that take an operand still read it.
+func (cpu *Mos6502) nop() {
+	cpu.fetch()
 }
 
 // ora is the Bitwise Logic OR operation.
-func (cpu *Mos6502) ora() uint8 {
+func (cpu *Mos6502) ora() {
 	cpu.fetch()
 	cpu.a = cpu.a | cpu.fetchedData
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
-	return 1
 }
 
 // pha is the Push Accumulator to Stack operation.
-func (cpu *Mos6502) pha() uint8 {
+func (cpu *Mos6502) pha() {
 	cpu.write(0x0100+word(cpu.stkp), cpu.a)
 	cpu.stkp--
-	return 0
 }
 
 // php is the Push Status Register to Stack operation.
-func (cpu *Mos6502) php() uint8 {
+func (cpu *Mos6502) php() {
 	cpu.write(0x0100+word(cpu.stkp), cpu.status|byte(B)|byte(U))
 	cpu.setStatusFlag(B, false)
 	cpu.setStatusFlag(U, false)
 	cpu.stkp--
-	return 0
 }
 
 // pla is the Pop Accumulator Off Stack operation.
-func (cpu *Mos6502) pla() uint8 {
+func (cpu *Mos6502) pla() {
 	cpu.stkp++
 	cpu.a = cpu.read(0x0100 + word(cpu.stkp))
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
-	return 0
 }
 
 // plp is the Pop Status Register Off Stack operation.
-func (cpu *Mos6502) plp() uint8 {
+func (cpu *Mos6502) plp() {
 	cpu.stkp++
 	cpu.status = cpu.read(0x0100 + word(cpu.stkp))
 	cpu.setStatusFlag(U, true)
-	return 0
 }
 
 // rol is the Rotate Left operation.
-func (cpu *Mos6502) rol() uint8 {
+func (cpu *Mos6502) rol() {
 	cpu.fetch()
 	cpu.temp = word(cpu.fetchedData)<<1 | word(cpu.GetStatusFlag(C))
 	cpu.setStatusFlag(C, (cpu.fetchedData&0x80) > 0)
@@ -888,11 +1088,10 @@ func (cpu *Mos6502) rol() uint8 {
 	} else {
 		cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
 	}
-	return 0
 }
 
 // rol is the Rotate Right operation.
-func (cpu *Mos6502) ror() uint8 {
+func (cpu *Mos6502) ror() {
 	cpu.fetch()
 	cpu.temp = word(cpu.GetStatusFlag(C))<<7 | word(cpu.fetchedData)>>1
 	cpu.setStatusFlag(C, (cpu.fetchedData&0x01) > 0)
@@ -903,133 +1102,441 @@ func (cpu *Mos6502) ror() uint8 {
 	} else {
 		cpu.write(cpu.addressAbsolute, byte(cpu.temp&0x00ff))
 	}
-	return 0
 }
 
-// rti is the Return from Interrupt operation.
-func (cpu *Mos6502) rti() uint8 {
+// rti is the Return from Interrupt operation. It pulls the status register off
+// the stack, before the program counter is pulled on the following cycles.
+func (cpu *Mos6502) rti() {
 	cpu.stkp++
 	cpu.status = cpu.read(0x0100 + word(cpu.stkp))
 	cpu.status &= ^uint8(B)
 	cpu.status &= ^uint8(U)
-
-	cpu.stkp++
-	cpu.pc = word(cpu.read(0x0100 + word(cpu.stkp)))
-	cpu.stkp++
-	cpu.pc |= word(cpu.read(0x0100+word(cpu.stkp))) << 8
-	return 0
 }
 
-// rts is the Return from Subroutine operation.
-func (cpu *Mos6502) rts() uint8 {
-	cpu.stkp++
-	cpu.pc = word(cpu.read(0x0100 + word(cpu.stkp)))
-	cpu.stkp++
-	cpu.pc |= word(cpu.read(0x0100+word(cpu.stkp))) << 8
-
+// rts is the Return from Subroutine operation. The program counter has already
+// been pulled off the stack, pointing at the last byte of the JSR, so this
+// reads that byte and steps over it.
+func (cpu *Mos6502) rts() {
+	cpu.read(cpu.pc)
 	cpu.pc++
-	return 0
 }
 
 // sbc is the subtract with borrow in operation.
-func (cpu *Mos6502) sbc() uint8 {
-	cpu.fetch()
+func (cpu *Mos6502) sbc() {
+	cpu.subtract(cpu.fetch())
+}
 
-	value := word(cpu.fetchedData) ^ 0x00ff
-	cpu.temp = word(cpu.a) + value + word(cpu.GetStatusFlag(C))
+// subtract is a convenience method containing the logic of sbc, subtracting a
+// value and the borrow from the accumulator.
+func (cpu *Mos6502) subtract(data byte) {
+	carry := cpu.GetStatusFlag(C)
+	value := word(data) ^ 0x00ff
+	cpu.temp = word(cpu.a) + value + word(carry)
 
-	cpu.setStatusFlag(C, (cpu.temp&0xff00)>>7 == 1)
+	cpu.setStatusFlag(C, cpu.temp > 255)
 	cpu.setStatusFlag(Z, (cpu.temp&0x00ff) == 0)
 	cpu.setStatusFlag(V, (cpu.temp^word(cpu.a))&(cpu.temp^value)&0x0080 > 0)
 	cpu.setStatusFlag(N, (cpu.temp&0x0080) > 0)
 
-	cpu.a = byte(cpu.temp & 0x00ff)
-	return 1
+	switch {
+	case cpu.decimalMode() && cpu.cmos():
+		cpu.a = subtractDecimalCMOS(cpu.a, data, carry)
+		cpu.setDecimalResultFlags()
+	case cpu.decimalMode():
+		cpu.a = subtractDecimal(cpu.a, data, carry)
+	default:
+		cpu.a = byte(cpu.temp & 0x00ff)
+	}
+}
+
+// subtractDecimal returns a minus b and the borrow as binary-coded decimal. The
+// NMOS 6502 sets the flags as it would in binary mode.
+func subtractDecimal(a byte, b byte, carry byte) byte {
+	low := int(a&0x0f) - int(b&0x0f) + int(carry) - 1
+	if low < 0 {
+		low = ((low - 0x06) & 0x0f) - 0x10
+	}
+	result := int(a&0xf0) - int(b&0xf0) + low
+	if result < 0 {
+		result -= 0x60
+	}
+	return byte(result)
+}
+
+// subtractDecimalCMOS returns a minus b and the borrow as binary-coded decimal
+// as the 65C02 works it out, which differs from the NMOS 6502 for invalid
+// decimal numbers.
+func subtractDecimalCMOS(a byte, b byte, carry byte) byte {
+	low := int(a&0x0f) - int(b&0x0f) + int(carry) - 1
+	result := int(a) - int(b) + int(carry) - 1
+	if result < 0 {
+		result -= 0x60
+	}
+	if low < 0 {
+		result -= 0x06
+	}
+	return byte(result)
+}
+
+// setDecimalResultFlags sets the N and Z flags from the result of a 65C02 adc
+// or sbc in decimal mode, which takes an extra cycle reading the next opcode to
+// work them out.
+func (cpu *Mos6502) setDecimalResultFlags() {
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+	cpu.steps = append(cpu.steps[:cpu.step:cpu.step], microOp{run: cpu.readPC})
+}
+
+// decimalMode returns whether adc and sbc work in binary-coded decimal, which
+// they do when the D flag is set on variants other than the 2A03.
+func (cpu *Mos6502) decimalMode() bool {
+	return cpu.variant != RP2A03 && cpu.GetStatusFlag(D) == 1
+}
+
+// cmos returns whether the CPU is a 65C02.
+func (cpu *Mos6502) cmos() bool {
+	return cpu.variant == WDC65C02 || cpu.variant == R65C02
 }
 
 // sec is the Set Carry Flag operation.
-func (cpu *Mos6502) sec() uint8 {
+func (cpu *Mos6502) sec() {
 	cpu.setStatusFlag(C, true)
-	return 0
 }
 
 // sed is the Set Decimal Flag operation.
-func (cpu *Mos6502) sed() uint8 {
+func (cpu *Mos6502) sed() {
 	cpu.setStatusFlag(D, true)
-	return 0
 }
 
 // sei is the Set Interrupt Flag operation.
-func (cpu *Mos6502) sei() uint8 {
+func (cpu *Mos6502) sei() {
 	cpu.setStatusFlag(I, true)
-	return 0
 }
 
 // sta is the Store Accumulator at Address operation.
-func (cpu *Mos6502) sta() uint8 {
+func (cpu *Mos6502) sta() {
 	cpu.write(cpu.addressAbsolute, cpu.a)
-	return 0
 }
 
 // stx is the Store X Register at Address operation.
-func (cpu *Mos6502) stx() uint8 {
+func (cpu *Mos6502) stx() {
 	cpu.write(cpu.addressAbsolute, cpu.x)
-	return 0
 }
 
 // sty is the Store Y Register at Address operation.
-func (cpu *Mos6502) sty() uint8 {
+func (cpu *Mos6502) sty() {
 	cpu.write(cpu.addressAbsolute, cpu.y)
-	return 0
 }
 
 // tax is the Transfer Accumulator to X Register operation.
-func (cpu *Mos6502) tax() uint8 {
+func (cpu *Mos6502) tax() {
 	cpu.x = cpu.a
 	cpu.setStatusFlag(Z, cpu.x == 0x00)
 	cpu.setStatusFlag(N, (cpu.x&0x80) > 0)
-	return 0
 }
 
 // tay is the Transfer Accumulator to Y Register operation.
-func (cpu *Mos6502) tay() uint8 {
+func (cpu *Mos6502) tay() {
 	cpu.y = cpu.a
 	cpu.setStatusFlag(Z, cpu.y == 0x00)
 	cpu.setStatusFlag(N, (cpu.y&0x80) > 0)
-	return 0
 }
 
 // tsx is the Transfer Stack Pointer to X Register operation.
-func (cpu *Mos6502) tsx() uint8 {
+func (cpu *Mos6502) tsx() {
 	cpu.x = cpu.stkp
 	cpu.setStatusFlag(Z, cpu.x == 0x00)
 	cpu.setStatusFlag(N, (cpu.x&0x80) > 0)
-	return 0
 }
 
 // tax is the Transfer X Register to Accumulator operation.
-func (cpu *Mos6502) txa() uint8 {
+func (cpu *Mos6502) txa() {
 	cpu.a = cpu.x
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
-	return 0
 }
 
 // txs is the Transfer X Register to Stack Pointer operation.
-func (cpu *Mos6502) txs() uint8 {
+func (cpu *Mos6502) txs() {
 	cpu.stkp = cpu.x
-	return 0
 }
 
 // tya is the Transfer Y Register to Accumulator operation.
-func (cpu *Mos6502) tya() uint8 {
+func (cpu *Mos6502) tya() {
 	cpu.a = cpu.y
 	cpu.setStatusFlag(Z, cpu.a == 0x00)
 	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
-	return 0
 }
 
-// xxx represents an unofficial opcode.
-func (cpu *Mos6502) xxx() uint8 {
-	return 0
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Unofficial Opcodes //////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// unstableMagic is the value ORed into the accumulator by XAA. It depends on
+// the chip and its temperature, but is 0xee on most 2A03s.
+const unstableMagic = 0xee
+
+// alr is the AND then Logical Shift Right operation, performing an and on
+// the accumulator followed by an lsr on it.
+func (cpu *Mos6502) alr() {
+	cpu.a &= cpu.fetch()
+	cpu.setStatusFlag(C, (cpu.a&0x01) > 0)
+	cpu.a >>= 1
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+// anc is the AND with Carry operation. It performs an and on the accumulator,
+// then copies the N flag into the Carry flag.
+func (cpu *Mos6502) anc() {
+	cpu.a &= cpu.fetch()
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+	cpu.setStatusFlag(C, (cpu.a&0x80) > 0)
+}
+
+// arr is the AND then Rotate Right operation. It performs an and on the
+// accumulator followed by a ror on it, but sets C from bit 6 of the result and
+// V from bit 6 exclusive-ored with bit 5.
+func (cpu *Mos6502) arr() {
+	cpu.a &= cpu.fetch()
+	cpu.a = cpu.GetStatusFlag(C)<<7 | cpu.a>>1
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+	cpu.setStatusFlag(C, (cpu.a&0x40) > 0)
+	cpu.setStatusFlag(V, ((cpu.a>>6)^(cpu.a>>5))&0x01 > 0)
+}
+
+// axs is the AND X Register then Subtract operation. It stores the accumulator
+// ANDed with the X register, minus data on the Bus, in the X register. The
+// flags are set as cmp would, and the Carry flag is ignored.
+func (cpu *Mos6502) axs() {
+	cpu.compare(cpu.a&cpu.x, cpu.fetch())
+	cpu.x = byte(cpu.temp & 0x00ff)
+}
+
+// dcp is the Decrement then Compare operation, performing a dec followed by
+// a cmp with the decremented value.
+func (cpu *Mos6502) dcp() {
+	cpu.dec()
+	cpu.compare(cpu.a, byte(cpu.temp&0x00ff))
+}
+
+// isc is the Increment then Subtract with Carry operation, performing an inc
+// followed by an sbc with the incremented value.
+func (cpu *Mos6502) isc() {
+	cpu.inc()
+	cpu.subtract(byte(cpu.temp & 0x00ff))
+}
+
+// kil is the Halt operation. It jams the CPU, which stops executing
+// instructions or handling interrupts until it is reset.
+func (cpu *Mos6502) kil() {
+	cpu.jammed = true
+}
+
+// las is the Load Accumulator, X Register and Stack Pointer operation. It
+// loads all three with data on the Bus ANDed with the stack pointer.
+func (cpu *Mos6502) las() {
+	cpu.stkp &= cpu.fetch()
+	cpu.a = cpu.stkp
+	cpu.x = cpu.stkp
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+// lax is the Load Accumulator and X Register operation. The immediate mode
+// opcode is unstable, and on most 2A03s loads the value unchanged like the
+// others.
+func (cpu *Mos6502) lax() {
+	cpu.a = cpu.fetch()
+	cpu.x = cpu.a
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+// rla is the Rotate Left then AND operation, performing a rol followed by an
+// and with the rotated value.
+func (cpu *Mos6502) rla() {
+	cpu.rol()
+	cpu.a &= byte(cpu.temp & 0x00ff)
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+// rra is the Rotate Right then Add with Carry operation, performing a ror
+// followed by an adc with the rotated value.
+func (cpu *Mos6502) rra() {
+	cpu.ror()
+	cpu.add(byte(cpu.temp & 0x00ff))
+}
+
+// sax is the Store Accumulator AND X Register operation.
+func (cpu *Mos6502) sax() {
+	cpu.write(cpu.addressAbsolute, cpu.a&cpu.x)
+}
+
+// sha is the Store Accumulator AND X Register AND High Byte operation.
+func (cpu *Mos6502) sha() {
+	cpu.storeHigh(cpu.a&cpu.x, cpu.y)
+}
+
+// shx is the Store X Register AND High Byte operation.
+func (cpu *Mos6502) shx() {
+	cpu.storeHigh(cpu.x, cpu.y)
+}
+
+// shy is the Store Y Register AND High Byte operation.
+func (cpu *Mos6502) shy() {
+	cpu.storeHigh(cpu.y, cpu.x)
+}
+
+// slo is the Arithmetic Shift Left then OR operation, performing an asl
+// followed by an ora with the shifted value.
+func (cpu *Mos6502) slo() {
+	cpu.asl()
+	cpu.a |= byte(cpu.temp & 0x00ff)
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+// sre is the Logical Shift Right then Exclusive Or operation, performing an
+// lsr followed by an eor with the shifted value.
+func (cpu *Mos6502) sre() {
+	cpu.lsr()
+	cpu.a ^= byte(cpu.temp & 0x00ff)
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+// storeHigh is a convenience method containing the common logic of the
+// unstable stores, which write data ANDed with the high byte of the base
+// address plus one. If indexing the base address crossed a page, the value
+// written also replaces the high byte of the address written to.
+func (cpu *Mos6502) storeHigh(data byte, index byte) {
+	base := cpu.addressAbsolute - word(index)
+	value := data & (byte(base>>8) + 1)
+
+	address := cpu.addressAbsolute
+	if (address & 0xff00) != (base & 0xff00) {
+		address = word(value)<<8 | address&0x00ff
+	}
+	cpu.write(address, value)
+}
+
+// tas is the Transfer Accumulator AND X Register to Stack Pointer then Store
+// operation. It stores the accumulator ANDed with the X register in the stack
+// pointer, then stores the stack pointer as sha would.
+func (cpu *Mos6502) tas() {
+	cpu.stkp = cpu.a & cpu.x
+	cpu.storeHigh(cpu.stkp, cpu.y)
+}
+
+// xaa is the Transfer X Register to Accumulator then AND operation. It is
+// unstable, storing the accumulator ORed with a magic value, ANDed with the X
+// register and data on the Bus, in the accumulator.
+func (cpu *Mos6502) xaa() {
+	cpu.a = (cpu.a | unstableMagic) & cpu.x & cpu.fetch()
+	cpu.setStatusFlag(Z, cpu.a == 0x00)
+	cpu.setStatusFlag(N, (cpu.a&0x80) > 0)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// 65C02 Opcodes ///////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// bra is the Branch Always operation.
+func (cpu *Mos6502) bra() {
+	cpu.branch()
+}
+
+// phx is the Push X Register to Stack operation.
+func (cpu *Mos6502) phx() {
+	cpu.write(0x0100+word(cpu.stkp), cpu.x)
+	cpu.stkp--
+}
+
+// phy is the Push Y Register to Stack operation.
+func (cpu *Mos6502) phy() {
+	cpu.write(0x0100+word(cpu.stkp), cpu.y)
+	cpu.stkp--
+}
+
+// plx is the Pop X Register Off Stack operation.
+func (cpu *Mos6502) plx() {
+	cpu.stkp++
+	cpu.x = cpu.read(0x0100 + word(cpu.stkp))
+	cpu.setStatusFlag(Z, cpu.x == 0x00)
+	cpu.setStatusFlag(N, (cpu.x&0x80) > 0)
+}
+
+// ply is the Pop Y Register Off Stack operation.
+func (cpu *Mos6502) ply() {
+	cpu.stkp++
+	cpu.y = cpu.read(0x0100 + word(cpu.stkp))
+	cpu.setStatusFlag(Z, cpu.y == 0x00)
+	cpu.setStatusFlag(N, (cpu.y&0x80) > 0)
+}
+
+// stz is the Store Zero at Address operation.
+func (cpu *Mos6502) stz() {
+	cpu.write(cpu.addressAbsolute, 0x00)
+}
+
+// trb is the Test and Reset Bits operation. It sets the Z flag as bit would,
+// then clears the bits set in the accumulator from data on the Bus.
+func (cpu *Mos6502) trb() {
+	cpu.fetch()
+	cpu.setStatusFlag(Z, cpu.a&cpu.fetchedData == 0x00)
+	cpu.write(cpu.addressAbsolute, cpu.fetchedData&^cpu.a)
+}
+
+// tsb is the Test and Set Bits operation. It sets the Z flag as bit would,
+// then sets the bits set in the accumulator in data on the Bus.
+func (cpu *Mos6502) tsb() {
+	cpu.fetch()
+	cpu.setStatusFlag(Z, cpu.a&cpu.fetchedData == 0x00)
+	cpu.write(cpu.addressAbsolute, cpu.fetchedData|cpu.a)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Rockwell Opcodes ////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// bbr returns the Branch on Bit Reset operation for a bit, which branches if
+// the bit of the zero page byte read is clear.
+func (cpu *Mos6502) bbr(b byte) func() {
+	return func() {
+		if cpu.fetchedData&(1<<b) == 0 {
+			cpu.branch()
+		}
+	}
+}
+
+// bbs returns the Branch on Bit Set operation for a bit, which branches if the
+// bit of the zero page byte read is set.
+func (cpu *Mos6502) bbs(b byte) func() {
+	return func() {
+		if cpu.fetchedData&(1<<b) > 0 {
+			cpu.branch()
+		}
+	}
+}
+
+// rmb returns the Reset Memory Bit operation for a bit, which clears the bit
+// of data on the Bus.
+func (cpu *Mos6502) rmb(b byte) func() {
+	return func() {
+		cpu.fetch()
+		cpu.write(cpu.addressAbsolute, cpu.fetchedData&^(1<<b))
+	}
+}
+
+// smb returns the Set Memory Bit operation for a bit, which sets the bit of
+// data on the Bus.
+func (cpu *Mos6502) smb(b byte) func() {
+	return func() {
+		cpu.fetch()
+		cpu.write(cpu.addressAbsolute, cpu.fetchedData|(1<<b))
+	}
 }
@@ -1,6 +1,7 @@
 package cpu
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/Jac0bDeal/goNES/internal/bus"
@@ -14,13 +15,8 @@ func newTestMos6502() *Mos6502 {
 			instruction{
 				operation:   "TOP",
 				addressMode: "TAM",
-				performOp: func() uint8 {
-					return 0
-				},
-				setAddressMode: func() uint8 {
-					return 0
-				},
-				cycles: 0,
+				performOp:   func() {},
+				cycles:      0,
 			},
 		},
 	}
@@ -39,6 +35,13 @@ func newBusBuilder() *busBuilder {
 	}
 }
 
+// read reads the given address on the Bus being built, leaving its value
+// latched on the data bus.
+func (b *busBuilder) read(address word) *busBuilder {
+	b.bus.Read(uint16(address))
+	return b
+}
+
 // build returns the built Bus instance.
 func (b *busBuilder) build() *bus.Bus {
 	return b.bus
@@ -50,147 +53,176 @@ func (b *busBuilder) write(address word, data byte) *busBuilder {
 	return b
 }
 
+// statusRegister is a bus.Device modelling a read-sensitive register, such as
+// PPUSTATUS, whose high bit is cleared by reads.
+type statusRegister struct {
+	status uint8
+}
+
+func (r *statusRegister) Read(uint16) uint8 {
+	s := r.status
+	r.status &^= 0x80
+	return s
+}
+
+func (r *statusRegister) Write(uint16, uint8) {}
+
+func (r *statusRegister) Peek(uint16) uint8 {
+	return r.status
+}
+
+func TestMos6502_Disassemble(t *testing.T) {
+	testCases := []struct {
+		name          string
+		addressStart  uint16
+		addressStop   uint16
+		expectedLines []string
+	}{
+		{
+			name:         "disassembles program in ram",
+			addressStart: 0x0000,
+			addressStop:  0x0005,
+			expectedLines: []string{
+				"$0: LDA $2002 {ABS}",
+				"$3: BPL $2 [$7] {REL}",
+			},
+		},
+		{
+			name:         "disassembles over read-sensitive register",
+			addressStart: 0x2002,
+			addressStop:  0x2003,
+			expectedLines: []string{
+				"$2002: NOP #$80 {IMM}",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reg := &statusRegister{status: 0x80}
+			b := bus.NewNESBus()
+			assert.NoError(t, b.Attach(reg, 0x2000, 0x3fff))
+			for i, data := range []byte{0xad, 0x02, 0x20, 0x10, 0x02} {
+				b.Write(uint16(i), data)
+			}
+			cpu := NewMos6502(RP2A03)
+			cpu.ConnectBus(b)
+
+			var lines []string
+			for _, in := range cpu.Disassemble(tc.addressStart, tc.addressStop) {
+				lines = append(lines, fmt.Sprintf("$%X: %s", in.Address, LegacyFormatter{}.Format(in)))
+			}
+
+			assert.Equal(t, tc.expectedLines, lines)
+			assert.Equal(t, uint8(0x80), reg.status, "disassembly mutated device state")
+		})
+	}
+
+	t.Run("follows the table of the variant", func(t *testing.T) {
+		b := bus.NewNESBus()
+		program := []byte{
+			0xb2, 0x10, // LDA ($10)
+			0x7c, 0x00, 0x10, // JMP ($1000,X)
+			0x8f, 0x10, 0xfb, // BBS0 $10, -5
+			0x80, 0x01, // BRA +1
+		}
+		for i, data := range program {
+			b.Write(uint16(i), data)
+		}
+		cpu := NewMos6502(R65C02)
+		cpu.ConnectBus(b)
+
+		var lines []string
+		for _, in := range cpu.Disassemble(0x0000, uint16(len(program))) {
+			lines = append(lines, LegacyFormatter{}.Format(in))
+		}
+
+		assert.Equal(t, []string{
+			"LDA ($10) {IZP}",
+			"JMP ($1000, X) {IAX}",
+			"BBS0 $10, $FB [$3] {ZPR}",
+			"BRA $1 [$B] {REL}",
+		}, lines)
+	})
+}
+
 func TestMos6502_Clock(t *testing.T) {
 	testCases := []struct {
 		name              string
 		setupInitialState func(*testing.T) *Mos6502
 		expectedPC        word
 		expectedA         byte
-		expectedX         byte
-		expectedY         byte
-		expectedStkp      byte
 		expectedStatus    byte
-		expectedCycles    uint8
+		expectedStep      int
+		expectedComplete  bool
 	}{
 		{
-			name: "cpu only decrements cycle count if non-zero",
+			name: "cpu runs only the next cycle of the current instruction",
 			setupInitialState: func(*testing.T) *Mos6502 {
-				return &Mos6502{
+				cpu := &Mos6502{
 					pc:     0x0000,
 					a:      0x11,
-					x:      0x11,
-					y:      0x11,
-					stkp:   0x11,
 					status: 0b00000000,
-					cycles: 1,
-					lookup: mos6502LookupTable{
-						{
-							operation:   "TOP",
-							addressMode: "TAM",
-							performOp: func() uint8 {
-								return 0
-							},
-							setAddressMode: func() uint8 {
-								return 0
-							},
-							cycles: 0,
-						},
-					},
-					bus: bus.NewBus(bus.RAM{}),
+					bus:    bus.NewBus(bus.RAM{}),
 				}
+				cpu.steps = []microOp{
+					{run: func() { cpu.a++ }},
+					{run: func() { cpu.a = 0 }},
+				}
+				return cpu
 			},
-			expectedPC:     0x0000,
-			expectedA:      0x11,
-			expectedX:      0x11,
-			expectedY:      0x11,
-			expectedStkp:   0x11,
-			expectedStatus: 0b00000000,
-			expectedCycles: 0,
+			expectedPC:       0x0000,
+			expectedA:        0x12,
+			expectedStatus:   0b00000000,
+			expectedStep:     1,
+			expectedComplete: false,
 		},
 		{
-			name: "cpu performs clock cycle correctly with no additional cycles",
-			setupInitialState: func(t *testing.T) *Mos6502 {
-				t.Helper()
+			name: "cpu completes instruction on its last cycle",
+			setupInitialState: func(*testing.T) *Mos6502 {
 				cpu := &Mos6502{
 					pc:     0x0000,
 					a:      0x11,
-					x:      0x11,
-					y:      0x11,
-					stkp:   0x11,
 					status: 0b00000000,
-					cycles: 0,
-					bus: bus.NewBus(bus.RAM{
-						0x00,
-					}),
+					bus:    bus.NewBus(bus.RAM{}),
 				}
-				cpu.lookup = mos6502LookupTable{
-					{
-						operation:   "TOP",
-						addressMode: "TAM",
-						performOp: func() uint8 {
-							// assert that U flag is set to true first
-							assert.Equal(t, uint8(0x01), cpu.GetStatusFlag(U))
-							// set U flag to false for final state assertion
-							cpu.setStatusFlag(U, false)
-							return 0
-						},
-						setAddressMode: func() uint8 {
-							return 0
-						},
-						cycles: 2,
-					},
+				cpu.steps = []microOp{
+					{run: func() { cpu.a = 0 }},
+					{run: func() { cpu.a++ }},
 				}
+				cpu.step = 1
 				return cpu
 			},
-			expectedPC:     0x0001,
-			expectedA:      0x11,
-			expectedX:      0x11,
-			expectedY:      0x11,
-			expectedStkp:   0x11,
-			expectedStatus: 0b00100000,
-			expectedCycles: 1,
+			expectedPC:       0x0000,
+			expectedA:        0x12,
+			expectedStatus:   0b00000000,
+			expectedStep:     2,
+			expectedComplete: true,
 		},
 		{
-			name: "cpu performs clock cycle with additional operation cycle only",
-			setupInitialState: func(t *testing.T) *Mos6502 {
-				t.Helper()
-				cpu := &Mos6502{
+			name: "cpu spends idle cycles doing nothing",
+			setupInitialState: func(*testing.T) *Mos6502 {
+				return &Mos6502{
 					pc:     0x0000,
 					a:      0x11,
-					x:      0x11,
-					y:      0x11,
-					stkp:   0x11,
 					status: 0b00000000,
-					cycles: 0,
-					bus: bus.NewBus(bus.RAM{
-						0x00,
-					}),
-				}
-				cpu.lookup = mos6502LookupTable{
-					{
-						operation:   "TOP",
-						addressMode: "TAM",
-						performOp: func() uint8 {
-							return 1
-						},
-						setAddressMode: func() uint8 {
-							return 0
-						},
-						cycles: 2,
-					},
+					steps:  resetSteps[:],
+					bus:    bus.NewBus(bus.RAM{}),
 				}
-				return cpu
 			},
-			expectedPC:     0x0001,
-			expectedA:      0x11,
-			expectedX:      0x11,
-			expectedY:      0x11,
-			expectedStkp:   0x11,
-			expectedStatus: 0b00100000,
-			expectedCycles: 1,
+			expectedPC:       0x0000,
+			expectedA:        0x11,
+			expectedStatus:   0b00000000,
+			expectedStep:     1,
+			expectedComplete: false,
 		},
 		{
-			name: "cpu performs clock cycle with additional address mode cycle only",
+			name: "cpu fetches the next opcode once complete",
 			setupInitialState: func(t *testing.T) *Mos6502 {
 				t.Helper()
 				cpu := &Mos6502{
 					pc:     0x0000,
 					a:      0x11,
-					x:      0x11,
-					y:      0x11,
-					stkp:   0x11,
 					status: 0b00000000,
-					cycles: 0,
 					bus: bus.NewBus(bus.RAM{
 						0x00,
 					}),
@@ -199,63 +231,43 @@ func TestMos6502_Clock(t *testing.T) {
 					{
 						operation:   "TOP",
 						addressMode: "TAM",
-						performOp: func() uint8 {
-							return 0
-						},
-						setAddressMode: func() uint8 {
-							return 1
+						performOp:   func() {},
+						cycles:      2,
+						steps: []microOp{
+							{run: func() {
+								// assert that U flag is set to true first
+								assert.Equal(t, uint8(0x01), cpu.GetStatusFlag(U))
+								cpu.a++
+							}},
 						},
-						cycles: 2,
 					},
 				}
 				return cpu
 			},
-			expectedPC:     0x0001,
-			expectedA:      0x11,
-			expectedX:      0x11,
-			expectedY:      0x11,
-			expectedStkp:   0x11,
-			expectedStatus: 0b00100000,
-			expectedCycles: 1,
+			expectedPC:       0x0001,
+			expectedA:        0x11,
+			expectedStatus:   0b00100000,
+			expectedStep:     0,
+			expectedComplete: false,
 		},
 		{
-			name: "cpu performs clock cycle with additional address mode cycle only",
-			setupInitialState: func(t *testing.T) *Mos6502 {
-				t.Helper()
-				cpu := &Mos6502{
+			name: "jammed cpu does nothing",
+			setupInitialState: func(*testing.T) *Mos6502 {
+				return &Mos6502{
 					pc:     0x0000,
 					a:      0x11,
-					x:      0x11,
-					y:      0x11,
-					stkp:   0x11,
 					status: 0b00000000,
-					cycles: 0,
+					jammed: true,
 					bus: bus.NewBus(bus.RAM{
 						0x00,
 					}),
 				}
-				cpu.lookup = mos6502LookupTable{
-					{
-						operation:   "TOP",
-						addressMode: "TAM",
-						performOp: func() uint8 {
-							return 1
-						},
-						setAddressMode: func() uint8 {
-							return 1
-						},
-						cycles: 2,
-					},
-				}
-				return cpu
 			},
-			expectedPC:     0x0001,
-			expectedA:      0x11,
-			expectedX:      0x11,
-			expectedY:      0x11,
-			expectedStkp:   0x11,
-			expectedStatus: 0b00100000,
-			expectedCycles: 2,
+			expectedPC:       0x0000,
+			expectedA:        0x11,
+			expectedStatus:   0b00000000,
+			expectedStep:     0,
+			expectedComplete: true,
 		},
 	}
 	for _, tc := range testCases {
@@ -265,11 +277,10 @@ func TestMos6502_Clock(t *testing.T) {
 
 			assert.Equal(t, tc.expectedPC, cpu.pc)
 			assert.Equal(t, tc.expectedA, cpu.a)
-			assert.Equal(t, tc.expectedX, cpu.x)
-			assert.Equal(t, tc.expectedY, cpu.y)
-			assert.Equal(t, tc.expectedStkp, cpu.stkp)
 			assert.Equal(t, tc.expectedStatus, cpu.status)
-			assert.Equal(t, tc.expectedCycles, cpu.cycles)
+			assert.Equal(t, tc.expectedStep, cpu.step)
+			assert.Equal(t, tc.expectedComplete, cpu.Complete())
+			assert.Equal(t, uint32(1), cpu.clockCount)
 		})
 	}
 }
@@ -292,7 +303,12 @@ func TestMos6502_Reset(t *testing.T) {
 				addressAbsolute: 0x1111,
 				addressRelative: 0x1111,
 				fetchedData:     0x11,
-				cycles:          1,
+				steps:           []microOp{{}},
+				step:            1,
+				polling:         true,
+				nmiEdge:         true,
+				irqPending:      true,
+				jammed:          true,
 				bus: newBusBuilder().
 					write(0xfffc, 0x20).
 					write(0xfffd, 0x04).
@@ -308,7 +324,7 @@ func TestMos6502_Reset(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0000,
 				fetchedData:     0x00,
-				cycles:          8,
+				steps:           resetSteps[:],
 				bus: newBusBuilder().
 					write(0xfffc, 0x20).
 					write(0xfffd, 0x04).
@@ -326,147 +342,489 @@ func TestMos6502_Reset(t *testing.T) {
 	}
 }
 
-func TestMos6502_InterruptRequest(t *testing.T) {
+func TestMos6502_PowerCycle(t *testing.T) {
+	cpu := newInterruptTestMos6502(0xa2, 0x07) // LDX #$07
+	step(cpu)
+	cpu.SetIRQ(IRQExternal, true)
+	cpu.jammed = true
+
+	cpu.PowerCycle()
+
+	assert.Equal(t, word(0), cpu.pc)
+	assert.Equal(t, byte(0), cpu.x)
+	assert.Equal(t, IRQSource(0), cpu.irq, "IRQ line held")
+	assert.False(t, cpu.Jammed())
+	assert.Equal(t, RP2A03, cpu.variant)
+
+	cpu.Reset()
+	step(cpu)
+	cpu.pc = 0x8000
+	step(cpu)
+	assert.Equal(t, byte(0x07), cpu.x, "instruction table bound to another CPU")
+}
+
+// step clocks the CPU until the current instruction or interrupt sequence has
+// completed.
+func step(cpu *Mos6502) {
+	cpu.Clock()
+	for !cpu.Complete() {
+		cpu.Clock()
+	}
+}
+
+// newInterruptTestMos6502 creates a Mos6502 running NOPs from 0x8000, with an
+// IRQ handler at 0x9000 and an NMI handler at 0xa000 that each execute a NOP
+// before returning. The passed bytes are written to the start of the program.
+func newInterruptTestMos6502(program ...byte) *Mos6502 {
+	b := newBusBuilder()
+	for i := word(0); i < 0x10; i++ {
+		b.write(0x8000+i, 0xea) // NOP
+	}
+	for i, data := range program {
+		b.write(0x8000+word(i), data)
+	}
+	b.write(0x9000, 0xea) // NOP
+	b.write(0x9001, 0x40) // RTI
+	b.write(0xa000, 0xea) // NOP
+	b.write(0xa001, 0x40) // RTI
+	b.write(0xfffa, 0x00)
+	b.write(0xfffb, 0xa0)
+	b.write(0xfffe, 0x00)
+	b.write(0xffff, 0x90)
+
+	cpu := NewMos6502(RP2A03)
+	cpu.ConnectBus(b.build())
+	cpu.pc = 0x8000
+	cpu.stkp = 0xfd
+	cpu.status = byte(U)
+	return cpu
+}
+
+// pushedStatus returns the status register pushed by the last interrupt
+// sequence, assuming the stack pointer was 0xfd when it started.
+func pushedStatus(cpu *Mos6502) byte {
+	return cpu.bus.Peek(0x01fb)
+}
+
+func TestMos6502_SetIRQ(t *testing.T) {
+	t.Run("released line is not serviced", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		step(cpu)
+		step(cpu)
+
+		assert.Equal(t, word(0x8002), cpu.pc)
+	})
+
+	t.Run("asserted line is ignored while I is set", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.setStatusFlag(I, true)
+		cpu.SetIRQ(IRQMapper, true)
+		step(cpu)
+		step(cpu)
+
+		assert.Equal(t, word(0x8002), cpu.pc)
+	})
+
+	t.Run("asserted line is serviced after the current instruction", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetIRQ(IRQMapper, true)
+		step(cpu)
+		assert.Equal(t, word(0x8001), cpu.pc)
+
+		step(cpu)
+		assert.Equal(t, word(0x9000), cpu.pc)
+		assert.Equal(t, uint8(1), cpu.GetStatusFlag(I))
+		assert.Equal(t, byte(0xfa), cpu.stkp)
+		assert.Equal(t, byte(0x01), cpu.bus.Peek(0x01fc))
+		assert.Equal(t, byte(0x80), cpu.bus.Peek(0x01fd))
+		assert.Equal(t, byte(0b00100000), pushedStatus(cpu))
+	})
+
+	t.Run("line asserted on the last cycle is serviced an instruction later", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.Clock()
+		cpu.SetIRQ(IRQMapper, true)
+		cpu.Clock()
+		step(cpu)
+		assert.Equal(t, word(0x8002), cpu.pc)
+
+		step(cpu)
+		assert.Equal(t, word(0x9000), cpu.pc)
+	})
+
+	t.Run("line stays asserted until acknowledged", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetIRQ(IRQMapper, true)
+		step(cpu) // NOP
+		step(cpu) // IRQ
+		step(cpu) // NOP
+		step(cpu) // RTI
+		assert.Equal(t, word(0x8001), cpu.pc)
+
+		step(cpu) // IRQ again, as the source never released the line
+		assert.Equal(t, word(0x9000), cpu.pc)
+
+		cpu.SetIRQ(IRQMapper, false)
+		step(cpu) // NOP
+		step(cpu) // RTI
+		step(cpu) // NOP
+		assert.Equal(t, word(0x8002), cpu.pc)
+	})
+
+	t.Run("line stays asserted while any source asserts it", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetIRQ(IRQMapper, true)
+		cpu.SetIRQ(IRQDMC, true)
+		cpu.SetIRQ(IRQMapper, false)
+		step(cpu) // NOP
+		step(cpu) // IRQ
+		assert.Equal(t, word(0x9000), cpu.pc)
+
+		cpu.SetIRQ(IRQDMC, false)
+		step(cpu) // NOP
+		step(cpu) // RTI
+		step(cpu) // NOP
+		assert.Equal(t, word(0x8002), cpu.pc)
+	})
+}
+
+func TestMos6502_IRQPolling(t *testing.T) {
 	testCases := []struct {
-		name          string
-		initialState  *Mos6502
-		expectedState *Mos6502
+		name            string
+		program         []byte
+		status          byte
+		stack           byte
+		clocksBeforeIRQ int
+		expectedPC      []word
+		expectedStatus  byte
 	}{
 		{
-			name: "cpu Interrupt Request does nothing with I true",
-			initialState: &Mos6502{
-				pc:              0x1111,
-				a:               0x11,
-				x:               0x11,
-				y:               0x11,
-				stkp:            0x11,
-				status:          0b00100100,
-				addressAbsolute: 0x1111,
-				addressRelative: 0x1111,
-				fetchedData:     0x11,
-				cycles:          1,
-				bus: newBusBuilder().
-					write(0xfffe, 0x20).
-					write(0xffff, 0x04).
-					build(),
-			},
-			expectedState: &Mos6502{
-				pc:              0x1111,
-				a:               0x11,
-				x:               0x11,
-				y:               0x11,
-				stkp:            0x11,
-				status:          0b00100100,
-				addressAbsolute: 0x1111,
-				addressRelative: 0x1111,
-				fetchedData:     0x11,
-				cycles:          1,
-				bus: newBusBuilder().
-					write(0xfffe, 0x20).
-					write(0xffff, 0x04).
-					build(),
-			},
+			name:           "CLI delays an IRQ by one instruction",
+			program:        []byte{0x58}, // CLI
+			status:         0b00100100,
+			expectedPC:     []word{0x8001, 0x8002, 0x9000},
+			expectedStatus: 0b00100000,
 		},
 		{
-			name: "cpu Interrupt Request executes correctly",
-			initialState: &Mos6502{
-				pc:              0x0420,
-				a:               0x11,
-				x:               0x11,
-				y:               0x11,
-				stkp:            0x11,
-				status:          0b00010000,
-				addressAbsolute: 0x1111,
-				addressRelative: 0x1111,
-				fetchedData:     0x11,
-				cycles:          1,
-				bus: newBusBuilder().
-					write(0xfffe, 0x20).
-					write(0xffff, 0x04).
-					build(),
-			},
-			expectedState: &Mos6502{
-				pc:              0x0420,
-				a:               0x11,
-				x:               0x11,
-				y:               0x11,
-				stkp:            0x0e,
-				status:          0b00100100,
-				addressAbsolute: 0xfffe,
-				addressRelative: 0x1111,
-				fetchedData:     0x11,
-				cycles:          7,
-				bus: newBusBuilder().
-					write(0x010f, 0b00100100).
-					write(0x0110, 0x20).
-					write(0x0111, 0x04).
-					write(0xfffe, 0x20).
-					write(0xffff, 0x04).
-					build(),
-			},
+			name:           "SEI lets an IRQ through with I pushed set",
+			program:        []byte{0x78}, // SEI
+			status:         0b00100000,
+			expectedPC:     []word{0x8001, 0x9000},
+			expectedStatus: 0b00100100,
+		},
+		{
+			name:           "PLP clearing I delays an IRQ by one instruction",
+			program:        []byte{0x28}, // PLP
+			status:         0b00100100,
+			stack:          0b00100000,
+			expectedPC:     []word{0x8001, 0x8002, 0x9000},
+			expectedStatus: 0b00100000,
+		},
+		{
+			name:           "PLP setting I lets an IRQ through",
+			program:        []byte{0x28}, // PLP
+			status:         0b00100000,
+			stack:          0b00100100,
+			expectedPC:     []word{0x8001, 0x9000},
+			expectedStatus: 0b00100100,
+		},
+		{
+			name:            "taken branch on the same page skips the poll before its last cycle",
+			program:         []byte{0xd0, 0x00}, // BNE +0
+			status:          0b00100000,
+			clocksBeforeIRQ: 1,
+			expectedPC:      []word{0x8002, 0x8003, 0x9000},
+			expectedStatus:  0b00100000,
+		},
+		{
+			name:            "taken branch across a page polls before its last cycle",
+			program:         []byte{0xd0, 0x80}, // BNE -128
+			status:          0b00100000,
+			clocksBeforeIRQ: 2,
+			expectedPC:      []word{0x7f82, 0x9000},
+			expectedStatus:  0b00100000,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			cpu.InterruptRequest()
+			cpu := newInterruptTestMos6502(tc.program...)
+			cpu.status = tc.status
+			if tc.stack != 0 {
+				cpu.write(0x0100+word(cpu.stkp), tc.stack)
+				cpu.stkp--
+			}
+			for i := 0; i < tc.clocksBeforeIRQ; i++ {
+				cpu.Clock()
+			}
+			cpu.SetIRQ(IRQExternal, true)
 
-			assert.Equal(t, tc.expectedState, cpu)
+			for _, pc := range tc.expectedPC {
+				step(cpu)
+				assert.Equal(t, pc, cpu.pc)
+			}
+			assert.Equal(t, tc.expectedStatus, pushedStatus(cpu), "incorrect pushed status")
+		})
+	}
+}
+
+func TestMos6502_SetNMI(t *testing.T) {
+	t.Run("asserted line is serviced after the current instruction", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetNMI(true)
+		step(cpu)
+		assert.Equal(t, word(0x8001), cpu.pc)
+
+		step(cpu)
+		assert.Equal(t, word(0xa000), cpu.pc)
+		assert.Equal(t, uint8(1), cpu.GetStatusFlag(I))
+		assert.Equal(t, byte(0b00100000), pushedStatus(cpu))
+	})
+
+	t.Run("asserted line is serviced while I is set", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.setStatusFlag(I, true)
+		cpu.SetNMI(true)
+		step(cpu)
+		step(cpu)
+
+		assert.Equal(t, word(0xa000), cpu.pc)
+	})
+
+	t.Run("held line is serviced once", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetNMI(true)
+		step(cpu) // NOP
+		step(cpu) // NMI
+		step(cpu) // NOP
+		step(cpu) // RTI
+		step(cpu) // NOP
+		step(cpu) // NOP
+
+		assert.Equal(t, word(0x8003), cpu.pc)
+	})
+
+	t.Run("released and reasserted line is serviced again", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetNMI(true)
+		step(cpu) // NOP
+		step(cpu) // NMI
+		cpu.SetNMI(false)
+		step(cpu) // NOP
+		cpu.SetNMI(true)
+		step(cpu) // RTI
+		step(cpu) // NMI
+
+		assert.Equal(t, word(0xa000), cpu.pc)
+	})
+
+	t.Run("edge is latched after the line is released", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetNMI(true)
+		cpu.SetNMI(false)
+		step(cpu)
+		step(cpu)
+
+		assert.Equal(t, word(0xa000), cpu.pc)
+	})
+
+	t.Run("NMI takes priority over IRQ", func(t *testing.T) {
+		cpu := newInterruptTestMos6502()
+		cpu.SetIRQ(IRQMapper, true)
+		cpu.SetNMI(true)
+		step(cpu)
+		step(cpu)
+
+		assert.Equal(t, word(0xa000), cpu.pc)
+	})
+}
+
+func TestMos6502_InterruptHijacking(t *testing.T) {
+	testCases := []struct {
+		name            string
+		program         []byte
+		irq             bool
+		clocksBeforeNMI int
+		expectedPC      word
+		expectedStatus  byte
+		expectedNextPCs []word
+	}{
+		{
+			name:            "NMI early in BRK hijacks it",
+			program:         []byte{0x00, 0x00}, // BRK
+			clocksBeforeNMI: 1,
+			expectedPC:      0xa000,
+			expectedStatus:  0b00110000,
+			expectedNextPCs: []word{0xa001},
+		},
+		{
+			name:            "NMI late in BRK is serviced after the first handler instruction",
+			program:         []byte{0x00, 0x00}, // BRK
+			clocksBeforeNMI: 5,
+			expectedPC:      0x9000,
+			expectedStatus:  0b00110000,
+			expectedNextPCs: []word{0x9001, 0xa000},
+		},
+		{
+			name:            "NMI early in an IRQ hijacks it",
+			irq:             true,
+			clocksBeforeNMI: 3,
+			expectedPC:      0xa000,
+			expectedStatus:  0b00100000,
+			expectedNextPCs: []word{0xa001},
+		},
+		{
+			name:            "NMI late in an IRQ is serviced after the first handler instruction",
+			irq:             true,
+			clocksBeforeNMI: 7,
+			expectedPC:      0x9000,
+			expectedStatus:  0b00100000,
+			expectedNextPCs: []word{0x9001, 0xa000},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cpu := newInterruptTestMos6502(tc.program...)
+			cpu.SetIRQ(IRQMapper, tc.irq)
+			for i := 0; i < tc.clocksBeforeNMI; i++ {
+				cpu.Clock()
+			}
+			cpu.SetNMI(true)
+			for !cpu.Complete() {
+				cpu.Clock()
+			}
+
+			assert.Equal(t, tc.expectedPC, cpu.pc)
+			assert.Equal(t, tc.expectedStatus, pushedStatus(cpu), "incorrect pushed status")
+
+			for _, pc := range tc.expectedNextPCs {
+				step(cpu)
+				assert.Equal(t, pc, cpu.pc)
+			}
 		})
 	}
 }
 
-func TestMos6502_NonMaskableInterrupt(t *testing.T) {
+// oamRegister is a bus.Device recording the bytes written to OAMDATA.
+type oamRegister struct {
+	data []uint8
+}
+
+func (r *oamRegister) Read(uint16) uint8 {
+	return 0
+}
+
+func (r *oamRegister) Write(address uint16, data uint8) {
+	if address == 0x2004 {
+		r.data = append(r.data, data)
+	}
+}
+
+func (r *oamRegister) Peek(uint16) uint8 {
+	return 0
+}
+
+func TestMos6502_OAMDMA(t *testing.T) {
 	testCases := []struct {
 		name          string
-		initialState  *Mos6502
-		expectedState *Mos6502
+		program       []byte
+		instructions  int
+		expectedStall uint32
 	}{
 		{
-			name: "cpu NMI request executes correctly",
-			initialState: &Mos6502{
-				pc:              0x0420,
-				a:               0x11,
-				x:               0x11,
-				y:               0x11,
-				stkp:            0x11,
-				status:          0b00010000,
-				addressAbsolute: 0x1111,
-				addressRelative: 0x1111,
-				fetchedData:     0x11,
-				cycles:          1,
-				bus: newBusBuilder().
-					write(0xfffa, 0x20).
-					write(0xfffb, 0x04).
-					build(),
-			},
-			expectedState: &Mos6502{
-				pc:              0x0420,
-				a:               0x11,
-				x:               0x11,
-				y:               0x11,
-				stkp:            0x0e,
-				status:          0b00100100,
-				addressAbsolute: 0xfffa,
-				addressRelative: 0x1111,
-				fetchedData:     0x11,
-				cycles:          8,
-				bus: newBusBuilder().
-					write(0x010f, 0b00100100).
-					write(0x0110, 0x20).
-					write(0x0111, 0x04).
-					write(0xfffa, 0x20).
-					write(0xfffb, 0x04).
-					build(),
+			name:          "halted on an even cycle",
+			program:       []byte{0x8d, 0x14, 0x40}, // STA $4014
+			instructions:  1,
+			expectedStall: 513,
+		},
+		{
+			name: "halted on an odd cycle",
+			program: []byte{
+				0xa6, 0x00, // LDX $00
+				0x8d, 0x14, 0x40, // STA $4014
 			},
+			instructions:  2,
+			expectedStall: 514,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			cpu.NonMaskableInterrupt()
+			b := bus.NewNESBus()
+			oam := &oamRegister{}
+			prg := &bus.RAM{}
+			assert.NoError(t, b.Attach(oam, bus.PPUStart, bus.PPUEnd))
+			assert.NoError(t, b.Attach(prg, bus.CartridgeStart, bus.CartridgeEnd))
+			copy(prg[0x8000:], tc.program)
+			prg[0x8000+len(tc.program)] = 0xea // NOP
+			expectedOAM := make([]uint8, 256)
+			for i := range expectedOAM {
+				expectedOAM[i] = uint8(i) ^ 0x5a
+				b.Write(uint16(i), expectedOAM[i])
+			}
 
-			assert.Equal(t, tc.expectedState, cpu)
+			cpu := NewMos6502(RP2A03)
+			cpu.ConnectBus(b)
+			cpu.pc = 0x8000
+			for i := 0; i < tc.instructions; i++ {
+				step(cpu)
+			}
+			start := cpu.clockCount
+			for cpu.Complete() {
+				cpu.Clock()
+			}
+
+			assert.Equal(t, tc.expectedStall, cpu.clockCount-1-start, "incorrect stall cycles")
+			assert.Equal(t, byte(0xea), cpu.opcode, "instruction after the transfer not executed")
+			assert.Equal(t, expectedOAM, oam.data)
+		})
+	}
+}
+
+func TestMos6502_DMAHalt(t *testing.T) {
+	testCases := []struct {
+		name           string
+		program        []byte
+		clocksBefore   int
+		expectedHalted bool
+		expectedPC     word
+		expectedStack  byte
+	}{
+		{
+			name:           "read cycle is halted",
+			program:        []byte{0xad, 0x00, 0x00}, // LDA $0000
+			clocksBefore:   1,
+			expectedHalted: true,
+			expectedPC:     0x8001,
+		},
+		{
+			name:          "write cycle is not halted",
+			program:       []byte{0x48}, // PHA
+			clocksBefore:  2,
+			expectedPC:    0x8001,
+			expectedStack: 0x42,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := bus.NewNESBus()
+			prg := &bus.RAM{}
+			assert.NoError(t, b.Attach(prg, bus.CartridgeStart, bus.CartridgeEnd))
+			copy(prg[0x8000:], tc.program)
+
+			cpu := NewMos6502(RP2A03)
+			cpu.ConnectBus(b)
+			cpu.pc = 0x8000
+			cpu.stkp = 0xfd
+			cpu.a = 0x42
+			for i := 0; i < tc.clocksBefore; i++ {
+				cpu.Clock()
+			}
+			step := cpu.step
+			b.Write(bus.OAMDMA, 0x02)
+			cpu.Clock()
+
+			assert.Equal(t, tc.expectedHalted, cpu.step == step, "incorrect halt")
+			assert.Equal(t, tc.expectedPC, cpu.pc)
+			assert.Equal(t, tc.expectedStack, b.Peek(0x01fd))
 		})
 	}
 }
@@ -528,9 +886,20 @@ func TestMos6502_GetStatusFlag(t *testing.T) {
 	}
 }
 
-func TestMos6502_setStatusFlag(t *testing.T) {
-	allFlags := []Flag{
-		C,
+func TestMos6502_Complete(t *testing.T) {
+	cpu := newInterruptTestMos6502()
+	assert.True(t, cpu.Complete(), "complete before the first instruction")
+
+	cpu.Clock()
+	assert.False(t, cpu.Complete(), "complete during NOP")
+
+	cpu.Clock()
+	assert.True(t, cpu.Complete(), "not complete after NOP")
+}
+
+func TestMos6502_setStatusFlag(t *testing.T) {
+	allFlags := []Flag{
+		C,
 		Z,
 		I,
 		D,
@@ -564,914 +933,766 @@ func TestMos6502_setStatusFlag(t *testing.T) {
 			expectedStatus: 0b00000000,
 		},
 		{
-			name:           "initially true set to true does nothing",
-			initialStatus:  0b11111111,
-			value:          true,
-			expectedStatus: 0b11111111,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := newTestMos6502()
-			cpu.status = tc.initialStatus
-
-			for _, flag := range allFlags {
-				cpu.setStatusFlag(flag, tc.value)
-			}
-
-			assert.Equal(t, tc.expectedStatus, cpu.status)
-		})
-	}
-}
-
-func TestMos6502_imp(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "correct data fetched from accumulator",
-			initialState: &Mos6502{
-				a:           42,
-				fetchedData: 0,
-			},
-			expectedState: &Mos6502{
-				a:           42,
-				fetchedData: 42,
-			},
-			expectedAdditionalCycles: 0,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.imp()
-
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
-		})
-	}
-}
-
-func TestMos6502_imm(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "absolute address points to address immediately after program counter",
-			initialState: &Mos6502{
-				pc:              0,
-				addressAbsolute: 0,
-			},
-			expectedState: &Mos6502{
-				pc:              0,
-				addressAbsolute: 1,
-			},
-			expectedAdditionalCycles: 0,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.imm()
-
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
-		})
-	}
-}
-
-func TestMos6502_zp0(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "absolute address set to start of page 0x00 for byte 0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to correct location in page 0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0042,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to end of page 0x00 for byte 0xff",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x00ff,
-			},
-			expectedAdditionalCycles: 0,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.zp0()
-
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
-		})
-	}
-}
-
-func TestMos6502_zpx(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "absolute address set to start of page 0x00 for byte 0x00 and x=0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				x:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				x:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to correct location in page 0x00 and x=0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				x:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				x:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0042,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to end of page 0x00 for byte 0xff and x=0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				x:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				x:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x00ff,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to start of page 0x00 for byte 0xff and x=0x01",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				x:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				x:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to correct location in page 0x00 and x=0x01",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				x:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				x:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0043,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to byte 0xfe of page 0x00 for byte 0xff and x=0xff",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				x:               0xff,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				x:               0xff,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x00fe,
-			},
-			expectedAdditionalCycles: 0,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.zpx()
-
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
-		})
-	}
-}
-
-func TestMos6502_zpy(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "absolute address set to start of page 0x00 for byte 0x00 and y=0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				y:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				y:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to correct location in page 0x00 and y=0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				y:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				y:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0042,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to end of page 0x00 for byte 0xff and y=0x00",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				y:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				y:               0x00,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x00ff,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to start of page 0x00 for byte 0xff and y=0x01",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				y:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				y:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to correct location in page 0x00 and y=0x01",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				y:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				y:               0x01,
-				bus:             newBusBuilder().write(0x0000, 0x42).build(),
-				addressAbsolute: 0x0043,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "absolute address set to byte 0xfe of page 0x00 for byte 0xff and y=0xff",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				y:               0xff,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				y:               0xff,
-				bus:             newBusBuilder().write(0x0000, 0xff).build(),
-				addressAbsolute: 0x00fe,
-			},
-			expectedAdditionalCycles: 0,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.zpy()
-
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
-		})
-	}
-}
-
-func TestMos6502_rel(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "relative address at top of branch range is set correctly",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				bus:             newBusBuilder().write(0x0000, 0x79).build(),
-				addressRelative: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				bus:             newBusBuilder().write(0x0000, 0x79).build(),
-				addressRelative: 0x0079,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "relative address within branch range is set correctly",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressRelative: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				bus:             newBusBuilder().write(0x0000, 0x00).build(),
-				addressRelative: 0x0000,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "relative address at bottom of branch range is set correctly",
-			initialState: &Mos6502{
-				pc:              0x0000,
-				bus:             newBusBuilder().write(0x0000, 0x80).build(),
-				addressRelative: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc:              0x0001,
-				bus:             newBusBuilder().write(0x0000, 0x80).build(),
-				addressRelative: 0xff80,
-			},
-			expectedAdditionalCycles: 0,
+			name:           "initially true set to true does nothing",
+			initialStatus:  0b11111111,
+			value:          true,
+			expectedStatus: 0b11111111,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.rel()
+			cpu := newTestMos6502()
+			cpu.status = tc.initialStatus
 
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
+			for _, flag := range allFlags {
+				cpu.setStatusFlag(flag, tc.value)
+			}
+
+			assert.Equal(t, tc.expectedStatus, cpu.status)
 		})
 	}
 }
 
-func TestMos6502_abs(t *testing.T) {
+func TestMos6502_imp(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
-			name: "absolute address read correctly",
+			name: "correct data fetched from accumulator",
 			initialState: &Mos6502{
-				pc: 0x0000,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
+				a:           42,
+				fetchedData: 0,
 			},
 			expectedState: &Mos6502{
-				pc: 0x0002,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
+				a:           42,
+				fetchedData: 42,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.abs()
+			cpu.imp()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
 
-func TestMos6502_abx(t *testing.T) {
+func TestMos6502_imm(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
-			name: "x equals zero",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				x:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0002,
-				x:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "non-zero x and no page change",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				x:  0x10,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x02,
-				x:  0x0010,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0430,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "x value causing page change",
+			name: "absolute address points to program counter, which steps over it",
 			initialState: &Mos6502{
-				pc: 0x0000,
-				x:  0x42,
-				bus: newBusBuilder().
-					write(0x0000, 0xde).
-					write(0x0001, 0x03).
-					build(),
-				addressAbsolute: 0x0000,
+				pc:              0,
+				addressAbsolute: 0,
 			},
 			expectedState: &Mos6502{
-				pc: 0x0002,
-				x:  0x42,
-				bus: newBusBuilder().
-					write(0x0000, 0xde).
-					write(0x0001, 0x03).
-					build(),
-				addressAbsolute: 0x0420,
+				pc:              1,
+				addressAbsolute: 0,
 			},
-			expectedAdditionalCycles: 1,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.abx()
+			cpu.imm()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
 
-func TestMos6502_aby(t *testing.T) {
+func TestMos6502_rel(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
-			name: "y equals zero",
+			name: "relative address at top of branch range is set correctly",
 			initialState: &Mos6502{
-				pc: 0x0000,
-				y:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
+				pc:              0x0000,
+				bus:             newBusBuilder().write(0x0000, 0x79).build(),
+				addressRelative: 0x0000,
 			},
 			expectedState: &Mos6502{
-				pc: 0x0002,
-				y:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
+				pc:              0x0001,
+				bus:             newBusBuilder().write(0x0000, 0x79).build(),
+				addressRelative: 0x0079,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
-			name: "non-zero y and no page change",
+			name: "relative address within branch range is set correctly",
 			initialState: &Mos6502{
-				pc: 0x0000,
-				y:  0x10,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
+				pc:              0x0000,
+				bus:             newBusBuilder().write(0x0000, 0x00).build(),
+				addressRelative: 0x0000,
 			},
 			expectedState: &Mos6502{
-				pc: 0x02,
-				y:  0x0010,
-				bus: newBusBuilder().
-					write(0x0000, 0x20).
-					write(0x0001, 0x04).
-					build(),
-				addressAbsolute: 0x0430,
+				pc:              0x0001,
+				bus:             newBusBuilder().write(0x0000, 0x00).build(),
+				addressRelative: 0x0000,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
-			name: "y value causing page change",
+			name: "relative address at bottom of branch range is set correctly",
 			initialState: &Mos6502{
-				pc: 0x0000,
-				y:  0x42,
-				bus: newBusBuilder().
-					write(0x0000, 0xde).
-					write(0x0001, 0x03).
-					build(),
-				addressAbsolute: 0x0000,
+				pc:              0x0000,
+				bus:             newBusBuilder().write(0x0000, 0x80).build(),
+				addressRelative: 0x0000,
 			},
 			expectedState: &Mos6502{
-				pc: 0x0002,
-				y:  0x42,
-				bus: newBusBuilder().
-					write(0x0000, 0xde).
-					write(0x0001, 0x03).
-					build(),
-				addressAbsolute: 0x0420,
+				pc:              0x0001,
+				bus:             newBusBuilder().write(0x0000, 0x80).build(),
+				addressRelative: 0xff80,
 			},
-			expectedAdditionalCycles: 1,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.aby()
+			cpu.rel()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
 
-func TestMos6502_ind(t *testing.T) {
-	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
-	}{
-		{
-			name: "reads indirect address correctly with no page change",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				bus: newBusBuilder().
-					write(0x0000, 0x02).
-					write(0x0001, 0x00).
-					write(0x0002, 0x20).
-					write(0x0003, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0002,
-				bus: newBusBuilder().
-					write(0x0000, 0x02).
-					write(0x0001, 0x00).
-					write(0x0002, 0x20).
-					write(0x0003, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "replicates page change bug wrapping around to start of page",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				bus: newBusBuilder().
-					write(0x0000, 0xff).
-					write(0x0001, 0x00).
-					write(0x00ff, 0x04).
-					write(0x0100, 0x20).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0002,
-				bus: newBusBuilder().
-					write(0x0000, 0xff).
-					write(0x0001, 0x00).
-					write(0x00ff, 0x04).
-					write(0x0100, 0x20).
-					build(),
-				addressAbsolute: 0xff04,
-			},
-			expectedAdditionalCycles: 0,
-		},
-	}
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.ind()
+// busAccess is a single read or write made on a Bus.
+type busAccess struct {
+	address uint16
+	data    uint8
+	write   bool
+}
 
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
-		})
-	}
+// busRead returns a busAccess reading data from an address.
+func busRead(address uint16, data uint8) busAccess {
+	return busAccess{address: address, data: data}
+}
+
+// busWrite returns a busAccess writing data to an address.
+func busWrite(address uint16, data uint8) busAccess {
+	return busAccess{address: address, data: data, write: true}
+}
+
+// busRecorder is a bus.Device backed by RAM, recording every read and write
+// made through it.
+type busRecorder struct {
+	ram      bus.RAM
+	accesses []busAccess
+}
+
+func (r *busRecorder) Read(address uint16) uint8 {
+	data := r.ram.Read(address)
+	r.accesses = append(r.accesses, busRead(address, data))
+	return data
+}
+
+func (r *busRecorder) Write(address uint16, data uint8) {
+	r.ram.Write(address, data)
+	r.accesses = append(r.accesses, busWrite(address, data))
+}
+
+func (r *busRecorder) Peek(address uint16) uint8 {
+	return r.ram.Peek(address)
 }
 
-func TestMos6502_izx(t *testing.T) {
+func TestMos6502_busAccesses(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name             string
+		variant          Variant
+		program          []byte
+		memory           map[uint16]byte
+		x                byte
+		y                byte
+		stkp             byte
+		status           byte
+		expectedAccesses []busAccess
+		expectedPC       word
 	}{
 		{
-			name: "indirect assignment of page 0 address when x equals 0",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				x:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x01).
-					write(0x0001, 0xff).
-					write(0x0002, 0xff).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0001,
-				x:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x01).
-					write(0x0001, 0xff).
-					write(0x0002, 0xff).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0xffff,
+			name:    "implied mode reads the next byte",
+			program: []byte{0xea}, // NOP
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xea),
+				busRead(0x8001, 0x00),
 			},
-			expectedAdditionalCycles: 0,
+			expectedPC: 0x8001,
 		},
 		{
-			name: "indirect assignment of page 0x00 is offset by x index value",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				x:  0x02,
-				bus: newBusBuilder().
-					write(0x0000, 0x01).
-					write(0x0001, 0xff).
-					write(0x0002, 0xff).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0001,
-				x:  0x02,
-				bus: newBusBuilder().
-					write(0x0000, 0x01).
-					write(0x0001, 0xff).
-					write(0x0002, 0xff).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
-			},
-			expectedAdditionalCycles: 0,
-		},
+			name:    "immediate mode reads the operand",
+			program: []byte{0xa9, 0x42}, // LDA #$42
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xa9),
+				busRead(0x8001, 0x42),
+			},
+			expectedPC: 0x8002,
+		},
 		{
-			name: "indirect assignment of page 0x00 is offset by large x index value",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				x:  0xfe,
-				bus: newBusBuilder().
-					write(0x0000, 0x01).
-					write(0x00ff, 0x20).
-					write(0x0100, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0001,
-				x:  0xfe,
-				bus: newBusBuilder().
-					write(0x0000, 0x01).
-					write(0x00ff, 0x20).
-					write(0x0100, 0x04).
-					build(),
-				addressAbsolute: 0x0120,
+			name:    "zero page mode",
+			program: []byte{0xa5, 0x10}, // LDA $10
+			memory:  map[uint16]byte{0x0010: 0x55},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xa5),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x55),
 			},
-			expectedAdditionalCycles: 0,
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "zero page x mode reads the base address and wraps",
+			program: []byte{0xb5, 0x10}, // LDA $10,X
+			memory:  map[uint16]byte{0x0005: 0x55},
+			x:       0xf5,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xb5),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x00),
+				busRead(0x0005, 0x55),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "zero page y mode reads the base address",
+			program: []byte{0xb6, 0x10}, // LDX $10,Y
+			memory:  map[uint16]byte{0x0011: 0x55},
+			y:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xb6),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x00),
+				busRead(0x0011, 0x55),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "absolute mode",
+			program: []byte{0xad, 0x34, 0x12}, // LDA $1234
+			memory:  map[uint16]byte{0x1234: 0x55},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xad),
+				busRead(0x8001, 0x34),
+				busRead(0x8002, 0x12),
+				busRead(0x1234, 0x55),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "absolute x mode read within a page skips the fixup",
+			program: []byte{0xbd, 0x34, 0x12}, // LDA $1234,X
+			memory:  map[uint16]byte{0x1235: 0x55},
+			x:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xbd),
+				busRead(0x8001, 0x34),
+				busRead(0x8002, 0x12),
+				busRead(0x1235, 0x55),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "absolute x mode read across a page makes a dummy read from the wrong page",
+			program: []byte{0xbd, 0xff, 0x12}, // LDA $12FF,X
+			memory:  map[uint16]byte{0x1200: 0xaa, 0x1300: 0x55},
+			x:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xbd),
+				busRead(0x8001, 0xff),
+				busRead(0x8002, 0x12),
+				busRead(0x1200, 0xaa),
+				busRead(0x1300, 0x55),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "absolute y mode write within a page makes a dummy read",
+			program: []byte{0x99, 0x34, 0x12}, // STA $1234,Y
+			memory:  map[uint16]byte{0x1235: 0xaa},
+			y:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x99),
+				busRead(0x8001, 0x34),
+				busRead(0x8002, 0x12),
+				busRead(0x1235, 0xaa),
+				busWrite(0x1235, 0x00),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "read-modify-write writes the unmodified value first",
+			program: []byte{0xe6, 0x10}, // INC $10
+			memory:  map[uint16]byte{0x0010: 0x07},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xe6),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x07),
+				busWrite(0x0010, 0x07),
+				busWrite(0x0010, 0x08),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "indexed read-modify-write always fixes up the address",
+			program: []byte{0xfe, 0x34, 0x12}, // INC $1234,X
+			memory:  map[uint16]byte{0x1235: 0x07},
+			x:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xfe),
+				busRead(0x8001, 0x34),
+				busRead(0x8002, 0x12),
+				busRead(0x1235, 0x07),
+				busRead(0x1235, 0x07),
+				busWrite(0x1235, 0x07),
+				busWrite(0x1235, 0x08),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "indirect x mode reads the pointer before indexing it",
+			program: []byte{0xa1, 0x10}, // LDA ($10,X)
+			memory:  map[uint16]byte{0x0014: 0x00, 0x0015: 0x03, 0x0300: 0x55},
+			x:       0x04,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xa1),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x00),
+				busRead(0x0014, 0x00),
+				busRead(0x0015, 0x03),
+				busRead(0x0300, 0x55),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "indirect x mode pointer wraps around page 0x00",
+			program: []byte{0xa1, 0xff}, // LDA ($FF,X)
+			memory:  map[uint16]byte{0x00ff: 0x00, 0x0000: 0x03, 0x0300: 0x55},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xa1),
+				busRead(0x8001, 0xff),
+				busRead(0x00ff, 0x00),
+				busRead(0x00ff, 0x00),
+				busRead(0x0000, 0x03),
+				busRead(0x0300, 0x55),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "indirect y mode read across a page makes a dummy read from the wrong page",
+			program: []byte{0xb1, 0x10}, // LDA ($10),Y
+			memory:  map[uint16]byte{0x0010: 0xff, 0x0011: 0x02, 0x0300: 0x55},
+			y:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xb1),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0xff),
+				busRead(0x0011, 0x02),
+				busRead(0x0200, 0x00),
+				busRead(0x0300, 0x55),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "indirect y mode write within a page makes a dummy read",
+			program: []byte{0x91, 0x10}, // STA ($10),Y
+			memory:  map[uint16]byte{0x0010: 0x00, 0x0011: 0x02},
+			y:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x91),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x00),
+				busRead(0x0011, 0x02),
+				busRead(0x0201, 0x00),
+				busWrite(0x0201, 0x00),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "indirect mode replicates the page wrapping bug",
+			program: []byte{0x6c, 0xff, 0x10}, // JMP ($10FF)
+			memory:  map[uint16]byte{0x10ff: 0x34, 0x1000: 0x12, 0x1100: 0x56},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x6c),
+				busRead(0x8001, 0xff),
+				busRead(0x8002, 0x10),
+				busRead(0x10ff, 0x34),
+				busRead(0x1000, 0x12),
+			},
+			expectedPC: 0x1234,
+		},
+		{
+			name:    "jsr pushes the address of its last byte",
+			program: []byte{0x20, 0x34, 0x12}, // JSR $1234
+			stkp:    0xfd,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x20),
+				busRead(0x8001, 0x34),
+				busRead(0x01fd, 0x00),
+				busWrite(0x01fd, 0x80),
+				busWrite(0x01fc, 0x02),
+				busRead(0x8002, 0x12),
+			},
+			expectedPC: 0x1234,
+		},
+		{
+			name:    "rts steps over the last byte of the jsr",
+			program: []byte{0x60}, // RTS
+			memory:  map[uint16]byte{0x01fc: 0x02, 0x01fd: 0x80},
+			stkp:    0xfb,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x60),
+				busRead(0x8001, 0x00),
+				busRead(0x01fb, 0x00),
+				busRead(0x01fc, 0x02),
+				busRead(0x01fd, 0x80),
+				busRead(0x8002, 0x00),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "rti pulls the status then the program counter",
+			program: []byte{0x40}, // RTI
+			memory:  map[uint16]byte{0x01fb: 0x20, 0x01fc: 0x34, 0x01fd: 0x12},
+			stkp:    0xfa,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x40),
+				busRead(0x8001, 0x00),
+				busRead(0x01fa, 0x00),
+				busRead(0x01fb, 0x20),
+				busRead(0x01fc, 0x34),
+				busRead(0x01fd, 0x12),
+			},
+			expectedPC: 0x1234,
+		},
+		{
+			name:    "pha",
+			program: []byte{0x48}, // PHA
+			stkp:    0xfd,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x48),
+				busRead(0x8001, 0x00),
+				busWrite(0x01fd, 0x00),
+			},
+			expectedPC: 0x8001,
+		},
+		{
+			name:    "pla reads the stack before incrementing the stack pointer",
+			program: []byte{0x68}, // PLA
+			memory:  map[uint16]byte{0x01fe: 0x55},
+			stkp:    0xfd,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x68),
+				busRead(0x8001, 0x00),
+				busRead(0x01fd, 0x00),
+				busRead(0x01fe, 0x55),
+			},
+			expectedPC: 0x8001,
+		},
+		{
+			name:    "branch not taken",
+			program: []byte{0xd0, 0x02}, // BNE +2
+			status:  byte(Z),
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xd0),
+				busRead(0x8001, 0x02),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "branch taken within a page reads the next opcode",
+			program: []byte{0xd0, 0x02, 0xea}, // BNE +2
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xd0),
+				busRead(0x8001, 0x02),
+				busRead(0x8002, 0xea),
+			},
+			expectedPC: 0x8004,
+		},
+		{
+			name:    "branch taken across a page reads from the wrong page",
+			program: []byte{0xd0, 0x80, 0xea}, // BNE -128
+			memory:  map[uint16]byte{0x8082: 0xaa},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xd0),
+				busRead(0x8001, 0x80),
+				busRead(0x8002, 0xea),
+				busRead(0x8082, 0xaa),
+			},
+			expectedPC: 0x7f82,
+		},
+		{
+			name:    "brk pushes the address after its padding byte",
+			program: []byte{0x00, 0xff}, // BRK
+			memory:  map[uint16]byte{0xfffe: 0x34, 0xffff: 0x12},
+			stkp:    0xfd,
+			status:  byte(U),
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x00),
+				busRead(0x8001, 0xff),
+				busWrite(0x01fd, 0x80),
+				busWrite(0x01fc, 0x02),
+				busWrite(0x01fb, 0b00110000),
+				busRead(0xfffe, 0x34),
+				busRead(0xffff, 0x12),
+			},
+			expectedPC: 0x1234,
+		},
+		{
+			name:    "65C02 indirect jump reads the high byte from the next page",
+			variant: WDC65C02,
+			program: []byte{0x6c, 0xff, 0x10}, // JMP ($10FF)
+			memory:  map[uint16]byte{0x10ff: 0x34, 0x1100: 0x12},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x6c),
+				busRead(0x8001, 0xff),
+				busRead(0x8002, 0x10),
+				busRead(0x8002, 0x10),
+				busRead(0x10ff, 0x34),
+				busRead(0x1100, 0x12),
+			},
+			expectedPC: 0x1234,
+		},
+		{
+			name:    "65C02 absolute indexed indirect jump",
+			variant: WDC65C02,
+			program: []byte{0x7c, 0x00, 0x10}, // JMP ($1000,X)
+			memory:  map[uint16]byte{0x1002: 0x34, 0x1003: 0x12},
+			x:       0x02,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x7c),
+				busRead(0x8001, 0x00),
+				busRead(0x8002, 0x10),
+				busRead(0x8002, 0x10),
+				busRead(0x1002, 0x34),
+				busRead(0x1003, 0x12),
+			},
+			expectedPC: 0x1234,
+		},
+		{
+			name:    "65C02 zero page indirect mode",
+			variant: WDC65C02,
+			program: []byte{0xb2, 0x10}, // LDA ($10)
+			memory:  map[uint16]byte{0x0010: 0x34, 0x0011: 0x12, 0x1234: 0x55},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xb2),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x34),
+				busRead(0x0011, 0x12),
+				busRead(0x1234, 0x55),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "65C02 page crossing reads the last byte of the instruction again",
+			variant: WDC65C02,
+			program: []byte{0xbd, 0xff, 0x10}, // LDA $10FF,X
+			memory:  map[uint16]byte{0x1100: 0x55},
+			x:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xbd),
+				busRead(0x8001, 0xff),
+				busRead(0x8002, 0x10),
+				busRead(0x8002, 0x10),
+				busRead(0x1100, 0x55),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "65C02 read-modify-write reads its operand twice",
+			variant: WDC65C02,
+			program: []byte{0xe6, 0x10}, // INC $10
+			memory:  map[uint16]byte{0x0010: 0x41},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xe6),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x41),
+				busRead(0x0010, 0x41),
+				busWrite(0x0010, 0x42),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "65C02 shift within a page skips the fixup",
+			variant: WDC65C02,
+			program: []byte{0x1e, 0x00, 0x10}, // ASL $1000,X
+			memory:  map[uint16]byte{0x1001: 0x01},
+			x:       0x01,
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x1e),
+				busRead(0x8001, 0x00),
+				busRead(0x8002, 0x10),
+				busRead(0x1001, 0x01),
+				busRead(0x1001, 0x01),
+				busWrite(0x1001, 0x02),
+			},
+			expectedPC: 0x8003,
+		},
+		{
+			name:    "65C02 single byte nop",
+			variant: WDC65C02,
+			program: []byte{0x03}, // NOP
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x03),
+			},
+			expectedPC: 0x8001,
+		},
+		{
+			name:    "65C02 decimal mode takes an extra cycle",
+			variant: WDC65C02,
+			program: []byte{0x69, 0x01}, // ADC #$01
+			status:  byte(D),
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x69),
+				busRead(0x8001, 0x01),
+				busRead(0x8002, 0x00),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "65C02 stz",
+			variant: WDC65C02,
+			program: []byte{0x64, 0x10}, // STZ $10
+			memory:  map[uint16]byte{0x0010: 0x55},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x64),
+				busRead(0x8001, 0x10),
+				busWrite(0x0010, 0x00),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "R65C02 smb",
+			variant: R65C02,
+			program: []byte{0xf7, 0x10}, // SMB7 $10
+			memory:  map[uint16]byte{0x0010: 0x01},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0xf7),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0x01),
+				busRead(0x0010, 0x01),
+				busWrite(0x0010, 0x81),
+			},
+			expectedPC: 0x8002,
+		},
+		{
+			name:    "R65C02 bbr taken",
+			variant: R65C02,
+			program: []byte{0x0f, 0x10, 0x02}, // BBR0 $10, +2
+			memory:  map[uint16]byte{0x0010: 0xfe},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x0f),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0xfe),
+				busRead(0x0010, 0xfe),
+				busRead(0x8002, 0x02),
+				busRead(0x8003, 0x00),
+			},
+			expectedPC: 0x8005,
+		},
+		{
+			name:    "R65C02 bbs not taken",
+			variant: R65C02,
+			program: []byte{0x8f, 0x10, 0x02}, // BBS0 $10, +2
+			memory:  map[uint16]byte{0x0010: 0xfe},
+			expectedAccesses: []busAccess{
+				busRead(0x8000, 0x8f),
+				busRead(0x8001, 0x10),
+				busRead(0x0010, 0xfe),
+				busRead(0x0010, 0xfe),
+				busRead(0x8002, 0x02),
+			},
+			expectedPC: 0x8003,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.izx()
+			recorder := &busRecorder{}
+			copy(recorder.ram[0x8000:], tc.program)
+			for address, data := range tc.memory {
+				recorder.ram[address] = data
+			}
+			b := &bus.Bus{}
+			assert.NoError(t, b.Attach(recorder, 0x0000, 0xffff))
+
+			cpu := NewMos6502(tc.variant)
+			cpu.ConnectBus(b)
+			cpu.pc = 0x8000
+			cpu.x = tc.x
+			cpu.y = tc.y
+			cpu.stkp = tc.stkp
+			cpu.status = tc.status
+
+			cycles := 0
+			for cycles == 0 || !cpu.Complete() {
+				cpu.Clock()
+				cycles++
+			}
 
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
+			assert.Equal(t, tc.expectedAccesses, recorder.accesses)
+			assert.Equal(t, len(tc.expectedAccesses), cycles, "accesses not made one per cycle")
+			assert.Equal(t, tc.expectedPC, cpu.pc)
 		})
 	}
 }
 
-func TestMos6502_izy(t *testing.T) {
+func TestMos6502_lookupCycles(t *testing.T) {
+	for _, variant := range []Variant{RP2A03, NMOS6502, WDC65C02, R65C02} {
+		cpu := NewMos6502(variant)
+		for opcode, instruction := range cpu.lookup {
+			// the opcode fetch is the first cycle
+			cycles := len(instruction.steps) + 1
+			switch {
+			case instruction.addressMode == rel || instruction.addressMode == zpr:
+				cycles -= 2
+			case instruction.pageCrossOnly && (instruction.addressMode == abx || instruction.addressMode == aby || instruction.addressMode == izy):
+				cycles--
+			}
+
+			assert.Equal(t, int(instruction.cycles), cycles, "incorrect cycles for opcode $%02X of variant %d", opcode, variant)
+		}
+	}
+}
+
+func TestMos6502_cmosLookup(t *testing.T) {
+	unofficial := map[string]bool{
+		alr: true, anc: true, arr: true, axs: true, dcp: true, isc: true, kil: true, las: true, lax: true,
+		rla: true, rra: true, sax: true, sha: true, shx: true, shy: true, slo: true, sre: true, tas: true, xaa: true,
+	}
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name     string
+		variant  Variant
+		expected map[byte]string
 	}{
 		{
-			name: "indirect assignment of page 0x00 address when y equals 0",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				y:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x03).
-					write(0x0001, 0x01).
-					write(0x0002, 0x00).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0001,
-				y:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x03).
-					write(0x0001, 0x01).
-					write(0x0002, 0x00).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
-			},
-			expectedAdditionalCycles: 0,
-		},
-		{
-			name: "indirect assignment of page 0x00 address when y equals 0",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				y:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x03).
-					write(0x0001, 0x01).
-					write(0x0002, 0x00).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0000,
-			},
-			expectedState: &Mos6502{
-				pc: 0x0001,
-				y:  0x00,
-				bus: newBusBuilder().
-					write(0x0000, 0x03).
-					write(0x0001, 0x01).
-					write(0x0002, 0x00).
-					write(0x0003, 0x20).
-					write(0x0004, 0x04).
-					build(),
-				addressAbsolute: 0x0420,
+			name:    "65C02",
+			variant: WDC65C02,
+			expected: map[byte]string{
+				0x07: nop, 0x0f: nop, 0x12: ora, 0x1a: inc, 0x64: stz, 0x7c: jmp, 0x80: bra, 0xcb: nop, 0xda: phx, 0xff: nop,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
-			name: "indirect assignment of y shifted address with page change",
-			initialState: &Mos6502{
-				pc: 0x0000,
-				y:  0xff,
-				bus: newBusBuilder().
-					write(0x0000, 0x03).
-					write(0x0001, 0x01).
-					write(0x0002, 0x00).
-					write(0x0003, 0x10).
-					write(0x0004, 0x00).
-					build(),
-				addressAbsolute: 0x0000,
+			name:    "R65C02",
+			variant: R65C02,
+			expected: map[byte]string{
+				0x07: "RMB0", 0x0f: "BBR0", 0x12: ora, 0x77: "RMB7", 0x87: "SMB0", 0x8f: "BBS0", 0xcb: nop, 0xff: "BBS7",
 			},
-			expectedState: &Mos6502{
-				pc: 0x0001,
-				y:  0xff,
-				bus: newBusBuilder().
-					write(0x0000, 0x03).
-					write(0x0001, 0x01).
-					write(0x0002, 0x00).
-					write(0x0003, 0x10).
-					write(0x0004, 0x00).
-					build(),
-				addressAbsolute: 0x010f,
-			},
-			expectedAdditionalCycles: 1,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			cpu := tc.initialState
-			additionalCycles := cpu.izy()
-
-			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
+			cpu := NewMos6502(tc.variant)
+			for opcode, instruction := range cpu.lookup {
+				assert.False(t, unofficial[instruction.operation], "unofficial operation at opcode $%02X", opcode)
+			}
+			for opcode, operation := range tc.expected {
+				assert.Equal(t, operation, cpu.lookup[opcode].operation, "incorrect operation for opcode $%02X", opcode)
+			}
 		})
 	}
 }
@@ -1484,12 +1705,11 @@ func TestMos6502_adc(t *testing.T) {
 		initialAvalue uint8
 		initialCflag  bool
 
-		expectedAvalue           uint8
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedVflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue uint8
+		expectedCflag  uint8
+		expectedZflag  uint8
+		expectedVflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "0+0=0 sets Z=true",
@@ -1498,12 +1718,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x00,
 			initialCflag:  false,
 
-			expectedAvalue:           0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedCflag:  0,
+			expectedZflag:  1,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "x+(-x)=0 C=false sets Z=true",
@@ -1512,12 +1731,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x7f,
 			initialCflag:  false,
 
-			expectedAvalue:           0x00,
-			expectedCflag:            1,
-			expectedZflag:            1,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedCflag:  1,
+			expectedZflag:  1,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "x+(-x)+1=0 C=true sets Z=true",
@@ -1526,12 +1744,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x7f,
 			initialCflag:  true,
 
-			expectedAvalue:           0x00,
-			expectedCflag:            1,
-			expectedZflag:            1,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedCflag:  1,
+			expectedZflag:  1,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "P+P=P C=false no overflow",
@@ -1540,12 +1757,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x01,
 			initialCflag:  false,
 
-			expectedAvalue:           0x02,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x02,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "P+P=P C=true no overflow",
@@ -1554,12 +1770,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x01,
 			initialCflag:  true,
 
-			expectedAvalue:           0x03,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x03,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "N+N=N C=false no overflow",
@@ -1568,12 +1783,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  false,
 
-			expectedAvalue:           0xfe,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0xfe,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "N+N=N C=true no overflow",
@@ -1582,12 +1796,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  true,
 
-			expectedAvalue:           0xff,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0xff,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "P+P=N C=false causes overflow",
@@ -1596,12 +1809,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x01,
 			initialCflag:  false,
 
-			expectedAvalue:           0x80,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  1,
 		},
 		{
 			name: "P+P=P C=true causes overflow",
@@ -1610,12 +1822,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x01,
 			initialCflag:  true,
 
-			expectedAvalue:           0x80,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  1,
 		},
 		{
 			name: "N+N=P C=false causes overflow",
@@ -1624,12 +1835,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x81,
 			initialCflag:  false,
 
-			expectedAvalue:           0x02,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x02,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "N+N=P C=true causes overflow",
@@ -1638,12 +1848,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x80,
 			initialCflag:  true,
 
-			expectedAvalue:           0x01,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x01,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "P+N=P C=false cannot overflow",
@@ -1652,12 +1861,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  false,
 
-			expectedAvalue:           0x7e,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x7e,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "P+N=P C=true cannot overflow",
@@ -1666,12 +1874,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  true,
 
-			expectedAvalue:           0x7f,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x7f,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "P+N=N C=false cannot overflow",
@@ -1680,12 +1887,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x80,
 			initialCflag:  false,
 
-			expectedAvalue:           0x81,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x81,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "P+N=N C=true cannot overflow",
@@ -1694,12 +1900,11 @@ func TestMos6502_adc(t *testing.T) {
 			initialAvalue: 0x81,
 			initialCflag:  true,
 
-			expectedAvalue:           0x83,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x83,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 	}
 	for _, tc := range testCases {
@@ -1710,22 +1915,20 @@ func TestMos6502_adc(t *testing.T) {
 			}
 			cpu.lookup = mos6502LookupTable{
 				{
-					operation:      adc,
-					addressMode:    "TST",
-					performOp:      cpu.adc,
-					setAddressMode: func() uint8 { return 0 },
+					operation:   adc,
+					addressMode: "TST",
+					performOp:   cpu.adc,
 				},
 			}
 			cpu.setStatusFlag(C, tc.initialCflag)
 
-			additionalCycles := cpu.adc()
+			cpu.adc()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedVflag, cpu.GetStatusFlag(V), "incorrect V flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -1737,40 +1940,36 @@ func TestMos6502_and(t *testing.T) {
 		aValue    uint8
 		dataValue uint8
 
-		expectedAvalue           uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue uint8
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name:      "and operation performed correctly",
 			aValue:    0b00100101,
 			dataValue: 0b00100001,
 
-			expectedAvalue:           0b00100001,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0b00100001,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name:      "and operation results in 0 sets Z true",
 			aValue:    0b00100101,
 			dataValue: 0b10000010,
 
-			expectedAvalue:           0b00000000,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0b00000000,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name:      "and operation results in negative result sets N true",
 			aValue:    0b10100101,
 			dataValue: 0b10000100,
 
-			expectedAvalue:           0b10000100,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0b10000100,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 	for _, tc := range testCases {
@@ -1780,12 +1979,11 @@ func TestMos6502_and(t *testing.T) {
 				addressAbsolute: 0x0000,
 				bus:             bus.NewBus(bus.RAM{tc.dataValue}),
 			}
-			additionalCycles := cpu.and()
+			cpu.and()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
@@ -1797,12 +1995,11 @@ func TestMos6502_asl(t *testing.T) {
 		dataValue   uint8
 		instruction instruction
 
-		expectedAvalue           uint8
-		expectedBusValue         uint8
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue   uint8
+		expectedBusValue uint8
+		expectedCflag    uint8
+		expectedZflag    uint8
+		expectedNflag    uint8
 	}{
 		{
 			name: "asl operation in implied mode performed correctly",
@@ -1813,12 +2010,11 @@ func TestMos6502_asl(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0b00000010,
-			expectedBusValue:         0b00000000,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0b00000010,
+			expectedBusValue: 0b00000000,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "asl operation in non-implied mode performed correctly",
@@ -1829,12 +2025,11 @@ func TestMos6502_asl(t *testing.T) {
 				addressMode: "TST",
 			},
 
-			expectedAvalue:           0b00000000,
-			expectedBusValue:         0b00000010,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0b00000000,
+			expectedBusValue: 0b00000010,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "asl operation resulting in 0 sets Z true",
@@ -1845,12 +2040,11 @@ func TestMos6502_asl(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0b00000000,
-			expectedBusValue:         0b00000000,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0b00000000,
+			expectedBusValue: 0b00000000,
+			expectedCflag:    0,
+			expectedZflag:    1,
+			expectedNflag:    0,
 		},
 		{
 			name: "asl operation resulting in negative result sets N true",
@@ -1861,12 +2055,11 @@ func TestMos6502_asl(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0b10000100,
-			expectedBusValue:         0b00000000,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0b10000100,
+			expectedBusValue: 0b00000000,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    1,
 		},
 		{
 			name: "asl operation resulting in carry sets C true",
@@ -1877,12 +2070,11 @@ func TestMos6502_asl(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0b00000010,
-			expectedBusValue:         0b00000000,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0b00000010,
+			expectedBusValue: 0b00000000,
+			expectedCflag:    1,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 	}
 	for _, tc := range testCases {
@@ -1897,36 +2089,31 @@ func TestMos6502_asl(t *testing.T) {
 			} else {
 				cpu.write(cpu.addressAbsolute, tc.dataValue)
 			}
-			additionalCycles := cpu.asl()
+			cpu.asl()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedBusValue, cpu.read(cpu.addressAbsolute), "incorrect Bus value")
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles value")
 		})
 	}
 }
 
 func TestMos6502_bcc(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "C=true nothing happens",
 			initialState: &Mos6502{
 				status: 0b00000001,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b00000001,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "C=false assigns pc correctly",
@@ -1934,15 +2121,13 @@ func TestMos6502_bcc(t *testing.T) {
 				status:          0b11111110,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b11111110,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "C=false relative addressing assigns pc correctly",
@@ -1951,16 +2136,14 @@ func TestMos6502_bcc(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b11111110,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "C=false page change causes extra cycle",
@@ -1969,47 +2152,40 @@ func TestMos6502_bcc(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b11111110,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bcc()
+			cpu.bcc()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
 
 func TestMos6502_bcs(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "C=false nothing happens",
 			initialState: &Mos6502{
 				status: 0b11111110,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b11111110,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "C=true assigns pc correctly",
@@ -2017,15 +2193,13 @@ func TestMos6502_bcs(t *testing.T) {
 				status:          0b00000001,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b00000001,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "C=true relative addressing assigns pc correctly",
@@ -2034,16 +2208,14 @@ func TestMos6502_bcs(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b00000001,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "C=true page change causes extra cycle",
@@ -2052,47 +2224,40 @@ func TestMos6502_bcs(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b00000001,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bcs()
+			cpu.bcs()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
 
 func TestMos6502_beq(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "Z=false nothing happens",
 			initialState: &Mos6502{
 				status: 0b11111101,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b11111101,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "Z=true assigns pc correctly",
@@ -2100,15 +2265,13 @@ func TestMos6502_beq(t *testing.T) {
 				status:          0b00000010,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b00000010,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "Z=true relative addressing assigns pc correctly",
@@ -2117,16 +2280,14 @@ func TestMos6502_beq(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b00000010,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "Z=true page change causes extra cycle",
@@ -2135,37 +2296,33 @@ func TestMos6502_beq(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b00000010,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.beq()
+			cpu.beq()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles)
 		})
 	}
 }
 
 func TestMos6502_bit(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedVflag            uint8
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedZflag uint8
+		expectedNflag uint8
+		expectedVflag uint8
 	}{
 		{
 			name: "nothing gets set",
@@ -2175,10 +2332,9 @@ func TestMos6502_bit(t *testing.T) {
 					0x11,
 				}),
 			},
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedVflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedZflag: 0,
+			expectedNflag: 0,
+			expectedVflag: 0,
 		},
 		{
 			name: "result of mask is zero",
@@ -2188,10 +2344,9 @@ func TestMos6502_bit(t *testing.T) {
 					0x00,
 				}),
 			},
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedVflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedZflag: 1,
+			expectedNflag: 0,
+			expectedVflag: 0,
 		},
 		{
 			name: "addressed data is negative",
@@ -2201,10 +2356,9 @@ func TestMos6502_bit(t *testing.T) {
 					0x80,
 				}),
 			},
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedVflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedZflag: 0,
+			expectedNflag: 1,
+			expectedVflag: 0,
 		},
 		{
 			name: "addressed data is overflow value",
@@ -2214,43 +2368,37 @@ func TestMos6502_bit(t *testing.T) {
 					0x7f,
 				}),
 			},
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedVflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedZflag: 0,
+			expectedNflag: 0,
+			expectedVflag: 1,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bit()
+			cpu.bit()
 
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
 			assert.Equal(t, tc.expectedVflag, cpu.GetStatusFlag(V), "incorrect V flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_bmi(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "N=false nothing happens",
 			initialState: &Mos6502{
 				status: 0b01111111,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b01111111,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "N=true assigns pc correctly",
@@ -2258,15 +2406,13 @@ func TestMos6502_bmi(t *testing.T) {
 				status:          0b10000000,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b10000000,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "N=true relative addressing assigns pc correctly",
@@ -2275,16 +2421,14 @@ func TestMos6502_bmi(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b10000000,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "N=true page change causes extra cycle",
@@ -2293,47 +2437,40 @@ func TestMos6502_bmi(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b10000000,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bmi()
+			cpu.bmi()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_bne(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "Z=true nothing happens",
 			initialState: &Mos6502{
 				status: 0b00000010,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b00000010,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "Z=false assigns pc correctly",
@@ -2341,15 +2478,13 @@ func TestMos6502_bne(t *testing.T) {
 				status:          0b11111101,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b11111101,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "Z=false relative addressing assigns pc correctly",
@@ -2358,16 +2493,14 @@ func TestMos6502_bne(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b11111101,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "Z=false page change causes extra cycle",
@@ -2376,47 +2509,40 @@ func TestMos6502_bne(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b11111101,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bne()
+			cpu.bne()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_bpl(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "N=true nothing happens",
 			initialState: &Mos6502{
 				status: 0b10000000,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b10000000,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "N=false assigns pc correctly",
@@ -2424,15 +2550,13 @@ func TestMos6502_bpl(t *testing.T) {
 				status:          0b01111111,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b01111111,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "N=false relative addressing assigns pc correctly",
@@ -2441,16 +2565,14 @@ func TestMos6502_bpl(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b01111111,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "N=false page change causes extra cycle",
@@ -2459,91 +2581,79 @@ func TestMos6502_bpl(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b01111111,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bpl()
+			cpu.bpl()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_brk(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
-			name: "break is performed correctly",
+			name: "padding byte is read and skipped before the interrupt sequence",
 			initialState: &Mos6502{
 				pc:     0x11fe,
 				stkp:   0x42,
 				status: 0b11101011,
 				bus: newBusBuilder().
-					write(0xfffe, 0x20).
-					write(0xffff, 0x04).
+					write(0x11fe, 0x42).
 					build(),
 			},
 			expectedState: &Mos6502{
-				pc:     0x0420,
-				status: 0b11101111,
-				stkp:   0x3f,
+				pc:       0x11ff,
+				stkp:     0x42,
+				status:   0b11101011,
+				vector:   0xfffe,
+				breaking: true,
 				bus: newBusBuilder().
-					write(0x0140, 0b11111111).
-					write(0x0141, 0xff).
-					write(0x0142, 0x11).
-					write(0xfffe, 0x20).
-					write(0xffff, 0x04).
+					write(0x11fe, 0x42).
+					read(0x11fe).
 					build(),
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.brk()
+			cpu.brk()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_bvc(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "V=true nothing happens",
 			initialState: &Mos6502{
 				status: 0b01000000,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b01000000,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "V=false assigns pc correctly",
@@ -2551,15 +2661,13 @@ func TestMos6502_bvc(t *testing.T) {
 				status:          0b10111111,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b10111111,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "V=false relative addressing assigns pc correctly",
@@ -2568,16 +2676,14 @@ func TestMos6502_bvc(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b10111111,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "V=false page change causes extra cycle",
@@ -2586,47 +2692,40 @@ func TestMos6502_bvc(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b10111111,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bvc()
+			cpu.bvc()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_bvs(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
 			name: "V=false nothing happens",
 			initialState: &Mos6502{
 				status: 0b10111111,
-				cycles: 2,
 			},
 			expectedState: &Mos6502{
 				status: 0b10111111,
-				cycles: 2,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "V=true assigns pc correctly",
@@ -2634,15 +2733,13 @@ func TestMos6502_bvs(t *testing.T) {
 				status:          0b01000000,
 				addressAbsolute: 0x00000,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b01000000,
 				addressAbsolute: 0x0011,
 				pc:              0x0011,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "V=true relative addressing assigns pc correctly",
@@ -2651,16 +2748,14 @@ func TestMos6502_bvs(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x0011,
 				pc:              0x0011,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b01000000,
 				addressAbsolute: 0x00022,
 				addressRelative: 0x0011,
 				pc:              0x0022,
-				cycles:          3,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "V=true page change causes extra cycle",
@@ -2669,47 +2764,41 @@ func TestMos6502_bvs(t *testing.T) {
 				addressAbsolute: 0x0000,
 				addressRelative: 0x1100,
 				pc:              0x1111,
-				cycles:          2,
 			},
 			expectedState: &Mos6502{
 				status:          0b01000000,
 				addressAbsolute: 0x2211,
 				addressRelative: 0x1100,
 				pc:              0x2211,
-				cycles:          4,
+				branching:       true,
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.bvs()
+			cpu.bvs()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_clc(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialCflag             bool
-		expectedCflag            uint8
-		expectedAdditionalCycles uint8
+		name          string
+		initialCflag  bool
+		expectedCflag uint8
 	}{
 		{
-			name:                     "sets C=true to false",
-			initialCflag:             true,
-			expectedCflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets C=true to false",
+			initialCflag:  true,
+			expectedCflag: 0,
 		},
 		{
-			name:                     "sets C=false to false",
-			initialCflag:             false,
-			expectedCflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets C=false to false",
+			initialCflag:  false,
+			expectedCflag: 0,
 		},
 	}
 
@@ -2717,32 +2806,28 @@ func TestMos6502_clc(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.setStatusFlag(C, tc.initialCflag)
-			additionalCycles := cpu.clc()
+			cpu.clc()
 
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_cld(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialDflag             bool
-		expectedDflag            uint8
-		expectedAdditionalCycles uint8
+		name          string
+		initialDflag  bool
+		expectedDflag uint8
 	}{
 		{
-			name:                     "sets D=true to false",
-			initialDflag:             true,
-			expectedDflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets D=true to false",
+			initialDflag:  true,
+			expectedDflag: 0,
 		},
 		{
-			name:                     "sets D=false to false",
-			initialDflag:             false,
-			expectedDflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets D=false to false",
+			initialDflag:  false,
+			expectedDflag: 0,
 		},
 	}
 
@@ -2750,32 +2835,28 @@ func TestMos6502_cld(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.setStatusFlag(D, tc.initialDflag)
-			additionalCycles := cpu.cld()
+			cpu.cld()
 
 			assert.Equal(t, tc.expectedDflag, cpu.GetStatusFlag(D), "incorrect D flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_cli(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialIflag             bool
-		expectedIflag            uint8
-		expectedAdditionalCycles uint8
+		name          string
+		initialIflag  bool
+		expectedIflag uint8
 	}{
 		{
-			name:                     "sets I=true to false",
-			initialIflag:             true,
-			expectedIflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets I=true to false",
+			initialIflag:  true,
+			expectedIflag: 0,
 		},
 		{
-			name:                     "sets I=false to false",
-			initialIflag:             false,
-			expectedIflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets I=false to false",
+			initialIflag:  false,
+			expectedIflag: 0,
 		},
 	}
 
@@ -2783,32 +2864,28 @@ func TestMos6502_cli(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.setStatusFlag(I, tc.initialIflag)
-			additionalCycles := cpu.cli()
+			cpu.cli()
 
 			assert.Equal(t, tc.expectedIflag, cpu.GetStatusFlag(I), "incorrect I flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_clv(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialVflag             bool
-		expectedVflag            uint8
-		expectedAdditionalCycles uint8
+		name          string
+		initialVflag  bool
+		expectedVflag uint8
 	}{
 		{
-			name:                     "sets V=true to false",
-			initialVflag:             true,
-			expectedVflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets V=true to false",
+			initialVflag:  true,
+			expectedVflag: 0,
 		},
 		{
-			name:                     "sets V=false to false",
-			initialVflag:             false,
-			expectedVflag:            0,
-			expectedAdditionalCycles: 0,
+			name:          "sets V=false to false",
+			initialVflag:  false,
+			expectedVflag: 0,
 		},
 	}
 
@@ -2816,10 +2893,9 @@ func TestMos6502_clv(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.setStatusFlag(V, tc.initialVflag)
-			additionalCycles := cpu.clv()
+			cpu.clv()
 
 			assert.Equal(t, tc.expectedVflag, cpu.GetStatusFlag(V), "incorrect V flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -2831,10 +2907,9 @@ func TestMos6502_cmp(t *testing.T) {
 		aValue  byte
 		busData byte
 
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedCflag uint8
+		expectedZflag uint8
+		expectedNflag uint8
 	}{
 		{
 			name: "A=M results in Z=true",
@@ -2842,10 +2917,9 @@ func TestMos6502_cmp(t *testing.T) {
 			aValue:  0x42,
 			busData: 0x42,
 
-			expectedCflag:            1,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedCflag: 1,
+			expectedNflag: 0,
+			expectedZflag: 1,
 		},
 		{
 			name: "A>=M results in C=true",
@@ -2853,10 +2927,9 @@ func TestMos6502_cmp(t *testing.T) {
 			aValue:  0x42,
 			busData: 0x01,
 
-			expectedCflag:            1,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedCflag: 1,
+			expectedNflag: 0,
+			expectedZflag: 0,
 		},
 		{
 			name: "A<=M results in N=true",
@@ -2864,10 +2937,9 @@ func TestMos6502_cmp(t *testing.T) {
 			aValue:  0x01,
 			busData: 0x42,
 
-			expectedCflag:            0,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedCflag: 0,
+			expectedNflag: 1,
+			expectedZflag: 0,
 		},
 	}
 
@@ -2876,12 +2948,11 @@ func TestMos6502_cmp(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.a = tc.aValue
 			cpu.write(0x0000, tc.busData)
-			additionalCycles := cpu.cmp()
+			cpu.cmp()
 
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -2893,10 +2964,9 @@ func TestMos6502_cpx(t *testing.T) {
 		xValue  byte
 		busData byte
 
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedCflag uint8
+		expectedZflag uint8
+		expectedNflag uint8
 	}{
 		{
 			name: "X=M results in Z=true",
@@ -2904,10 +2974,9 @@ func TestMos6502_cpx(t *testing.T) {
 			xValue:  0x42,
 			busData: 0x42,
 
-			expectedCflag:            1,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 1,
+			expectedNflag: 0,
+			expectedZflag: 1,
 		},
 		{
 			name: "X>=M results in C=true",
@@ -2915,10 +2984,9 @@ func TestMos6502_cpx(t *testing.T) {
 			xValue:  0x42,
 			busData: 0x01,
 
-			expectedCflag:            1,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 1,
+			expectedNflag: 0,
+			expectedZflag: 0,
 		},
 		{
 			name: "X<=M results in N=true",
@@ -2926,10 +2994,9 @@ func TestMos6502_cpx(t *testing.T) {
 			xValue:  0x01,
 			busData: 0x42,
 
-			expectedCflag:            0,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 0,
+			expectedNflag: 1,
+			expectedZflag: 0,
 		},
 	}
 
@@ -2938,12 +3005,11 @@ func TestMos6502_cpx(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.x = tc.xValue
 			cpu.write(0x0000, tc.busData)
-			additionalCycles := cpu.cpx()
+			cpu.cpx()
 
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -2955,10 +3021,9 @@ func TestMos6502_cpy(t *testing.T) {
 		yValue  byte
 		busData byte
 
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedCflag uint8
+		expectedZflag uint8
+		expectedNflag uint8
 	}{
 		{
 			name: "Y=M results in Z=true",
@@ -2966,10 +3031,9 @@ func TestMos6502_cpy(t *testing.T) {
 			yValue:  0x42,
 			busData: 0x42,
 
-			expectedCflag:            1,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 1,
+			expectedNflag: 0,
+			expectedZflag: 1,
 		},
 		{
 			name: "Y>=M results in C=true",
@@ -2977,10 +3041,9 @@ func TestMos6502_cpy(t *testing.T) {
 			yValue:  0x42,
 			busData: 0x01,
 
-			expectedCflag:            1,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 1,
+			expectedNflag: 0,
+			expectedZflag: 0,
 		},
 		{
 			name: "Y<=M results in N=true",
@@ -2988,10 +3051,9 @@ func TestMos6502_cpy(t *testing.T) {
 			yValue:  0x01,
 			busData: 0x42,
 
-			expectedCflag:            0,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 0,
+			expectedNflag: 1,
+			expectedZflag: 0,
 		},
 	}
 
@@ -3000,12 +3062,11 @@ func TestMos6502_cpy(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.y = tc.yValue
 			cpu.write(0x0000, tc.busData)
-			additionalCycles := cpu.cpy()
+			cpu.cpy()
 
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3017,10 +3078,9 @@ func TestMos6502_dec(t *testing.T) {
 		address      word
 		initialValue byte
 
-		expectedValue            byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedValue byte
+		expectedZflag uint8
+		expectedNflag uint8
 	}{
 		{
 			name: "value is decremented",
@@ -3028,10 +3088,9 @@ func TestMos6502_dec(t *testing.T) {
 			address:      0x0420,
 			initialValue: 0x42,
 
-			expectedValue:            0x41,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedValue: 0x41,
+			expectedNflag: 0,
+			expectedZflag: 0,
 		},
 		{
 			name: "decrement resulting in 0 sets Z=true",
@@ -3039,10 +3098,9 @@ func TestMos6502_dec(t *testing.T) {
 			address:      0x0420,
 			initialValue: 0x01,
 
-			expectedValue:            0x00,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedValue: 0x00,
+			expectedNflag: 0,
+			expectedZflag: 1,
 		},
 		{
 			name: "decrement resulting in -1 sets N=true",
@@ -3050,10 +3108,9 @@ func TestMos6502_dec(t *testing.T) {
 			address:      0x0420,
 			initialValue: 0x00,
 
-			expectedValue:            0xff,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedValue: 0xff,
+			expectedNflag: 1,
+			expectedZflag: 0,
 		},
 	}
 
@@ -3062,12 +3119,11 @@ func TestMos6502_dec(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.addressAbsolute = tc.address
 			cpu.write(cpu.addressAbsolute, tc.initialValue)
-			additionalCycles := cpu.dec()
+			cpu.dec()
 
 			assert.Equal(t, tc.expectedValue, cpu.read(tc.address), "incorrect value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3078,40 +3134,36 @@ func TestMos6502_dex(t *testing.T) {
 
 		initialXvalue byte
 
-		expectedXvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedXvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "value is decremented",
 
 			initialXvalue: 0x42,
 
-			expectedXvalue:           0x41,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x41,
+			expectedNflag:  0,
+			expectedZflag:  0,
 		},
 		{
 			name: "decrement resulting in 0 sets Z=true",
 
 			initialXvalue: 0x01,
 
-			expectedXvalue:           0x00,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x00,
+			expectedNflag:  0,
+			expectedZflag:  1,
 		},
 		{
 			name: "decrement resulting in -1 sets N=true",
 
 			initialXvalue: 0x00,
 
-			expectedXvalue:           0xff,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0xff,
+			expectedNflag:  1,
+			expectedZflag:  0,
 		},
 	}
 
@@ -3119,12 +3171,11 @@ func TestMos6502_dex(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.x = tc.initialXvalue
-			additionalCycles := cpu.dex()
+			cpu.dex()
 
 			assert.Equal(t, tc.expectedXvalue, cpu.x, "incorrect X value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3135,40 +3186,36 @@ func TestMos6502_dey(t *testing.T) {
 
 		initialYvalue byte
 
-		expectedYvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedYvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "value is decremented",
 
 			initialYvalue: 0x42,
 
-			expectedYvalue:           0x41,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x41,
+			expectedNflag:  0,
+			expectedZflag:  0,
 		},
 		{
 			name: "decrement resulting in 0 sets Z=true",
 
 			initialYvalue: 0x01,
 
-			expectedYvalue:           0x00,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x00,
+			expectedNflag:  0,
+			expectedZflag:  1,
 		},
 		{
 			name: "decrement resulting in -1 sets N=true",
 
 			initialYvalue: 0x00,
 
-			expectedYvalue:           0xff,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0xff,
+			expectedNflag:  1,
+			expectedZflag:  0,
 		},
 	}
 
@@ -3176,12 +3223,11 @@ func TestMos6502_dey(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.y = tc.initialYvalue
-			additionalCycles := cpu.dey()
+			cpu.dey()
 
 			assert.Equal(t, tc.expectedYvalue, cpu.y, "incorrect Y value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3194,10 +3240,9 @@ func TestMos6502_eor(t *testing.T) {
 		dataValue     byte
 		initialAvalue byte
 
-		expectedAvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "result is positive",
@@ -3206,10 +3251,9 @@ func TestMos6502_eor(t *testing.T) {
 			dataValue:     0b01010101,
 			initialAvalue: 0b00101011,
 
-			expectedAvalue:           0b01111110,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0b01111110,
+			expectedNflag:  0,
+			expectedZflag:  0,
 		},
 		{
 			name: "result is zero sets Z=true",
@@ -3218,10 +3262,9 @@ func TestMos6502_eor(t *testing.T) {
 			dataValue:     0b01010101,
 			initialAvalue: 0b01010101,
 
-			expectedAvalue:           0b00000000,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0b00000000,
+			expectedNflag:  0,
+			expectedZflag:  1,
 		},
 		{
 			name: "result is negative sets N=true",
@@ -3230,10 +3273,9 @@ func TestMos6502_eor(t *testing.T) {
 			dataValue:     0b11010101,
 			initialAvalue: 0b00101010,
 
-			expectedAvalue:           0b11111111,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0b11111111,
+			expectedNflag:  1,
+			expectedZflag:  0,
 		},
 	}
 
@@ -3243,12 +3285,11 @@ func TestMos6502_eor(t *testing.T) {
 			cpu.a = tc.initialAvalue
 			cpu.addressAbsolute = tc.address
 			cpu.write(cpu.addressAbsolute, tc.dataValue)
-			additionalCycles := cpu.eor()
+			cpu.eor()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3260,10 +3301,9 @@ func TestMos6502_inc(t *testing.T) {
 		address      word
 		initialValue byte
 
-		expectedValue            byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedValue byte
+		expectedZflag uint8
+		expectedNflag uint8
 	}{
 		{
 			name: "value is incremented",
@@ -3271,10 +3311,9 @@ func TestMos6502_inc(t *testing.T) {
 			address:      0x0420,
 			initialValue: 0x42,
 
-			expectedValue:            0x43,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedValue: 0x43,
+			expectedNflag: 0,
+			expectedZflag: 0,
 		},
 		{
 			name: "increment resulting in 0 sets Z=true",
@@ -3282,10 +3321,9 @@ func TestMos6502_inc(t *testing.T) {
 			address:      0x0420,
 			initialValue: 0xff,
 
-			expectedValue:            0x00,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedValue: 0x00,
+			expectedNflag: 0,
+			expectedZflag: 1,
 		},
 		{
 			name: "increment resulting in negative sets N=true",
@@ -3293,10 +3331,9 @@ func TestMos6502_inc(t *testing.T) {
 			address:      0x0420,
 			initialValue: 0xf0,
 
-			expectedValue:            0xf1,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedValue: 0xf1,
+			expectedNflag: 1,
+			expectedZflag: 0,
 		},
 	}
 
@@ -3305,12 +3342,11 @@ func TestMos6502_inc(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.addressAbsolute = tc.address
 			cpu.write(cpu.addressAbsolute, tc.initialValue)
-			additionalCycles := cpu.inc()
+			cpu.inc()
 
 			assert.Equal(t, tc.expectedValue, cpu.read(tc.address), "incorrect value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3321,40 +3357,36 @@ func TestMos6502_inx(t *testing.T) {
 
 		initialXvalue byte
 
-		expectedXvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedXvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "value is incremented",
 
 			initialXvalue: 0x42,
 
-			expectedXvalue:           0x43,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x43,
+			expectedNflag:  0,
+			expectedZflag:  0,
 		},
 		{
 			name: "increment resulting in 0 sets Z=true",
 
 			initialXvalue: 0xff,
 
-			expectedXvalue:           0x00,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x00,
+			expectedNflag:  0,
+			expectedZflag:  1,
 		},
 		{
 			name: "increment resulting in negative sets N=true",
 
 			initialXvalue: 0xf0,
 
-			expectedXvalue:           0xf1,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0xf1,
+			expectedNflag:  1,
+			expectedZflag:  0,
 		},
 	}
 
@@ -3362,12 +3394,11 @@ func TestMos6502_inx(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.x = tc.initialXvalue
-			additionalCycles := cpu.inx()
+			cpu.inx()
 
 			assert.Equal(t, tc.expectedXvalue, cpu.x, "incorrect X value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3378,40 +3409,36 @@ func TestMos6502_iny(t *testing.T) {
 
 		initialYvalue byte
 
-		expectedYvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedYvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "value is incremented",
 
 			initialYvalue: 0x42,
 
-			expectedYvalue:           0x43,
-			expectedNflag:            0,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x43,
+			expectedNflag:  0,
+			expectedZflag:  0,
 		},
 		{
 			name: "increment resulting in 0 sets Z=true",
 
 			initialYvalue: 0xff,
 
-			expectedYvalue:           0x00,
-			expectedNflag:            0,
-			expectedZflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x00,
+			expectedNflag:  0,
+			expectedZflag:  1,
 		},
 		{
 			name: "increment resulting in negative sets N=true",
 
 			initialYvalue: 0xf0,
 
-			expectedYvalue:           0xf1,
-			expectedNflag:            1,
-			expectedZflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0xf1,
+			expectedNflag:  1,
+			expectedZflag:  0,
 		},
 	}
 
@@ -3419,12 +3446,11 @@ func TestMos6502_iny(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.y = tc.initialYvalue
-			additionalCycles := cpu.iny()
+			cpu.iny()
 
 			assert.Equal(t, tc.expectedYvalue, cpu.y, "incorrect Y value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3435,16 +3461,14 @@ func TestMos6502_jmp(t *testing.T) {
 
 		address word
 
-		expectedPC               word
-		expectedAdditionalCycles uint8
+		expectedPC word
 	}{
 		{
 			name: "program counter assigned correct address",
 
 			address: 0x0420,
 
-			expectedPC:               0x0420,
-			expectedAdditionalCycles: 0,
+			expectedPC: 0x0420,
 		},
 	}
 
@@ -3452,49 +3476,47 @@ func TestMos6502_jmp(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.addressAbsolute = tc.address
-			additionalCycles := cpu.jmp()
+			cpu.jmp()
 
 			assert.Equal(t, tc.expectedPC, cpu.pc, "incorrect PC value")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
 func TestMos6502_jsr(t *testing.T) {
 	testCases := []struct {
-		name                     string
-		initialState             *Mos6502
-		expectedState            *Mos6502
-		expectedAdditionalCycles uint8
+		name          string
+		initialState  *Mos6502
+		expectedState *Mos6502
 	}{
 		{
-			name: "program counter written to stack and then assigned with correct address",
+			name: "high byte of address read and program counter assigned with it",
 			initialState: &Mos6502{
 				pc:              0x1200,
-				stkp:            0x42,
-				addressAbsolute: 0x0420,
-				bus:             newBusBuilder().build(),
+				stkp:            0x40,
+				addressAbsolute: 0x0020,
+				bus: newBusBuilder().
+					write(0x1200, 0x04).
+					build(),
 			},
 			expectedState: &Mos6502{
 				pc:              0x0420,
 				stkp:            0x40,
 				addressAbsolute: 0x0420,
 				bus: newBusBuilder().
-					write(0x0141, 0xff).
-					write(0x0142, 0x11).
+					write(0x1200, 0x04).
+					read(0x1200).
 					build(),
 			},
-			expectedAdditionalCycles: 0,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := tc.initialState
-			additionalCycles := cpu.jsr()
+			cpu.jsr()
 
 			assert.Equal(t, tc.expectedState, cpu)
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3505,40 +3527,36 @@ func TestMos6502_lda(t *testing.T) {
 
 		busData byte
 
-		expectedAvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "accumulator assigned correct value",
 
 			busData: 0x42,
 
-			expectedAvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "accumulator assigned zero value and Z set true",
 
 			busData: 0x00,
 
-			expectedAvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "accumulator assigned negative value and N set true",
 
 			busData: 0x80,
 
-			expectedAvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -3547,12 +3565,11 @@ func TestMos6502_lda(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.addressAbsolute = 0x0000
 			cpu.bus = newBusBuilder().write(cpu.addressAbsolute, tc.busData).build()
-			additionalCycles := cpu.lda()
+			cpu.lda()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3563,40 +3580,36 @@ func TestMos6502_ldx(t *testing.T) {
 
 		busData byte
 
-		expectedXvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedXvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "x register assigned correct value",
 
 			busData: 0x42,
 
-			expectedXvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedXvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "x register assigned zero value and Z set true",
 
 			busData: 0x00,
 
-			expectedXvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedXvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "x register assigned negative value and N set true",
 
 			busData: 0x80,
 
-			expectedXvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedXvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -3605,12 +3618,11 @@ func TestMos6502_ldx(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.addressAbsolute = 0x0000
 			cpu.bus = newBusBuilder().write(cpu.addressAbsolute, tc.busData).build()
-			additionalCycles := cpu.ldx()
+			cpu.ldx()
 
 			assert.Equal(t, tc.expectedXvalue, cpu.x, "incorrect X value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3621,40 +3633,36 @@ func TestMos6502_ldy(t *testing.T) {
 
 		busData byte
 
-		expectedYvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedYvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "y register assigned correct value",
 
 			busData: 0x42,
 
-			expectedYvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedYvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "y register assigned zero value and Z set true",
 
 			busData: 0x00,
 
-			expectedYvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedYvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "y register assigned negative value and N set true",
 
 			busData: 0x80,
 
-			expectedYvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedYvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -3663,12 +3671,11 @@ func TestMos6502_ldy(t *testing.T) {
 			cpu := newTestMos6502()
 			cpu.addressAbsolute = 0x0000
 			cpu.bus = newBusBuilder().write(cpu.addressAbsolute, tc.busData).build()
-			additionalCycles := cpu.ldy()
+			cpu.ldy()
 
 			assert.Equal(t, tc.expectedYvalue, cpu.y, "incorrect Y value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3680,12 +3687,11 @@ func TestMos6502_lsr(t *testing.T) {
 		dataValue   uint8
 		instruction instruction
 
-		expectedAvalue           uint8
-		expectedBusValue         uint8
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue   uint8
+		expectedBusValue uint8
+		expectedCflag    uint8
+		expectedZflag    uint8
+		expectedNflag    uint8
 	}{
 		{
 			name: "lsr operation in implied mode performed correctly",
@@ -3696,12 +3702,11 @@ func TestMos6502_lsr(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x21,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x21,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "lsr operation in non-implied mode performed correctly",
@@ -3712,12 +3717,11 @@ func TestMos6502_lsr(t *testing.T) {
 				addressMode: "TST",
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x21,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x21,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "lsr operation resulting in 0 sets Z true",
@@ -3728,28 +3732,26 @@ func TestMos6502_lsr(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    1,
+			expectedNflag:    0,
 		},
 		{
-			name: "lsr operation resulting in negative result sets N and C true",
+			name: "lsr operation shifting out bit 0 sets C true and clears bit 7",
 
-			dataValue: 0x05,
+			dataValue: 0x85,
 			instruction: instruction{
 				operation:   lsr,
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x82,
-			expectedBusValue:         0x00,
-			expectedCflag:            1,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x42,
+			expectedBusValue: 0x00,
+			expectedCflag:    1,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 	}
 	for _, tc := range testCases {
@@ -3764,14 +3766,13 @@ func TestMos6502_lsr(t *testing.T) {
 			} else {
 				cpu.write(cpu.addressAbsolute, tc.dataValue)
 			}
-			additionalCycles := cpu.lsr()
+			cpu.lsr()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedBusValue, cpu.read(cpu.addressAbsolute), "incorrect Bus value")
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles value")
 		})
 	}
 }
@@ -3802,22 +3803,16 @@ func TestMos6502_nop(t *testing.T) {
 		name string
 
 		opcodes []byte
-
-		expectedAdditionalCycles uint8
 	}{
 		{
 			name: "opcodes requiring no additional cycls",
 
 			opcodes: noAdditionalCycleOpcodes,
-
-			expectedAdditionalCycles: 0,
 		},
 		{
 			name: "opcodes requiring 1 additional cycle",
 
 			opcodes: additionalCycleOpcodes,
-
-			expectedAdditionalCycles: 1,
 		},
 	}
 
@@ -3826,9 +3821,8 @@ func TestMos6502_nop(t *testing.T) {
 			for _, opcode := range tc.opcodes {
 				cpu := newTestMos6502()
 				cpu.opcode = opcode
-				additionalCycles := cpu.nop()
+				cpu.nop()
 
-				assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 			}
 		})
 	}
@@ -3841,40 +3835,36 @@ func TestMos6502_ora(t *testing.T) {
 		aValue    uint8
 		dataValue uint8
 
-		expectedAvalue           uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue uint8
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name:      "ora operation performed correctly",
 			aValue:    0b01010101,
 			dataValue: 0b00101010,
 
-			expectedAvalue:           0b01111111,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0b01111111,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name:      "ora operation results in 0 sets Z true",
 			aValue:    0b00000000,
 			dataValue: 0b00000000,
 
-			expectedAvalue:           0b00000000,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0b00000000,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name:      "ora operation results in negative result sets N true",
 			aValue:    0b10000000,
 			dataValue: 0b01111111,
 
-			expectedAvalue:           0b11111111,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0b11111111,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 	for _, tc := range testCases {
@@ -3884,12 +3874,11 @@ func TestMos6502_ora(t *testing.T) {
 				addressAbsolute: 0x0000,
 				bus:             bus.NewBus(bus.RAM{tc.dataValue}),
 			}
-			additionalCycles := cpu.ora()
+			cpu.ora()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3901,18 +3890,16 @@ func TestMos6502_pha(t *testing.T) {
 		aValue           uint8
 		initialStkpValue uint8
 
-		expectedStkpValue        uint8
-		expectedBus              *bus.Bus
-		expectedAdditionalCycles uint8
+		expectedStkpValue uint8
+		expectedBus       *bus.Bus
 	}{
 		{
 			name:             "pha operation performed correctly",
 			aValue:           0x42,
 			initialStkpValue: 0x11,
 
-			expectedStkpValue:        0x10,
-			expectedBus:              newBusBuilder().write(0x0111, 0x42).build(),
-			expectedAdditionalCycles: 0,
+			expectedStkpValue: 0x10,
+			expectedBus:       newBusBuilder().write(0x0111, 0x42).build(),
 		},
 	}
 	for _, tc := range testCases {
@@ -3922,11 +3909,10 @@ func TestMos6502_pha(t *testing.T) {
 				stkp: tc.initialStkpValue,
 				bus:  bus.NewBus(bus.RAM{}),
 			}
-			additionalCycles := cpu.pha()
+			cpu.pha()
 
 			assert.Equal(t, tc.expectedStkpValue, cpu.stkp, "incorrect stack pointer value")
 			assert.Equal(t, tc.expectedBus, cpu.bus, "incorrect bus flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3938,20 +3924,18 @@ func TestMos6502_php(t *testing.T) {
 		statusValue      uint8
 		initialStkpValue uint8
 
-		expectedStkpValue        uint8
-		expectedStatusValue      uint8
-		expectedBus              *bus.Bus
-		expectedAdditionalCycles uint8
+		expectedStkpValue   uint8
+		expectedStatusValue uint8
+		expectedBus         *bus.Bus
 	}{
 		{
 			name:             "php operation performed correctly",
 			statusValue:      0b11001111,
 			initialStkpValue: 0x11,
 
-			expectedStkpValue:        0x10,
-			expectedStatusValue:      0b11001111,
-			expectedBus:              newBusBuilder().write(0x0111, 0b11111111).build(),
-			expectedAdditionalCycles: 0,
+			expectedStkpValue:   0x10,
+			expectedStatusValue: 0b11001111,
+			expectedBus:         newBusBuilder().write(0x0111, 0b11111111).build(),
 		},
 	}
 	for _, tc := range testCases {
@@ -3961,12 +3945,11 @@ func TestMos6502_php(t *testing.T) {
 				stkp:   tc.initialStkpValue,
 				bus:    bus.NewBus(bus.RAM{}),
 			}
-			additionalCycles := cpu.php()
+			cpu.php()
 
 			assert.Equal(t, tc.expectedStkpValue, cpu.stkp, "incorrect stack pointer value")
 			assert.Equal(t, tc.expectedStatusValue, cpu.status, "incorrect status value")
 			assert.Equal(t, tc.expectedBus, cpu.bus, "incorrect bus flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -3978,11 +3961,10 @@ func TestMos6502_pla(t *testing.T) {
 		bus              *bus.Bus
 		initialStkpValue uint8
 
-		expectedStkpValue        uint8
-		expectedAvalue           uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedStkpValue uint8
+		expectedAvalue    uint8
+		expectedZflag     uint8
+		expectedNflag     uint8
 	}{
 		{
 			name: "pla operation performed correctly",
@@ -3990,11 +3972,10 @@ func TestMos6502_pla(t *testing.T) {
 			initialStkpValue: 0x10,
 			bus:              newBusBuilder().write(0x0111, 0x42).build(),
 
-			expectedStkpValue:        0x11,
-			expectedAvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedStkpValue: 0x11,
+			expectedAvalue:    0x42,
+			expectedZflag:     0,
+			expectedNflag:     0,
 		},
 		{
 			name: "pla operation reads negative value sets N true",
@@ -4002,11 +3983,10 @@ func TestMos6502_pla(t *testing.T) {
 			initialStkpValue: 0x10,
 			bus:              newBusBuilder().write(0x0111, 0xff).build(),
 
-			expectedStkpValue:        0x11,
-			expectedAvalue:           0xff,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedStkpValue: 0x11,
+			expectedAvalue:    0xff,
+			expectedZflag:     0,
+			expectedNflag:     1,
 		},
 		{
 			name: "pla operation reads zero value sets Z true",
@@ -4014,11 +3994,10 @@ func TestMos6502_pla(t *testing.T) {
 			initialStkpValue: 0x10,
 			bus:              newBusBuilder().write(0x0111, 0x00).build(),
 
-			expectedStkpValue:        0x11,
-			expectedAvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedStkpValue: 0x11,
+			expectedAvalue:    0x00,
+			expectedZflag:     1,
+			expectedNflag:     0,
 		},
 	}
 	for _, tc := range testCases {
@@ -4027,13 +4006,12 @@ func TestMos6502_pla(t *testing.T) {
 				stkp: tc.initialStkpValue,
 				bus:  tc.bus,
 			}
-			additionalCycles := cpu.pla()
+			cpu.pla()
 
 			assert.Equal(t, tc.expectedStkpValue, cpu.stkp, "incorrect stack pointer value")
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4045,10 +4023,9 @@ func TestMos6502_plp(t *testing.T) {
 		bus              *bus.Bus
 		initialStkpValue uint8
 
-		expectedStkpValue        uint8
-		expectedStatusValue      uint8
-		expectedUflag            uint8
-		expectedAdditionalCycles uint8
+		expectedStkpValue   uint8
+		expectedStatusValue uint8
+		expectedUflag       uint8
 	}{
 		{
 			name: "plp operation performed correctly",
@@ -4058,10 +4035,9 @@ func TestMos6502_plp(t *testing.T) {
 				write(0x0111, 0b11011111).
 				build(),
 
-			expectedStkpValue:        0x11,
-			expectedStatusValue:      0b11111111,
-			expectedUflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedStkpValue:   0x11,
+			expectedStatusValue: 0b11111111,
+			expectedUflag:       1,
 		},
 	}
 	for _, tc := range testCases {
@@ -4070,12 +4046,11 @@ func TestMos6502_plp(t *testing.T) {
 				stkp: tc.initialStkpValue,
 				bus:  tc.bus,
 			}
-			additionalCycles := cpu.plp()
+			cpu.plp()
 
 			assert.Equal(t, tc.expectedStkpValue, cpu.stkp, "incorrect stack pointer value")
 			assert.Equal(t, tc.expectedStatusValue, cpu.status, "incorrect A value")
 			assert.Equal(t, tc.expectedUflag, cpu.GetStatusFlag(U), "incorrect Z flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4088,12 +4063,11 @@ func TestMos6502_rol(t *testing.T) {
 		Cflag       bool
 		instruction instruction
 
-		expectedAvalue           uint8
-		expectedBusValue         uint8
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue   uint8
+		expectedBusValue uint8
+		expectedCflag    uint8
+		expectedZflag    uint8
+		expectedNflag    uint8
 	}{
 		{
 			name: "rol operation with C=false and implied mode performed correctly",
@@ -4105,12 +4079,11 @@ func TestMos6502_rol(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x02,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x02,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "rol operation with C=true and implied mode performed correctly",
@@ -4122,12 +4095,11 @@ func TestMos6502_rol(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x03,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x03,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "rol operation in non-implied mode performed correctly",
@@ -4139,12 +4111,11 @@ func TestMos6502_rol(t *testing.T) {
 				addressMode: "TST",
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x02,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x02,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "rol operation resulting in 0 sets Z true",
@@ -4156,12 +4127,11 @@ func TestMos6502_rol(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    1,
+			expectedNflag:    0,
 		},
 		{
 			name: "rol operation resulting in negative result sets N true",
@@ -4173,12 +4143,11 @@ func TestMos6502_rol(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0xe0,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0xe0,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    1,
 		},
 		{
 			name: "rol operation captures 7 bit shift in C flag",
@@ -4190,12 +4159,11 @@ func TestMos6502_rol(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x00,
-			expectedCflag:            1,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x00,
+			expectedCflag:    1,
+			expectedZflag:    1,
+			expectedNflag:    0,
 		},
 	}
 	for _, tc := range testCases {
@@ -4211,14 +4179,13 @@ func TestMos6502_rol(t *testing.T) {
 			} else {
 				cpu.write(cpu.addressAbsolute, tc.dataValue)
 			}
-			additionalCycles := cpu.rol()
+			cpu.rol()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedBusValue, cpu.read(cpu.addressAbsolute), "incorrect Bus value")
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles value")
 		})
 	}
 }
@@ -4231,12 +4198,11 @@ func TestMos6502_ror(t *testing.T) {
 		Cflag       bool
 		instruction instruction
 
-		expectedAvalue           uint8
-		expectedBusValue         uint8
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue   uint8
+		expectedBusValue uint8
+		expectedCflag    uint8
+		expectedZflag    uint8
+		expectedNflag    uint8
 	}{
 		{
 			name: "ror operation with C=false and implied mode performed correctly",
@@ -4248,12 +4214,11 @@ func TestMos6502_ror(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x10,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x10,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "ror operation with C=true and implied mode performed correctly",
@@ -4265,12 +4230,11 @@ func TestMos6502_ror(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x90,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x90,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    1,
 		},
 		{
 			name: "ror operation in non-implied mode performed correctly",
@@ -4282,12 +4246,11 @@ func TestMos6502_ror(t *testing.T) {
 				addressMode: "TST",
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x10,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x10,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    0,
 		},
 		{
 			name: "ror operation resulting in 0 sets Z true",
@@ -4299,12 +4262,11 @@ func TestMos6502_ror(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    1,
+			expectedNflag:    0,
 		},
 		{
 			name: "ror operation resulting in negative result sets N true",
@@ -4316,12 +4278,11 @@ func TestMos6502_ror(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x80,
-			expectedBusValue:         0x00,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x80,
+			expectedBusValue: 0x00,
+			expectedCflag:    0,
+			expectedZflag:    0,
+			expectedNflag:    1,
 		},
 		{
 			name: "ror operation captures 0 bit shift in C flag",
@@ -4333,12 +4294,11 @@ func TestMos6502_ror(t *testing.T) {
 				addressMode: imp,
 			},
 
-			expectedAvalue:           0x00,
-			expectedBusValue:         0x00,
-			expectedCflag:            1,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue:   0x00,
+			expectedBusValue: 0x00,
+			expectedCflag:    1,
+			expectedZflag:    1,
+			expectedNflag:    0,
 		},
 	}
 	for _, tc := range testCases {
@@ -4354,14 +4314,13 @@ func TestMos6502_ror(t *testing.T) {
 			} else {
 				cpu.write(cpu.addressAbsolute, tc.dataValue)
 			}
-			additionalCycles := cpu.ror()
+			cpu.ror()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedBusValue, cpu.read(cpu.addressAbsolute), "incorrect Bus value")
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles value")
 		})
 	}
 }
@@ -4373,25 +4332,19 @@ func TestMos6502_rti(t *testing.T) {
 		initialStkpValue byte
 		bus              *bus.Bus
 
-		expectedPC               word
-		expectedStkp             byte
-		expectedStatus           byte
-		expectedAdditionalCycles uint8
+		expectedStkp   byte
+		expectedStatus byte
 	}{
 		{
-			name: "correct status and pc read",
+			name: "correct status read with B and U cleared",
 
 			initialStkpValue: 0x00,
 			bus: newBusBuilder().
 				write(0x0101, 0xff).
-				write(0x0102, 0x20).
-				write(0x0103, 0x04).
 				build(),
 
-			expectedPC:               0x0420,
-			expectedStkp:             0x03,
-			expectedStatus:           0b11001111,
-			expectedAdditionalCycles: 0,
+			expectedStkp:   0x01,
+			expectedStatus: 0b11001111,
 		},
 	}
 
@@ -4401,12 +4354,10 @@ func TestMos6502_rti(t *testing.T) {
 				stkp: tc.initialStkpValue,
 				bus:  tc.bus,
 			}
-			additionalCycles := cpu.rti()
+			cpu.rti()
 
-			assert.Equal(t, tc.expectedPC, cpu.pc, "incorrect PC value")
 			assert.Equal(t, tc.expectedStkp, cpu.stkp, "incorrect stack pointer value")
 			assert.Equal(t, tc.expectedStatus, cpu.status, "incorrect status value")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4415,39 +4366,34 @@ func TestMos6502_rts(t *testing.T) {
 	testCases := []struct {
 		name string
 
+		initialPC        word
 		initialStkpValue byte
-		bus              *bus.Bus
 
-		expectedPC               word
-		expectedStkp             byte
-		expectedAdditionalCycles uint8
+		expectedPC   word
+		expectedStkp byte
 	}{
 		{
-			name: "correct status and pc read",
+			name: "pulled pc stepped past the end of the jsr",
 
-			initialStkpValue: 0x00,
-			bus: newBusBuilder().
-				write(0x0101, 0x1f).
-				write(0x0102, 0x04).
-				build(),
+			initialPC:        0x041f,
+			initialStkpValue: 0x02,
 
-			expectedPC:               0x0420,
-			expectedStkp:             0x02,
-			expectedAdditionalCycles: 0,
+			expectedPC:   0x0420,
+			expectedStkp: 0x02,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := &Mos6502{
+				pc:   tc.initialPC,
 				stkp: tc.initialStkpValue,
-				bus:  tc.bus,
+				bus:  bus.NewBus(bus.RAM{}),
 			}
-			additionalCycles := cpu.rts()
+			cpu.rts()
 
 			assert.Equal(t, tc.expectedPC, cpu.pc, "incorrect PC value")
 			assert.Equal(t, tc.expectedStkp, cpu.stkp, "incorrect stack pointer value")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4460,12 +4406,11 @@ func TestMos6502_sbc(t *testing.T) {
 		initialAvalue byte
 		initialCflag  bool
 
-		expectedAvalue           byte
-		expectedCflag            uint8
-		expectedZflag            uint8
-		expectedVflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue byte
+		expectedCflag  uint8
+		expectedZflag  uint8
+		expectedVflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "0-0=-1 C=false sets Z=true",
@@ -4474,12 +4419,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x00,
 			initialCflag:  false,
 
-			expectedAvalue:           0xff,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0xff,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "0-0=0 C=true sets Z=true",
@@ -4488,12 +4432,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x00,
 			initialCflag:  true,
 
-			expectedAvalue:           0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedCflag:  1,
+			expectedZflag:  1,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "P-P=-1 C=false sets Z=true",
@@ -4502,12 +4445,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x7f,
 			initialCflag:  false,
 
-			expectedAvalue:           0xff,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0xff,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "P-P=0 C=true sets Z=true",
@@ -4516,12 +4458,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x7f,
 			initialCflag:  true,
 
-			expectedAvalue:           0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedCflag:  1,
+			expectedZflag:  1,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "N-N=-1 C=false cannot overflow",
@@ -4530,12 +4471,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x80,
 			initialCflag:  false,
 
-			expectedAvalue:           0xff,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0xff,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "N-N=0 C=true cannot overflow",
@@ -4544,12 +4484,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x80,
 			initialCflag:  true,
 
-			expectedAvalue:           0x00,
-			expectedCflag:            0,
-			expectedZflag:            1,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x00,
+			expectedCflag:  1,
+			expectedZflag:  1,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "N-N=P C=false cannot overflow",
@@ -4558,12 +4497,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  false,
 
-			expectedAvalue:           0x7e,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x7e,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "N-N=P C=true cannot overflow",
@@ -4572,12 +4510,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  true,
 
-			expectedAvalue:           0x7f,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x7f,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "0-N=N C=true overflows",
@@ -4586,12 +4523,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x00,
 			initialCflag:  true,
 
-			expectedAvalue:           0x80,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  1,
 		},
 		{
 			name: "P-P=P C=false cannot overflow",
@@ -4600,12 +4536,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x7f,
 			initialCflag:  false,
 
-			expectedAvalue:           0x7d,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x7d,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "P-P=P C=true cannot overflow",
@@ -4614,12 +4549,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x7f,
 			initialCflag:  true,
 
-			expectedAvalue:           0x01,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x01,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "0-P=N C=false cannot overflow",
@@ -4628,12 +4562,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x00,
 			initialCflag:  false,
 
-			expectedAvalue:           0x80,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "0-P=N C=true cannot overflow",
@@ -4642,12 +4575,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x00,
 			initialCflag:  true,
 
-			expectedAvalue:           0x81,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x81,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 		{
 			name: "P-N=N C=false causes overflow",
@@ -4656,12 +4588,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x01,
 			initialCflag:  false,
 
-			expectedAvalue:           0x80,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  1,
 		},
 		{
 			name: "P-N=N C=true causes overflow",
@@ -4670,12 +4601,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0x01,
 			initialCflag:  true,
 
-			expectedAvalue:           0x81,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x81,
+			expectedCflag:  0,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  1,
 		},
 		{
 			name: "N-P=P C=false causes overflow",
@@ -4684,12 +4614,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  false,
 
-			expectedAvalue:           0x7f,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x7f,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "N-P=P C=true cannot overflow",
@@ -4698,12 +4627,11 @@ func TestMos6502_sbc(t *testing.T) {
 			initialAvalue: 0xff,
 			initialCflag:  true,
 
-			expectedAvalue:           0x80,
-			expectedCflag:            0,
-			expectedZflag:            0,
-			expectedVflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 1,
+			expectedAvalue: 0x80,
+			expectedCflag:  1,
+			expectedZflag:  0,
+			expectedVflag:  0,
+			expectedNflag:  1,
 		},
 	}
 	for _, tc := range testCases {
@@ -4714,50 +4642,587 @@ func TestMos6502_sbc(t *testing.T) {
 			}
 			cpu.lookup = mos6502LookupTable{
 				{
-					operation:      sbc,
-					addressMode:    "TST",
-					performOp:      cpu.sbc,
-					setAddressMode: func() uint8 { return 0 },
+					operation:   sbc,
+					addressMode: "TST",
+					performOp:   cpu.sbc,
 				},
 			}
 			cpu.setStatusFlag(C, tc.initialCflag)
 
-			additionalCycles := cpu.sbc()
+			cpu.sbc()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedVflag, cpu.GetStatusFlag(V), "incorrect V flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
+func TestMos6502_decimalMode(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		variant       Variant
+		operation     string
+		busValue      uint8
+		initialAvalue uint8
+		initialCflag  bool
+
+		expectedAvalue uint8
+		expectedCflag  uint8
+	}{
+		{
+			name: "adc adds decimal digits",
+
+			variant:       NMOS6502,
+			operation:     adc,
+			busValue:      0x34,
+			initialAvalue: 0x12,
+
+			expectedAvalue: 0x46,
+			expectedCflag:  0,
+		},
+		{
+			name: "adc carries between digits",
+
+			variant:       NMOS6502,
+			operation:     adc,
+			busValue:      0x26,
+			initialAvalue: 0x15,
+
+			expectedAvalue: 0x41,
+			expectedCflag:  0,
+		},
+		{
+			name: "adc sets C when the sum is over 99",
+
+			variant:       NMOS6502,
+			operation:     adc,
+			busValue:      0x92,
+			initialAvalue: 0x81,
+
+			expectedAvalue: 0x73,
+			expectedCflag:  1,
+		},
+		{
+			name: "adc adds the carry",
+
+			variant:       NMOS6502,
+			operation:     adc,
+			busValue:      0x46,
+			initialAvalue: 0x58,
+			initialCflag:  true,
+
+			expectedAvalue: 0x05,
+			expectedCflag:  1,
+		},
+		{
+			name: "sbc subtracts decimal digits",
+
+			variant:       NMOS6502,
+			operation:     sbc,
+			busValue:      0x12,
+			initialAvalue: 0x46,
+			initialCflag:  true,
+
+			expectedAvalue: 0x34,
+			expectedCflag:  1,
+		},
+		{
+			name: "sbc borrows between digits",
+
+			variant:       NMOS6502,
+			operation:     sbc,
+			busValue:      0x13,
+			initialAvalue: 0x40,
+			initialCflag:  true,
+
+			expectedAvalue: 0x27,
+			expectedCflag:  1,
+		},
+		{
+			name: "sbc subtracts the borrow",
+
+			variant:       NMOS6502,
+			operation:     sbc,
+			busValue:      0x02,
+			initialAvalue: 0x32,
+			initialCflag:  false,
+
+			expectedAvalue: 0x29,
+			expectedCflag:  1,
+		},
+		{
+			name: "sbc clears C when the difference is negative",
+
+			variant:       NMOS6502,
+			operation:     sbc,
+			busValue:      0x21,
+			initialAvalue: 0x12,
+			initialCflag:  true,
+
+			expectedAvalue: 0x91,
+			expectedCflag:  0,
+		},
+		{
+			name: "2A03 ignores the D flag",
+
+			variant:       RP2A03,
+			operation:     adc,
+			busValue:      0x26,
+			initialAvalue: 0x15,
+
+			expectedAvalue: 0x3b,
+			expectedCflag:  0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cpu := newDecimalTestMos6502(tc.variant, tc.initialAvalue, tc.busValue, tc.initialCflag)
+			if tc.operation == adc {
+				cpu.adc()
+			} else {
+				cpu.sbc()
+			}
+
+			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect A value")
+			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
+		})
+	}
+
+	t.Run("adc sets Z from the binary sum and N from the unadjusted sum", func(t *testing.T) {
+		cpu := newDecimalTestMos6502(NMOS6502, 0x99, 0x01, false)
+		cpu.adc()
+
+		assert.Equal(t, uint8(0x00), cpu.a, "incorrect A value")
+		assert.Equal(t, uint8(1), cpu.GetStatusFlag(C), "incorrect C flag")
+		assert.Equal(t, uint8(0), cpu.GetStatusFlag(Z), "incorrect Z flag")
+		assert.Equal(t, uint8(1), cpu.GetStatusFlag(N), "incorrect N flag")
+	})
+}
+
+// newDecimalTestMos6502 creates a Mos6502 of a Variant in decimal mode, about
+// to add or subtract data from the accumulator.
+func newDecimalTestMos6502(variant Variant, a byte, data byte, carry bool) *Mos6502 {
+	cpu := NewMos6502(variant)
+	cpu.ConnectBus(bus.NewBus(bus.RAM{data}))
+	cpu.opcode = 0x69 // ADC #
+	cpu.a = a
+	cpu.setStatusFlag(D, true)
+	cpu.setStatusFlag(C, carry)
+	return cpu
+}
+
+// decimalTestVector is the expected result of an adc or sbc in decimal mode,
+// worked out as in Bruce Clark's decimal mode tests.
+type decimalTestVector struct {
+	a byte
+	n bool
+	v bool
+	z bool
+	c bool
+}
+
+// nmosDecimalAdd works out the result of an NMOS 6502 adc in decimal mode,
+// following Appendix A of Bruce Clark's "Decimal Mode" tutorial.
+func nmosDecimalAdd(a byte, b byte, carry int) decimalTestVector {
+	// Seq. 1, for the accumulator and C
+	al := int(a&0x0f) + int(b&0x0f) + carry
+	if al >= 0x0a {
+		al = ((al + 0x06) & 0x0f) + 0x10
+	}
+	sum := int(a&0xf0) + int(b&0xf0) + al
+	if sum >= 0xa0 {
+		sum += 0x60
+	}
+
+	// Seq. 2, for N and V, using signed arithmetic
+	al = int(a&0x0f) + int(b&0x0f) + carry
+	if al >= 0x0a {
+		al = ((al + 0x06) & 0x0f) + 0x10
+	}
+	signed := int(int8(a&0xf0)) + int(int8(b&0xf0)) + al
+
+	return decimalTestVector{
+		a: byte(sum),
+		n: signed&0x80 != 0,
+		v: signed < -128 || signed > 127,
+		z: byte(int(a)+int(b)+carry) == 0,
+		c: sum >= 0x100,
+	}
+}
+
+// nmosDecimalSubtract works out the result of an NMOS 6502 sbc in decimal
+// mode, following Appendix A of Bruce Clark's "Decimal Mode" tutorial.
+func nmosDecimalSubtract(a byte, b byte, carry int) decimalTestVector {
+	// Seq. 3, for the accumulator
+	al := int(a&0x0f) - int(b&0x0f) + carry - 1
+	if al < 0 {
+		al = ((al - 0x06) & 0x0f) - 0x10
+	}
+	difference := int(a&0xf0) - int(b&0xf0) + al
+	if difference < 0 {
+		difference -= 0x60
+	}
+
+	// flags are set as in binary mode
+	binary := int(a) - int(b) + carry - 1
+	signed := int(int8(a)) - int(int8(b)) + carry - 1
+	return decimalTestVector{
+		a: byte(difference),
+		n: binary&0x80 != 0,
+		v: signed < -128 || signed > 127,
+		z: byte(binary) == 0,
+		c: binary >= 0,
+	}
+}
+
+// cmosDecimalAdd works out the result of a 65C02 adc in decimal mode, which
+// sets N and Z from the accumulator.
+func cmosDecimalAdd(a byte, b byte, carry int) decimalTestVector {
+	expected := nmosDecimalAdd(a, b, carry)
+	expected.n = expected.a&0x80 != 0
+	expected.z = expected.a == 0
+	return expected
+}
+
+// cmosDecimalSubtract works out the result of a 65C02 sbc in decimal mode,
+// following Seq. 4 of Appendix A of Bruce Clark's "Decimal Mode" tutorial.
+func cmosDecimalSubtract(a byte, b byte, carry int) decimalTestVector {
+	expected := nmosDecimalSubtract(a, b, carry)
+
+	al := int(a&0x0f) - int(b&0x0f) + carry - 1
+	difference := int(a) - int(b) + carry - 1
+	if difference < 0 {
+		difference -= 0x60
+	}
+	if al < 0 {
+		difference -= 0x06
+	}
+	expected.a = byte(difference)
+	expected.n = expected.a&0x80 != 0
+	expected.z = expected.a == 0
+	return expected
+}
+
+func TestMos6502_decimalModeVectors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		variant   Variant
+		operation string
+		expected  func(a byte, b byte, carry int) decimalTestVector
+	}{
+		{name: "NMOS adc", variant: NMOS6502, operation: adc, expected: nmosDecimalAdd},
+		{name: "NMOS sbc", variant: NMOS6502, operation: sbc, expected: nmosDecimalSubtract},
+		{name: "65C02 adc", variant: WDC65C02, operation: adc, expected: cmosDecimalAdd},
+		{name: "65C02 sbc", variant: WDC65C02, operation: sbc, expected: cmosDecimalSubtract},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cpu := newDecimalTestMos6502(tc.variant, 0x00, 0x00, false)
+			for a := 0; a < 0x100; a++ {
+				for b := 0; b < 0x100; b++ {
+					for carry := 0; carry < 2; carry++ {
+						cpu.a = byte(a)
+						cpu.write(0x0000, byte(b))
+						cpu.setStatusFlag(C, carry == 1)
+						if tc.operation == adc {
+							cpu.adc()
+						} else {
+							cpu.sbc()
+						}
+
+						actual := decimalTestVector{
+							a: cpu.a,
+							n: cpu.GetStatusFlag(N) == 1,
+							v: cpu.GetStatusFlag(V) == 1,
+							z: cpu.GetStatusFlag(Z) == 1,
+							c: cpu.GetStatusFlag(C) == 1,
+						}
+						expected := tc.expected(byte(a), byte(b), carry)
+						if !assert.Equal(t, expected, actual, "$%02X, $%02X, C=%d", a, b, carry) {
+							return
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// decimalKnownResults are results of adc and sbc in decimal mode measured on
+// hardware, which check the vectors above independently of how they are worked
+// out. The adc results with invalid BCD digits are the examples of Bruce
+// Clark's "Decimal Mode" tutorial, which the 65C02 shares, and the sbc results
+// with invalid digits borrow from neither digit, so are left uncorrected.
+var decimalKnownResults = []struct {
+	operation string
+	a         byte
+	b         byte
+	carry     bool
+
+	expectedA byte
+	expectedC bool
+	expectedV bool
+}{
+	{operation: adc, a: 0x12, b: 0x34, expectedA: 0x46},
+	{operation: adc, a: 0x15, b: 0x26, expectedA: 0x41},
+	{operation: adc, a: 0x99, b: 0x01, expectedA: 0x00, expectedC: true},
+	{operation: adc, a: 0x24, b: 0x56, expectedA: 0x80, expectedV: true},
+	{operation: adc, a: 0x93, b: 0x82, expectedA: 0x75, expectedC: true, expectedV: true},
+	{operation: adc, a: 0x89, b: 0x76, expectedA: 0x65, expectedC: true},
+	{operation: adc, a: 0x80, b: 0xf0, expectedA: 0xd0, expectedC: true, expectedV: true},
+	{operation: adc, a: 0x80, b: 0xfa, expectedA: 0xe0, expectedC: true},
+	{operation: adc, a: 0x2f, b: 0x4f, expectedA: 0x74},
+	{operation: adc, a: 0x6f, b: 0x00, carry: true, expectedA: 0x76},
+	{operation: sbc, a: 0x46, b: 0x12, carry: true, expectedA: 0x34, expectedC: true},
+	{operation: sbc, a: 0x40, b: 0x13, carry: true, expectedA: 0x27, expectedC: true},
+	{operation: sbc, a: 0x32, b: 0x02, expectedA: 0x29, expectedC: true},
+	{operation: sbc, a: 0x21, b: 0x34, carry: true, expectedA: 0x87},
+	{operation: sbc, a: 0x00, b: 0x01, carry: true, expectedA: 0x99},
+	{operation: sbc, a: 0xfa, b: 0x0a, carry: true, expectedA: 0xf0, expectedC: true},
+	{operation: sbc, a: 0x1f, b: 0x0f, carry: true, expectedA: 0x10, expectedC: true},
+}
+
+// decimalVector returns the function working out the vectors of an operation
+// on a Variant.
+func decimalVector(variant Variant, operation string) func(a byte, b byte, carry int) decimalTestVector {
+	switch {
+	case variant == NMOS6502 && operation == adc:
+		return nmosDecimalAdd
+	case variant == NMOS6502:
+		return nmosDecimalSubtract
+	case operation == adc:
+		return cmosDecimalAdd
+	default:
+		return cmosDecimalSubtract
+	}
+}
+
+func TestMos6502_decimalModeKnownResults(t *testing.T) {
+	variants := []struct {
+		name    string
+		variant Variant
+	}{
+		{name: "NMOS", variant: NMOS6502},
+		{name: "65C02", variant: WDC65C02},
+	}
+	for _, v := range variants {
+		variant := v.variant
+		for _, tc := range decimalKnownResults {
+			carry := 0
+			if tc.carry {
+				carry = 1
+			}
+			name := fmt.Sprintf("%s $%02X %s $%02X C=%d", v.name, tc.a, tc.operation, tc.b, carry)
+			t.Run(name, func(t *testing.T) {
+				cpu := newDecimalTestMos6502(variant, tc.a, tc.b, tc.carry)
+				if tc.operation == adc {
+					cpu.adc()
+				} else {
+					cpu.sbc()
+				}
+
+				assert.Equal(t, tc.expectedA, cpu.a, "incorrect A value")
+				assert.Equal(t, tc.expectedC, cpu.GetStatusFlag(C) == 1, "incorrect C flag")
+				assert.Equal(t, tc.expectedV, cpu.GetStatusFlag(V) == 1, "incorrect V flag")
+
+				// the vectors must agree too, or they check against a shared mistake
+				vector := decimalVector(variant, tc.operation)(tc.a, tc.b, carry)
+				assert.Equal(t, tc.expectedA, vector.a, "incorrect A vector")
+				assert.Equal(t, tc.expectedC, vector.c, "incorrect C vector")
+				assert.Equal(t, tc.expectedV, vector.v, "incorrect V vector")
+			})
+		}
+	}
+}
+
+func TestMos6502_cmosOperations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		program []byte
+		memory  map[uint16]byte
+		a       byte
+		x       byte
+		y       byte
+		status  byte
+
+		expectedA      byte
+		expectedX      byte
+		expectedY      byte
+		expectedStatus byte
+		expectedMemory map[uint16]byte
+	}{
+		{
+			name:    "bit immediate only sets Z",
+			program: []byte{0x89, 0xc0}, // BIT #$C0
+			a:       0x01,
+
+			expectedA:      0x01,
+			expectedStatus: byte(Z) | byte(U),
+		},
+		{
+			name:    "inc accumulator",
+			program: []byte{0x1a}, // INC A
+			a:       0x7f,
+
+			expectedA:      0x80,
+			expectedStatus: byte(N) | byte(U),
+		},
+		{
+			name:    "dec accumulator",
+			program: []byte{0x3a}, // DEC A
+			a:       0x01,
+
+			expectedStatus: byte(Z) | byte(U),
+		},
+		{
+			name:    "phx then ply",
+			program: []byte{0xda, 0x7a}, // PHX, PLY
+			x:       0x80,
+
+			expectedX:      0x80,
+			expectedY:      0x80,
+			expectedStatus: byte(N) | byte(U),
+		},
+		{
+			name:    "phy then plx",
+			program: []byte{0x5a, 0xfa}, // PHY, PLX
+			x:       0x55,
+
+			expectedStatus: byte(Z) | byte(U),
+		},
+		{
+			name:    "tsb sets bits and Z from the bits tested",
+			program: []byte{0x04, 0x10}, // TSB $10
+			memory:  map[uint16]byte{0x0010: 0x0c},
+			a:       0x03,
+
+			expectedA:      0x03,
+			expectedStatus: byte(Z) | byte(U),
+			expectedMemory: map[uint16]byte{0x0010: 0x0f},
+		},
+		{
+			name:    "trb resets bits and Z from the bits tested",
+			program: []byte{0x1c, 0x00, 0x10}, // TRB $1000
+			memory:  map[uint16]byte{0x1000: 0x0f},
+			a:       0x03,
+
+			expectedA:      0x03,
+			expectedStatus: byte(U),
+			expectedMemory: map[uint16]byte{0x1000: 0x0c},
+		},
+		{
+			name:    "stz indexed",
+			program: []byte{0x9e, 0x00, 0x10}, // STZ $1000,X
+			memory:  map[uint16]byte{0x1001: 0x55},
+			x:       0x01,
+
+			expectedX:      0x01,
+			expectedStatus: byte(U),
+			expectedMemory: map[uint16]byte{0x1001: 0x00},
+		},
+		{
+			name:    "sta zero page indirect",
+			program: []byte{0x92, 0x10}, // STA ($10)
+			memory:  map[uint16]byte{0x0010: 0x00, 0x0011: 0x10},
+			a:       0x55,
+
+			expectedA:      0x55,
+			expectedStatus: byte(U),
+			expectedMemory: map[uint16]byte{0x1000: 0x55},
+		},
+		{
+			name:    "rmb",
+			program: []byte{0x37, 0x10}, // RMB3 $10
+			memory:  map[uint16]byte{0x0010: 0xff},
+
+			expectedStatus: byte(U),
+			expectedMemory: map[uint16]byte{0x0010: 0xf7},
+		},
+		{
+			name:    "decimal adc sets N and Z from the result",
+			program: []byte{0x69, 0x01}, // ADC #$01
+			a:       0x99,
+			status:  byte(D),
+
+			expectedStatus: byte(D) | byte(C) | byte(Z) | byte(U),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := newBusBuilder()
+			for i, data := range tc.program {
+				b.write(0x8000+word(i), data)
+			}
+			for address, data := range tc.memory {
+				b.write(word(address), data)
+			}
+			cpu := NewMos6502(R65C02)
+			cpu.ConnectBus(b.build())
+			cpu.pc = 0x8000
+			cpu.stkp = 0xfd
+			cpu.a = tc.a
+			cpu.x = tc.x
+			cpu.y = tc.y
+			cpu.status = tc.status | byte(U)
+
+			for cpu.pc < 0x8000+word(len(tc.program)) {
+				step(cpu)
+			}
+
+			assert.Equal(t, tc.expectedA, cpu.a, "incorrect A value")
+			assert.Equal(t, tc.expectedX, cpu.x, "incorrect X value")
+			assert.Equal(t, tc.expectedY, cpu.y, "incorrect Y value")
+			assert.Equal(t, tc.expectedStatus, cpu.status, "incorrect status")
+			for address, data := range tc.expectedMemory {
+				assert.Equal(t, data, cpu.bus.Peek(address), "incorrect data at $%04X", address)
+			}
+		})
+	}
+
+	t.Run("interrupts clear D", func(t *testing.T) {
+		b := newBusBuilder()
+		b.write(0x8000, 0x00) // BRK
+		b.write(0xfffe, 0x00)
+		b.write(0xffff, 0x90)
+		cpu := NewMos6502(WDC65C02)
+		cpu.ConnectBus(b.build())
+		cpu.pc = 0x8000
+		cpu.stkp = 0xfd
+		cpu.status = byte(D) | byte(U)
+
+		step(cpu)
+
+		assert.Equal(t, word(0x9000), cpu.pc)
+		assert.Equal(t, uint8(0), cpu.GetStatusFlag(D), "incorrect D flag")
+		assert.Equal(t, byte(D)|byte(B)|byte(U), pushedStatus(cpu), "incorrect pushed status")
+	})
+}
+
 func TestMos6502_sec(t *testing.T) {
 	testCases := []struct {
 		name string
 
 		initialCflag bool
 
-		expectedCflag            uint8
-		expectedAdditionalCycles uint8
+		expectedCflag uint8
 	}{
 		{
 			name: "C=false is set to true",
 
 			initialCflag: false,
 
-			expectedCflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 1,
 		},
 		{
 			name: "C=true stays true",
 
 			initialCflag: true,
 
-			expectedCflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedCflag: 1,
 		},
 	}
 
@@ -4765,10 +5230,9 @@ func TestMos6502_sec(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := &Mos6502{}
 			cpu.setStatusFlag(C, tc.initialCflag)
-			additionalCycles := cpu.sec()
+			cpu.sec()
 
 			assert.Equal(t, tc.expectedCflag, cpu.GetStatusFlag(C), "incorrect C flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4779,24 +5243,21 @@ func TestMos6502_sed(t *testing.T) {
 
 		initialDflag bool
 
-		expectedDflag            uint8
-		expectedAdditionalCycles uint8
+		expectedDflag uint8
 	}{
 		{
 			name: "D=false is set to true",
 
 			initialDflag: false,
 
-			expectedDflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedDflag: 1,
 		},
 		{
 			name: "D=true stays true",
 
 			initialDflag: true,
 
-			expectedDflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedDflag: 1,
 		},
 	}
 
@@ -4804,10 +5265,9 @@ func TestMos6502_sed(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := &Mos6502{}
 			cpu.setStatusFlag(D, tc.initialDflag)
-			additionalCycles := cpu.sed()
+			cpu.sed()
 
 			assert.Equal(t, tc.expectedDflag, cpu.GetStatusFlag(D), "incorrect D flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4818,24 +5278,21 @@ func TestMos6502_sei(t *testing.T) {
 
 		initialIflag bool
 
-		expectedIflag            uint8
-		expectedAdditionalCycles uint8
+		expectedIflag uint8
 	}{
 		{
 			name: "I=false is set to true",
 
 			initialIflag: false,
 
-			expectedIflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedIflag: 1,
 		},
 		{
 			name: "I=true stays true",
 
 			initialIflag: true,
 
-			expectedIflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedIflag: 1,
 		},
 	}
 
@@ -4843,10 +5300,9 @@ func TestMos6502_sei(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cpu := &Mos6502{}
 			cpu.setStatusFlag(I, tc.initialIflag)
-			additionalCycles := cpu.sei()
+			cpu.sei()
 
 			assert.Equal(t, tc.expectedIflag, cpu.GetStatusFlag(I), "incorrect I flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4858,8 +5314,7 @@ func TestMos6502_sta(t *testing.T) {
 		address word
 		aValue  byte
 
-		expectedBus              *bus.Bus
-		expectedAdditionalCycles uint8
+		expectedBus *bus.Bus
 	}{
 		{
 			name: "accumulator written to address",
@@ -4870,7 +5325,6 @@ func TestMos6502_sta(t *testing.T) {
 			expectedBus: newBusBuilder().
 				write(0x0420, 0x42).
 				build(),
-			expectedAdditionalCycles: 0,
 		},
 	}
 
@@ -4881,10 +5335,9 @@ func TestMos6502_sta(t *testing.T) {
 				a:               tc.aValue,
 				bus:             newBusBuilder().build(),
 			}
-			additionalCycles := cpu.sta()
+			cpu.sta()
 
 			assert.Equal(t, tc.expectedBus, cpu.bus, "incorrect bus")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4896,8 +5349,7 @@ func TestMos6502_stx(t *testing.T) {
 		address word
 		xValue  byte
 
-		expectedBus              *bus.Bus
-		expectedAdditionalCycles uint8
+		expectedBus *bus.Bus
 	}{
 		{
 			name: "x register written to address",
@@ -4908,7 +5360,6 @@ func TestMos6502_stx(t *testing.T) {
 			expectedBus: newBusBuilder().
 				write(0x0420, 0x42).
 				build(),
-			expectedAdditionalCycles: 0,
 		},
 	}
 
@@ -4919,10 +5370,9 @@ func TestMos6502_stx(t *testing.T) {
 				x:               tc.xValue,
 				bus:             newBusBuilder().build(),
 			}
-			additionalCycles := cpu.stx()
+			cpu.stx()
 
 			assert.Equal(t, tc.expectedBus, cpu.bus, "incorrect bus")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4934,8 +5384,7 @@ func TestMos6502_sty(t *testing.T) {
 		address word
 		yValue  byte
 
-		expectedBus              *bus.Bus
-		expectedAdditionalCycles uint8
+		expectedBus *bus.Bus
 	}{
 		{
 			name: "y register written to address",
@@ -4946,7 +5395,6 @@ func TestMos6502_sty(t *testing.T) {
 			expectedBus: newBusBuilder().
 				write(0x0420, 0x42).
 				build(),
-			expectedAdditionalCycles: 0,
 		},
 	}
 
@@ -4957,10 +5405,9 @@ func TestMos6502_sty(t *testing.T) {
 				y:               tc.yValue,
 				bus:             newBusBuilder().build(),
 			}
-			additionalCycles := cpu.sty()
+			cpu.sty()
 
 			assert.Equal(t, tc.expectedBus, cpu.bus, "incorrect bus")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -4971,40 +5418,36 @@ func TestMos6502_tax(t *testing.T) {
 
 		aValue byte
 
-		expectedXvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedXvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "accumulator value assigned to x register",
 
 			aValue: 0x42,
 
-			expectedXvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "zero value assigned sets Z=true",
 
 			aValue: 0x00,
 
-			expectedXvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "negative value assigned sets N=true",
 
 			aValue: 0x80,
 
-			expectedXvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -5013,12 +5456,11 @@ func TestMos6502_tax(t *testing.T) {
 			cpu := &Mos6502{
 				a: tc.aValue,
 			}
-			additionalCycles := cpu.tax()
+			cpu.tax()
 
 			assert.Equal(t, tc.expectedXvalue, cpu.x, "incorrect x value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -5029,40 +5471,36 @@ func TestMos6502_tay(t *testing.T) {
 
 		aValue byte
 
-		expectedYvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedYvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "accumulator value assigned to y register",
 
 			aValue: 0x42,
 
-			expectedYvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "zero value assigned sets Z=true",
 
 			aValue: 0x00,
 
-			expectedYvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "negative value assigned sets N=true",
 
 			aValue: 0x80,
 
-			expectedYvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedYvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -5071,12 +5509,11 @@ func TestMos6502_tay(t *testing.T) {
 			cpu := &Mos6502{
 				a: tc.aValue,
 			}
-			additionalCycles := cpu.tay()
+			cpu.tay()
 
 			assert.Equal(t, tc.expectedYvalue, cpu.y, "incorrect y value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -5087,40 +5524,36 @@ func TestMos6502_tsx(t *testing.T) {
 
 		stkpValue byte
 
-		expectedXvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedXvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "stack pointer value assigned to x register",
 
 			stkpValue: 0x42,
 
-			expectedXvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "zero value assigned sets Z=true",
 
 			stkpValue: 0x00,
 
-			expectedXvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "negative value assigned sets N=true",
 
 			stkpValue: 0x80,
 
-			expectedXvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedXvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -5129,12 +5562,11 @@ func TestMos6502_tsx(t *testing.T) {
 			cpu := &Mos6502{
 				stkp: tc.stkpValue,
 			}
-			additionalCycles := cpu.tsx()
+			cpu.tsx()
 
 			assert.Equal(t, tc.expectedXvalue, cpu.x, "incorrect x value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -5145,40 +5577,36 @@ func TestMos6502_txa(t *testing.T) {
 
 		xValue byte
 
-		expectedAvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "x value assigned to accumulator",
 
 			xValue: 0x42,
 
-			expectedAvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "zero value assigned sets Z=true",
 
 			xValue: 0x00,
 
-			expectedAvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "negative value assigned sets N=true",
 
 			xValue: 0x80,
 
-			expectedAvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -5187,12 +5615,11 @@ func TestMos6502_txa(t *testing.T) {
 			cpu := &Mos6502{
 				x: tc.xValue,
 			}
-			additionalCycles := cpu.txa()
+			cpu.txa()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect a value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -5203,16 +5630,14 @@ func TestMos6502_txs(t *testing.T) {
 
 		xValue byte
 
-		expectedStkpValue        byte
-		expectedAdditionalCycles uint8
+		expectedStkpValue byte
 	}{
 		{
 			name: "x value assigned to stack pointer",
 
 			xValue: 0x42,
 
-			expectedStkpValue:        0x42,
-			expectedAdditionalCycles: 0,
+			expectedStkpValue: 0x42,
 		},
 	}
 
@@ -5221,10 +5646,9 @@ func TestMos6502_txs(t *testing.T) {
 			cpu := &Mos6502{
 				x: tc.xValue,
 			}
-			additionalCycles := cpu.txs()
+			cpu.txs()
 
 			assert.Equal(t, tc.expectedStkpValue, cpu.stkp, "incorrect stack pointer value")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
@@ -5235,40 +5659,36 @@ func TestMos6502_tya(t *testing.T) {
 
 		yValue byte
 
-		expectedAvalue           byte
-		expectedZflag            uint8
-		expectedNflag            uint8
-		expectedAdditionalCycles uint8
+		expectedAvalue byte
+		expectedZflag  uint8
+		expectedNflag  uint8
 	}{
 		{
 			name: "y value assigned to accumulator",
 
 			yValue: 0x42,
 
-			expectedAvalue:           0x42,
-			expectedZflag:            0,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0x42,
+			expectedZflag:  0,
+			expectedNflag:  0,
 		},
 		{
 			name: "zero value assigned sets Z=true",
 
 			yValue: 0x00,
 
-			expectedAvalue:           0x00,
-			expectedZflag:            1,
-			expectedNflag:            0,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0x00,
+			expectedZflag:  1,
+			expectedNflag:  0,
 		},
 		{
 			name: "negative value assigned sets N=true",
 
 			yValue: 0x80,
 
-			expectedAvalue:           0x80,
-			expectedZflag:            0,
-			expectedNflag:            1,
-			expectedAdditionalCycles: 0,
+			expectedAvalue: 0x80,
+			expectedZflag:  0,
+			expectedNflag:  1,
 		},
 	}
 
@@ -5277,19 +5697,356 @@ func TestMos6502_tya(t *testing.T) {
 			cpu := &Mos6502{
 				y: tc.yValue,
 			}
-			additionalCycles := cpu.tya()
+			cpu.tya()
 
 			assert.Equal(t, tc.expectedAvalue, cpu.a, "incorrect a value")
 			assert.Equal(t, tc.expectedZflag, cpu.GetStatusFlag(Z), "incorrect Z flag")
 			assert.Equal(t, tc.expectedNflag, cpu.GetStatusFlag(N), "incorrect N flag")
-			assert.Equal(t, tc.expectedAdditionalCycles, additionalCycles, "incorrect additional cycles")
 		})
 	}
 }
 
-func TestMos6502_xxx(t *testing.T) {
-	cpu := &Mos6502{}
-	additionalCycles := cpu.xxx()
-	assert.Equal(t, uint8(0), additionalCycles)
-	assert.Equal(t, &Mos6502{}, cpu)
+func TestMos6502_Jammed(t *testing.T) {
+	cpu := newInterruptTestMos6502(0x02) // KIL
+	step(cpu)
+	assert.True(t, cpu.Jammed(), "not jammed by KIL")
+	assert.True(t, cpu.Complete(), "KIL never completes")
+
+	cpu.SetNMI(true)
+	for i := 0; i < 10; i++ {
+		cpu.Clock()
+	}
+	assert.Equal(t, word(0x8001), cpu.pc, "jammed CPU executed instructions")
+	assert.Equal(t, uint32(12), cpu.clockCount, "jammed CPU stopped counting cycles")
+
+	cpu.bus.Write(0xfffc, 0x01)
+	cpu.bus.Write(0xfffd, 0x80)
+	cpu.Reset()
+	assert.False(t, cpu.Jammed(), "still jammed after reset")
+}
+
+func TestMos6502_unofficialOpcodes(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		program []byte
+		memory  map[word]byte
+		a       byte
+		x       byte
+		y       byte
+		stkp    byte
+		status  byte
+
+		expectedA      byte
+		expectedX      byte
+		expectedY      byte
+		expectedStkp   byte
+		expectedStatus byte
+		expectedMemory map[word]byte
+		expectedPC     word
+		expectedCycles uint32
+	}{
+		{
+			name:           "lax loads accumulator and X register",
+			program:        []byte{0xa7, 0x10},
+			memory:         map[word]byte{0x0010: 0x80},
+			expectedA:      0x80,
+			expectedX:      0x80,
+			expectedStatus: 0b10100000,
+			expectedPC:     0x8002,
+			expectedCycles: 3,
+		},
+		{
+			name:           "lax takes an additional cycle on page crossing",
+			program:        []byte{0xbf, 0xff, 0x02},
+			memory:         map[word]byte{0x0300: 0x42},
+			y:              0x01,
+			expectedA:      0x42,
+			expectedX:      0x42,
+			expectedY:      0x01,
+			expectedStatus: 0b00100000,
+			expectedPC:     0x8003,
+			expectedCycles: 5,
+		},
+		{
+			name:           "immediate lax loads the value unchanged",
+			program:        []byte{0xab, 0x00},
+			a:              0xff,
+			x:              0xff,
+			expectedStatus: 0b00100010,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "sax stores accumulator AND X register",
+			program:        []byte{0x87, 0x10},
+			a:              0xf0,
+			x:              0x3c,
+			expectedA:      0xf0,
+			expectedX:      0x3c,
+			expectedStatus: 0b00100000,
+			expectedMemory: map[word]byte{0x0010: 0x30},
+			expectedPC:     0x8002,
+			expectedCycles: 3,
+		},
+		{
+			name:           "dcp decrements then compares",
+			program:        []byte{0xc7, 0x10},
+			memory:         map[word]byte{0x0010: 0x43},
+			a:              0x42,
+			expectedA:      0x42,
+			expectedStatus: 0b00100011,
+			expectedMemory: map[word]byte{0x0010: 0x42},
+			expectedPC:     0x8002,
+			expectedCycles: 5,
+		},
+		{
+			name:           "isc increments then subtracts",
+			program:        []byte{0xef, 0x00, 0x03},
+			memory:         map[word]byte{0x0300: 0x0f},
+			a:              0x20,
+			status:         0b00000001,
+			expectedA:      0x10,
+			expectedStatus: 0b00100001,
+			expectedMemory: map[word]byte{0x0300: 0x10},
+			expectedPC:     0x8003,
+			expectedCycles: 6,
+		},
+		{
+			name:           "slo shifts left then ORs",
+			program:        []byte{0x07, 0x10},
+			memory:         map[word]byte{0x0010: 0x81},
+			a:              0x04,
+			expectedA:      0x06,
+			expectedStatus: 0b00100001,
+			expectedMemory: map[word]byte{0x0010: 0x02},
+			expectedPC:     0x8002,
+			expectedCycles: 5,
+		},
+		{
+			name:           "rla rotates left then ANDs",
+			program:        []byte{0x37, 0x0f},
+			memory:         map[word]byte{0x0010: 0x81},
+			a:              0x0f,
+			x:              0x01,
+			status:         0b00000001,
+			expectedA:      0x03,
+			expectedX:      0x01,
+			expectedStatus: 0b00100001,
+			expectedMemory: map[word]byte{0x0010: 0x03},
+			expectedPC:     0x8002,
+			expectedCycles: 6,
+		},
+		{
+			name:           "sre shifts right then exclusive ORs",
+			program:        []byte{0x43, 0x0e},
+			memory:         map[word]byte{0x0010: 0x00, 0x0011: 0x03, 0x0300: 0x03},
+			a:              0x80,
+			x:              0x02,
+			expectedA:      0x81,
+			expectedX:      0x02,
+			expectedStatus: 0b10100001,
+			expectedMemory: map[word]byte{0x0300: 0x01},
+			expectedPC:     0x8002,
+			expectedCycles: 8,
+		},
+		{
+			name:           "rra rotates right then adds without page crossing cycle",
+			program:        []byte{0x73, 0x10},
+			memory:         map[word]byte{0x0010: 0xf8, 0x0011: 0x02, 0x0308: 0x02},
+			a:              0x01,
+			y:              0x10,
+			status:         0b00000001,
+			expectedA:      0x82,
+			expectedY:      0x10,
+			expectedStatus: 0b10100000,
+			expectedMemory: map[word]byte{0x0308: 0x81},
+			expectedPC:     0x8002,
+			expectedCycles: 8,
+		},
+		{
+			name:           "anc ANDs then copies N into C",
+			program:        []byte{0x0b, 0x80},
+			a:              0xff,
+			expectedA:      0x80,
+			expectedStatus: 0b10100001,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "alr ANDs then shifts right",
+			program:        []byte{0x4b, 0x03},
+			a:              0xff,
+			expectedA:      0x01,
+			expectedStatus: 0b00100001,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "arr ANDs then rotates right, setting C from bit 6",
+			program:        []byte{0x6b, 0xff},
+			a:              0xc0,
+			status:         0b00000001,
+			expectedA:      0xe0,
+			expectedStatus: 0b10100001,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "arr sets V from bit 6 exclusive ORed with bit 5",
+			program:        []byte{0x6b, 0xff},
+			a:              0x40,
+			expectedA:      0x20,
+			expectedStatus: 0b01100000,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "axs subtracts from accumulator AND X register",
+			program:        []byte{0xcb, 0x02},
+			a:              0x0f,
+			x:              0x35,
+			expectedA:      0x0f,
+			expectedX:      0x03,
+			expectedStatus: 0b00100001,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "las ANDs with stack pointer into accumulator, X register and stack pointer",
+			program:        []byte{0xbb, 0x00, 0x03},
+			memory:         map[word]byte{0x0300: 0x3f},
+			stkp:           0xf0,
+			expectedA:      0x30,
+			expectedX:      0x30,
+			expectedStkp:   0x30,
+			expectedStatus: 0b00100000,
+			expectedPC:     0x8003,
+			expectedCycles: 4,
+		},
+		{
+			name:           "xaa ANDs magic value, X register and operand",
+			program:        []byte{0x8b, 0xff},
+			a:              0x01,
+			x:              0x0f,
+			expectedA:      0x0f,
+			expectedX:      0x0f,
+			expectedStatus: 0b00100000,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "shx stores X register AND high byte plus one",
+			program:        []byte{0x9e, 0x00, 0x03},
+			x:              0xff,
+			y:              0x01,
+			expectedX:      0xff,
+			expectedY:      0x01,
+			expectedStatus: 0b00100000,
+			expectedMemory: map[word]byte{0x0301: 0x04},
+			expectedPC:     0x8003,
+			expectedCycles: 5,
+		},
+		{
+			name:           "shy replaces high byte of address on page crossing",
+			program:        []byte{0x9c, 0xff, 0x06},
+			memory:         map[word]byte{0x0700: 0x55},
+			x:              0x01,
+			y:              0x03,
+			expectedX:      0x01,
+			expectedY:      0x03,
+			expectedStatus: 0b00100000,
+			expectedMemory: map[word]byte{0x0300: 0x03, 0x0700: 0x55},
+			expectedPC:     0x8003,
+			expectedCycles: 5,
+		},
+		{
+			name:           "sha stores accumulator AND X register AND high byte plus one",
+			program:        []byte{0x93, 0x10},
+			memory:         map[word]byte{0x0010: 0x00, 0x0011: 0x03},
+			a:              0xff,
+			x:              0x0f,
+			y:              0x01,
+			expectedA:      0xff,
+			expectedX:      0x0f,
+			expectedY:      0x01,
+			expectedStatus: 0b00100000,
+			expectedMemory: map[word]byte{0x0301: 0x04},
+			expectedPC:     0x8002,
+			expectedCycles: 6,
+		},
+		{
+			name:           "tas stores accumulator AND X register in stack pointer and memory",
+			program:        []byte{0x9b, 0x00, 0x03},
+			memory:         map[word]byte{0x0300: 0xff},
+			a:              0xf3,
+			x:              0x3f,
+			stkp:           0xfd,
+			expectedA:      0xf3,
+			expectedX:      0x3f,
+			expectedStkp:   0x33,
+			expectedStatus: 0b00100000,
+			expectedMemory: map[word]byte{0x0300: 0x00},
+			expectedPC:     0x8003,
+			expectedCycles: 5,
+		},
+		{
+			name:           "immediate nop skips its operand",
+			program:        []byte{0x80, 0xff},
+			expectedStatus: 0b00100000,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+		{
+			name:           "zero page x nop skips its operand",
+			program:        []byte{0x14, 0x10},
+			expectedStatus: 0b00100000,
+			expectedPC:     0x8002,
+			expectedCycles: 4,
+		},
+		{
+			name:           "absolute x nop takes an additional cycle on page crossing",
+			program:        []byte{0x1c, 0xff, 0x02},
+			x:              0x01,
+			expectedX:      0x01,
+			expectedStatus: 0b00100000,
+			expectedPC:     0x8003,
+			expectedCycles: 5,
+		},
+		{
+			name:           "unofficial sbc subtracts",
+			program:        []byte{0xeb, 0x01},
+			a:              0x03,
+			status:         0b00000001,
+			expectedA:      0x02,
+			expectedStatus: 0b00100001,
+			expectedPC:     0x8002,
+			expectedCycles: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cpu := newInterruptTestMos6502(tc.program...)
+			for address, data := range tc.memory {
+				cpu.write(address, data)
+			}
+			cpu.a = tc.a
+			cpu.x = tc.x
+			cpu.y = tc.y
+			cpu.stkp = tc.stkp
+			cpu.status = tc.status
+			step(cpu)
+
+			assert.Equal(t, tc.expectedA, cpu.a, "incorrect A value")
+			assert.Equal(t, tc.expectedX, cpu.x, "incorrect X value")
+			assert.Equal(t, tc.expectedY, cpu.y, "incorrect Y value")
+			assert.Equal(t, tc.expectedStkp, cpu.stkp, "incorrect stack pointer")
+			assert.Equal(t, tc.expectedStatus, cpu.status, "incorrect status")
+			for address, data := range tc.expectedMemory {
+				assert.Equal(t, data, cpu.read(address), "incorrect value at $%04X", address)
+			}
+			assert.Equal(t, tc.expectedPC, cpu.pc, "incorrect program counter")
+			assert.Equal(t, tc.expectedCycles, cpu.clockCount, "incorrect cycles")
+		})
+	}
 }
@@ -0,0 +1,450 @@
+package debugger
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+)
+
+const (
+	// disassemblyBefore is the number of instructions before the program
+	// counter shown by dis without an address.
+	disassemblyBefore = 4
+	// disassemblyLength is the number of instructions shown by dis from the
+	// program counter or an address.
+	disassemblyLength = 8
+	// dumpLength is the number of bytes shown by mem without a length.
+	dumpLength = 64
+	// dumpWidth is the number of bytes on each line of mem.
+	dumpWidth = 16
+)
+
+// opcodes that return from subroutines and interrupts, which end a finish.
+const (
+	opcodeRTI = 0x40
+	opcodeRTS = 0x60
+)
+
+// command is a debugger command.
+type command struct {
+	names []string // names are the full name followed by any abbreviations.
+	args  string
+	help  string
+	run   func(d *Debugger, args []string) error
+}
+
+// commands are the debugger commands, in the order listed by help. They are set
+// by init as help refers to them.
+var commands []command
+
+func init() {
+	commands = []command{
+		{[]string{"step", "s"}, "[count]", "execute count instructions, 1 by default", (*Debugger).step},
+		{[]string{"next", "n"}, "", "execute an instruction, running subroutines called by jsr to their return", (*Debugger).next},
+		{[]string{"finish", "out"}, "", "run until the current subroutine or interrupt handler returns", (*Debugger).finish},
+		{[]string{"until", "u"}, "address", "run until the program counter reaches address", (*Debugger).until},
+		{[]string{"continue", "c"}, "", "run until a breakpoint, a jam or an interrupt", (*Debugger).cont},
+		{[]string{"regs", "r"}, "", "show the registers, flags and cycle count", (*Debugger).regs},
+		{[]string{"mem", "x"}, "address [length]", "show length bytes of memory from address in hex", (*Debugger).mem},
+		{[]string{"set"}, "address byte...", "write bytes to memory from address", (*Debugger).set},
+		{[]string{"dis", "d"}, "[address] [count]", "disassemble count instructions from address, or around the program counter", (*Debugger).dis},
+		{[]string{"break", "b"}, "address", "add a breakpoint at address", (*Debugger).addBreak},
+		{[]string{"delete"}, "[id]", "delete a breakpoint, or all of them", (*Debugger).deleteBreak},
+		{[]string{"breaks"}, "", "list the breakpoints", (*Debugger).listBreaks},
+		{[]string{"reset"}, "", "reset the console", (*Debugger).reset},
+		{[]string{"source"}, "file", "execute the commands in a script file", (*Debugger).source},
+		{[]string{"history"}, "", "list the commands executed, which !n repeats", (*Debugger).listHistory},
+		{[]string{"help", "h"}, "", "list the commands", (*Debugger).help},
+		{[]string{"quit", "q"}, "", "end the session", (*Debugger).quit},
+	}
+}
+
+// lookupCommand returns the command with a name or abbreviation.
+func lookupCommand(name string) (command, bool) {
+	name = strings.ToLower(name)
+	for _, c := range commands {
+		for _, n := range c.names {
+			if n == name {
+				return c, true
+			}
+		}
+	}
+	return command{}, false
+}
+
+// expectArgs returns an error unless the number of arguments is within range.
+func expectArgs(args []string, min, max int) error {
+	switch {
+	case len(args) >= min && len(args) <= max:
+		return nil
+	case min == max:
+		return fmt.Errorf("expected %d arguments, got %d", min, len(args))
+	default:
+		return fmt.Errorf("expected %d to %d arguments, got %d", min, max, len(args))
+	}
+}
+
+// parseHex parses a hexadecimal number up to max, written with or without a
+// leading '$' or "0x".
+func parseHex(s string, max int) (int, bool) {
+	digits := strings.TrimPrefix(s, "$")
+	if digits == s {
+		digits = strings.TrimPrefix(strings.ToLower(s), "0x")
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || int(value) > max {
+		return 0, false
+	}
+	return int(value), true
+}
+
+// parseCount parses a positive decimal count, or returns n if there is no
+// argument.
+func parseCount(args []string, i int, n int) (int, error) {
+	if len(args) <= i {
+		return n, nil
+	}
+	count, err := strconv.Atoi(args[i])
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("invalid count %q", args[i])
+	}
+	return count, nil
+}
+
+// address parses an address written in hex or, with Symbols, as a name with
+// an optional decimal offset, e.g. "reset+2".
+func (d *Debugger) address(s string) (uint16, error) {
+	if d.symbols != nil {
+		name, offset := s, 0
+		if i := strings.LastIndexByte(s, '+'); i > 0 {
+			n, err := strconv.Atoi(s[i+1:])
+			if err == nil {
+				name, offset = s[:i], n
+			}
+		}
+		if address, ok := d.symbols.Address(name); ok {
+			return address + uint16(offset), nil
+		}
+	}
+	if address, ok := parseHex(s, 0xffff); ok {
+		return uint16(address), nil
+	}
+	return 0, fmt.Errorf("unknown address %q", s)
+}
+
+// step executes a number of instructions.
+func (d *Debugger) step(args []string) error {
+	if err := expectArgs(args, 0, 1); err != nil {
+		return err
+	}
+	count, err := parseCount(args, 0, 1)
+	if err != nil {
+		return err
+	}
+	d.run(func(byte) bool {
+		count--
+		return count == 0
+	})
+	return nil
+}
+
+// next executes an instruction, stepping over a subroutine call by running
+// until it returns to the instruction after the jsr.
+func (d *Debugger) next(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	c := d.machine.CPU()
+	in := d.disassembler().Decode(c.GetProgramCounter())
+	if in.Mnemonic != "JSR" {
+		return d.step(nil)
+	}
+	returnAddress := in.Address + uint16(len(in.Bytes))
+	stackPointer := c.GetStackPointer()
+	d.run(func(byte) bool {
+		c := d.machine.CPU()
+		return c.GetProgramCounter() == returnAddress && c.GetStackPointer() >= stackPointer
+	})
+	return nil
+}
+
+// finish runs until a return pops the stack above where it is now, leaving the
+// current subroutine or interrupt handler.
+func (d *Debugger) finish(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	stackPointer := d.machine.CPU().GetStackPointer()
+	d.run(func(opcode byte) bool {
+		return (opcode == opcodeRTS || opcode == opcodeRTI) && d.machine.CPU().GetStackPointer() > stackPointer
+	})
+	return nil
+}
+
+// until runs until the program counter reaches an address.
+func (d *Debugger) until(args []string) error {
+	if err := expectArgs(args, 1, 1); err != nil {
+		return err
+	}
+	address, err := d.address(args[0])
+	if err != nil {
+		return err
+	}
+	d.run(func(byte) bool {
+		return d.machine.CPU().GetProgramCounter() == address
+	})
+	return nil
+}
+
+// cont runs until stopped by a breakpoint, a jam or an interrupt.
+func (d *Debugger) cont(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	d.run(func(byte) bool { return false })
+	return nil
+}
+
+// flagNames are the letters of the status flags from bit 7 to bit 0.
+var flagNames = []struct {
+	flag   cpu.Flag
+	letter byte
+}{
+	{cpu.N, 'N'}, {cpu.V, 'V'}, {cpu.U, 'U'}, {cpu.B, 'B'},
+	{cpu.D, 'D'}, {cpu.I, 'I'}, {cpu.Z, 'Z'}, {cpu.C, 'C'},
+}
+
+// regs shows the registers, with the set flags in upper case and the clear
+// ones in lower case.
+func (d *Debugger) regs(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	c := d.machine.CPU()
+	var status byte
+	flags := make([]byte, len(flagNames))
+	for i, f := range flagNames {
+		flags[i] = f.letter + 'a' - 'A'
+		if c.GetStatusFlag(f.flag) == 1 {
+			flags[i] = f.letter
+			status |= byte(f.flag)
+		}
+	}
+	d.printf("A=$%02X X=$%02X Y=$%02X SP=$%02X PC=%s P=$%02X [%s] cycles=%d\n",
+		c.GetAccumulator(), c.GetX(), c.GetY(), c.GetStackPointer(), d.describe(c.GetProgramCounter()),
+		status, flags, d.machine.Cycles())
+	return nil
+}
+
+// mem shows memory in hex and ASCII, without the side effects of reading.
+func (d *Debugger) mem(args []string) error {
+	if err := expectArgs(args, 1, 2); err != nil {
+		return err
+	}
+	address, err := d.address(args[0])
+	if err != nil {
+		return err
+	}
+	length, err := parseCount(args, 1, dumpLength)
+	if err != nil {
+		return err
+	}
+
+	b := d.machine.Bus()
+	for row := 0; row < length; row += dumpWidth {
+		var hex, ascii strings.Builder
+		for i := row; i < row+dumpWidth; i++ {
+			if i >= length {
+				hex.WriteString("   ")
+				continue
+			}
+			data := b.Peek(address + uint16(i))
+			fmt.Fprintf(&hex, " %02X", data)
+			if data < 0x20 || data > 0x7e {
+				data = '.'
+			}
+			ascii.WriteByte(data)
+		}
+		d.printf("$%04X:%s  |%s|\n", address+uint16(row), hex.String(), ascii.String())
+	}
+	return nil
+}
+
+// set writes bytes to memory through the bus, as the CPU would.
+func (d *Debugger) set(args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected an address and at least one byte")
+	}
+	address, err := d.address(args[0])
+	if err != nil {
+		return err
+	}
+	data := make([]byte, len(args)-1)
+	for i, arg := range args[1:] {
+		value, ok := parseHex(arg, 0xff)
+		if !ok {
+			return fmt.Errorf("invalid byte %q", arg)
+		}
+		data[i] = byte(value)
+	}
+	for i, value := range data {
+		d.machine.Bus().Write(address+uint16(i), value)
+	}
+	return nil
+}
+
+// dis disassembles from an address, or from a few instructions before the
+// program counter.
+func (d *Debugger) dis(args []string) error {
+	if err := expectArgs(args, 0, 2); err != nil {
+		return err
+	}
+	dis := d.disassembler()
+	var address uint16
+	count := disassemblyLength
+	if len(args) > 0 {
+		var err error
+		if address, err = d.address(args[0]); err != nil {
+			return err
+		}
+		if count, err = parseCount(args, 1, disassemblyLength); err != nil {
+			return err
+		}
+	} else {
+		pc := d.machine.CPU().GetProgramCounter()
+		before := d.before(dis, pc, disassemblyBefore)
+		for _, a := range before {
+			d.printInstruction(dis.Decode(a))
+		}
+		address = pc
+	}
+	for i := 0; i < count; i++ {
+		in := dis.Decode(address)
+		d.printInstruction(in)
+		address += uint16(len(in.Bytes))
+	}
+	return nil
+}
+
+// before returns the addresses of up to n instructions leading to an address.
+// As instructions vary in length, it decodes from the furthest address that
+// lines up with the address, which is most likely right for code.
+func (d *Debugger) before(dis *cpu.Disassembler, address uint16, n int) []uint16 {
+	for start := int(address) - 3*n; start < int(address); start++ {
+		if start < 0 {
+			continue
+		}
+		var addresses []uint16
+		a := start
+		for a < int(address) {
+			addresses = append(addresses, uint16(a))
+			a += len(dis.Decode(uint16(a)).Bytes)
+		}
+		if a == int(address) {
+			if len(addresses) > n {
+				addresses = addresses[len(addresses)-n:]
+			}
+			return addresses
+		}
+	}
+	return nil
+}
+
+// addBreak adds a breakpoint.
+func (d *Debugger) addBreak(args []string) error {
+	if err := expectArgs(args, 1, 1); err != nil {
+		return err
+	}
+	address, err := d.address(args[0])
+	if err != nil {
+		return err
+	}
+	if id, ok := d.breakpointAt(address); ok {
+		return fmt.Errorf("breakpoint %d is already at %s", id, d.describe(address))
+	}
+	id := d.addBreakpoint(address)
+	d.printf("breakpoint %d at %s\n", id, d.describe(address))
+	return nil
+}
+
+// deleteBreak deletes a breakpoint by ID, or every breakpoint.
+func (d *Debugger) deleteBreak(args []string) error {
+	if err := expectArgs(args, 0, 1); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		d.breakpoints = nil
+		return nil
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || !d.deleteBreakpoint(id) {
+		return fmt.Errorf("no breakpoint %q", args[0])
+	}
+	return nil
+}
+
+// listBreaks lists the breakpoints.
+func (d *Debugger) listBreaks(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	if len(d.breakpoints) == 0 {
+		d.printf("no breakpoints\n")
+	}
+	for _, b := range d.breakpoints {
+		d.printf("%d: %s\n", b.id, d.describe(b.address))
+	}
+	return nil
+}
+
+// reset resets the console and runs its reset sequence, showing the first
+// instruction.
+func (d *Debugger) reset(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	d.machine.Reset()
+	d.machine.StepInstruction()
+	d.printLocation()
+	return nil
+}
+
+// source executes a script.
+func (d *Debugger) source(args []string) error {
+	if err := expectArgs(args, 1, 1); err != nil {
+		return err
+	}
+	return d.Source(args[0])
+}
+
+// listHistory lists the commands executed, numbered for "!n".
+func (d *Debugger) listHistory(args []string) error {
+	if err := expectArgs(args, 0, 0); err != nil {
+		return err
+	}
+	for i, line := range d.history {
+		d.printf("%4d  %s\n", i+1, line)
+	}
+	return nil
+}
+
+// help lists the commands.
+func (d *Debugger) help(args []string) error {
+	for _, c := range commands {
+		usage := strings.Join(c.names, ", ")
+		if c.args != "" {
+			usage += " " + c.args
+		}
+		d.printf("  %-30s %s\n", usage, c.help)
+	}
+	d.printf("Addresses are hex, e.g. $8000, or symbol names. An empty line repeats the last command.\n")
+	return nil
+}
+
+// quit ends the session.
+func (d *Debugger) quit(args []string) error {
+	return ErrQuit
+}
@@ -0,0 +1,292 @@
+package debugger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+)
+
+// prompt is written before each command read by Run.
+const prompt = "(goNES) "
+
+// maxSourceDepth is the deepest that scripts may source other scripts.
+const maxSourceDepth = 8
+
+// ErrQuit is returned by Execute for the quit command.
+var ErrQuit = errors.New("quit")
+
+// Machine is the system a Debugger runs, such as a console.NES.
+type Machine interface {
+	CPU() *cpu.Mos6502
+	Bus() *bus.Bus
+	Cycles() uint64
+	StepInstruction()
+	Reset()
+}
+
+// Symbols names addresses for a Debugger and finds the addresses of names, as a
+// symbols.Resolver does.
+type Symbols interface {
+	cpu.Labeler
+	Address(name string) (uint16, bool)
+}
+
+// breakpoint stops a run when the CPU reaches its address.
+type breakpoint struct {
+	id      int
+	address uint16
+}
+
+// Debugger steps a Machine an instruction at a time under the control of
+// commands, read interactively by Run or from a script by Source.
+type Debugger struct {
+	machine   Machine
+	out       io.Writer
+	formatter cpu.Formatter
+	symbols   Symbols
+
+	breakpoints []breakpoint
+	nextID      int
+
+	history     []string
+	sourceDepth int
+
+	// interrupted is set by Interrupt to stop a run, and accessed atomically
+	interrupted int32
+}
+
+// New constructs and returns a pointer to a Debugger of a Machine, writing to
+// out and disassembling in the syntax of a Formatter.
+func New(m Machine, out io.Writer, f cpu.Formatter) *Debugger {
+	return &Debugger{
+		machine:   m,
+		out:       out,
+		formatter: f,
+		nextID:    1,
+	}
+}
+
+// SetSymbols sets the Symbols that name addresses in disassembly and that
+// commands may give in place of addresses.
+func (d *Debugger) SetSymbols(s Symbols) {
+	d.symbols = s
+}
+
+// Interrupt stops a run in progress after its current instruction. It may be
+// called from another goroutine, such as a signal handler.
+func (d *Debugger) Interrupt() {
+	atomic.StoreInt32(&d.interrupted, 1)
+}
+
+// printf writes formatted output.
+func (d *Debugger) printf(format string, args ...interface{}) {
+	fmt.Fprintf(d.out, format, args...)
+}
+
+// Run reads and executes commands until the quit command or the end of input,
+// writing a prompt before each. An empty line repeats the last command. Errors
+// are written to the output rather than ending the session.
+func (d *Debugger) Run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		d.printf(prompt)
+		if !scanner.Scan() {
+			d.printf("\n")
+			return scanner.Err()
+		}
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" && len(d.history) > 0 {
+			line = d.history[len(d.history)-1]
+		}
+		err := d.Execute(line)
+		if err == ErrQuit {
+			return nil
+		}
+		if err != nil {
+			d.printf("error: %v\n", err)
+		}
+	}
+}
+
+// Source executes the commands in a script file, one per line, stopping at the
+// first that fails. Blank lines and lines starting with '#' are skipped.
+func (d *Debugger) Source(path string) error {
+	if d.sourceDepth == maxSourceDepth {
+		return fmt.Errorf("scripts nested deeper than %d files", maxSourceDepth)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	d.sourceDepth++
+	defer func() { d.sourceDepth-- }()
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := d.Execute(line); err != nil {
+			if err == ErrQuit {
+				return err
+			}
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Execute executes a command line, recording it in the history. It returns
+// ErrQuit for the quit command.
+func (d *Debugger) Execute(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	if strings.HasPrefix(fields[0], "!") {
+		expanded, err := d.expandHistory(fields[0])
+		if err != nil {
+			return err
+		}
+		d.printf("%s\n", expanded)
+		return d.Execute(expanded)
+	}
+	d.history = append(d.history, line)
+
+	c, ok := lookupCommand(fields[0])
+	if !ok {
+		return fmt.Errorf("unknown command %q, try help", fields[0])
+	}
+	return c.run(d, fields[1:])
+}
+
+// expandHistory returns the command of a history reference, "!!" for the last
+// command or "!n" for the nth.
+func (d *Debugger) expandHistory(reference string) (string, error) {
+	if len(d.history) == 0 {
+		return "", errors.New("no commands in history")
+	}
+	if reference == "!!" {
+		return d.history[len(d.history)-1], nil
+	}
+	var n int
+	if _, err := fmt.Sscanf(reference, "!%d", &n); err != nil || n < 1 || n > len(d.history) {
+		return "", fmt.Errorf("no command %q in history", reference)
+	}
+	return d.history[n-1], nil
+}
+
+// disassembler returns a Disassembler of the CPU's memory naming addresses
+// with the Symbols.
+func (d *Debugger) disassembler() *cpu.Disassembler {
+	dis := d.machine.CPU().Disassembler()
+	if d.symbols != nil {
+		dis.SetLabeler(d.symbols)
+	}
+	return dis
+}
+
+// describe returns an address with the name of the symbol at or covering it,
+// e.g. "$8005 <reset+2>".
+func (d *Debugger) describe(address uint16) string {
+	if d.symbols != nil {
+		if name, offset, ok := d.symbols.Label(address); ok {
+			if offset != 0 {
+				return fmt.Sprintf("$%04X <%s+%d>", address, name, offset)
+			}
+			return fmt.Sprintf("$%04X <%s>", address, name)
+		}
+	}
+	return fmt.Sprintf("$%04X", address)
+}
+
+// printInstruction writes a decoded instruction, marking the one at the program
+// counter, after the label of its address.
+func (d *Debugger) printInstruction(in cpu.Instruction) {
+	if d.symbols != nil {
+		if name, offset, ok := d.symbols.Label(in.Address); ok && offset == 0 {
+			d.printf("%s:\n", name)
+		}
+	}
+	marker := "  "
+	if in.Address == d.machine.CPU().GetProgramCounter() {
+		marker = "=>"
+	}
+	text := d.formatter.Format(in)
+	if in.Comment != "" {
+		text = fmt.Sprintf("%-24s ; %s", text, strings.Split(in.Comment, "\n")[0])
+	}
+	d.printf("%s $%04X: %s\n", marker, in.Address, text)
+}
+
+// printLocation writes the instruction at the program counter.
+func (d *Debugger) printLocation() {
+	d.printInstruction(d.disassembler().Decode(d.machine.CPU().GetProgramCounter()))
+}
+
+// run steps the Machine until stop, given the opcode just executed, returns true,
+// a breakpoint is reached, the CPU jams or the run is interrupted, then writes
+// where it stopped.
+func (d *Debugger) run(stop func(opcode byte) bool) {
+	atomic.StoreInt32(&d.interrupted, 0)
+	for {
+		opcode := d.machine.Bus().Peek(d.machine.CPU().GetProgramCounter())
+		d.machine.StepInstruction()
+		c := d.machine.CPU()
+		pc := c.GetProgramCounter()
+		if c.Jammed() {
+			d.printf("CPU jammed at %s\n", d.describe(pc))
+			break
+		}
+		if id, ok := d.breakpointAt(pc); ok {
+			d.printf("breakpoint %d at %s\n", id, d.describe(pc))
+			break
+		}
+		if stop(opcode) {
+			break
+		}
+		if atomic.LoadInt32(&d.interrupted) != 0 {
+			d.printf("interrupted at %s\n", d.describe(pc))
+			break
+		}
+	}
+	d.printLocation()
+}
+
+// breakpointAt returns the breakpoint at an address.
+func (d *Debugger) breakpointAt(address uint16) (int, bool) {
+	for _, b := range d.breakpoints {
+		if b.address == address {
+			return b.id, true
+		}
+	}
+	return 0, false
+}
+
+// addBreakpoint adds a breakpoint at an address and returns its ID.
+func (d *Debugger) addBreakpoint(address uint16) int {
+	id := d.nextID
+	d.nextID++
+	d.breakpoints = append(d.breakpoints, breakpoint{id: id, address: address})
+	return id
+}
+
+// deleteBreakpoint deletes the breakpoint with an ID.
+func (d *Debugger) deleteBreakpoint(id int) bool {
+	for i, b := range d.breakpoints {
+		if b.id == id {
+			d.breakpoints = append(d.breakpoints[:i], d.breakpoints[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
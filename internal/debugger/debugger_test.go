@@ -0,0 +1,354 @@
+package debugger
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/asm"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/console"
+	"github.com/Jac0bDeal/goNES/internal/cpu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProgram doubles 1 twice into $10 through nested subroutines, then jams.
+const testProgram = `
+	.org $c000
+reset:
+	ldx #$ff
+	txs
+	lda #1
+	jsr double
+	jsr double
+	sta $10
+done:
+	.byte $02
+
+double:
+	asl a
+	jsr inner
+	rts
+inner:
+	nop
+	rts
+
+	.org $fffa
+	.word reset, reset, reset
+`
+
+// testSymbols names the exact addresses of the labels of a program.
+type testSymbols map[string]int
+
+func (s testSymbols) Label(address uint16) (string, int, bool) {
+	for name, value := range s {
+		if value == int(address) {
+			return name, 0, true
+		}
+	}
+	return "", 0, false
+}
+
+func (s testSymbols) Comment(uint16) (string, bool) {
+	return "", false
+}
+
+func (s testSymbols) Address(name string) (uint16, bool) {
+	value, ok := s[name]
+	return uint16(value), ok
+}
+
+// newTestDebugger returns a Debugger of a console running the test program,
+// with its labels as symbols, and the buffer it writes to.
+func newTestDebugger(t *testing.T) (*Debugger, *console.NES, *bytes.Buffer) {
+	program, err := asm.New(cpu.RP2A03).Assemble("test.s", []byte(testProgram))
+	require.NoError(t, err)
+	image, err := program.INES()
+	require.NoError(t, err)
+	cart, err := cartridge.New(image)
+	require.NoError(t, err)
+	n, err := console.New(cart, console.NTSC)
+	require.NoError(t, err)
+	// run the reset sequence
+	n.StepInstruction()
+
+	out := &bytes.Buffer{}
+	d := New(n, out, cpu.CA65Formatter{})
+	d.SetSymbols(testSymbols(program.Symbols))
+	return d, n, out
+}
+
+func TestDebugger_Execute(t *testing.T) {
+	testCases := []struct {
+		name        string
+		commands    []string
+		expectedPC  uint16
+		expectedA   uint8
+		expectedOut string
+	}{
+		{
+			name:        "step",
+			commands:    []string{"step"},
+			expectedPC:  0xc002,
+			expectedOut: "=> $C002: txs\n",
+		},
+		{
+			name:       "step count",
+			commands:   []string{"s 3"},
+			expectedPC: 0xc005,
+			expectedA:  1,
+		},
+		{
+			name:        "next over a subroutine",
+			commands:    []string{"step 3", "next"},
+			expectedPC:  0xc008,
+			expectedA:   2,
+			expectedOut: "=> $C008: jsr double\n",
+		},
+		{
+			name:       "next without a subroutine",
+			commands:   []string{"n"},
+			expectedPC: 0xc002,
+		},
+		{
+			name:       "finish nested subroutines",
+			commands:   []string{"until inner", "finish"},
+			expectedPC: 0xc012,
+			expectedA:  2,
+		},
+		{
+			name:       "finish a subroutine",
+			commands:   []string{"until double", "out"},
+			expectedPC: 0xc008,
+			expectedA:  2,
+		},
+		{
+			name:        "until an address",
+			commands:    []string{"u $c008"},
+			expectedPC:  0xc008,
+			expectedA:   2,
+			expectedOut: "=> $C008: jsr double\n",
+		},
+		{
+			name:        "continue to a breakpoint",
+			commands:    []string{"break inner", "continue", "c"},
+			expectedPC:  0xc013,
+			expectedA:   4,
+			expectedOut: "breakpoint 1 at $C013 <inner>\n",
+		},
+		{
+			name:        "continue to a jam",
+			commands:    []string{"c"},
+			expectedPC:  0xc00e,
+			expectedA:   4,
+			expectedOut: "CPU jammed at $C00E <double>\n",
+		},
+		{
+			name:        "reset",
+			commands:    []string{"step 5", "reset"},
+			expectedPC:  0xc000,
+			expectedOut: "reset:\n=> $C000: ldx #$FF\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, n, out := newTestDebugger(t)
+
+			for _, command := range tc.commands {
+				require.NoError(t, d.Execute(command))
+			}
+
+			assert.Equal(t, tc.expectedPC, n.CPU().GetProgramCounter())
+			assert.Equal(t, tc.expectedA, n.CPU().GetAccumulator())
+			assert.Contains(t, out.String(), tc.expectedOut)
+		})
+	}
+}
+
+func TestDebugger_Execute_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		command       string
+		expectedError string
+	}{
+		{
+			name:          "unknown command",
+			command:       "jump",
+			expectedError: `unknown command "jump", try help`,
+		},
+		{
+			name:          "unknown address",
+			command:       "until nowhere",
+			expectedError: `unknown address "nowhere"`,
+		},
+		{
+			name:          "address out of range",
+			command:       "mem $10000",
+			expectedError: `unknown address "$10000"`,
+		},
+		{
+			name:          "invalid count",
+			command:       "step 0",
+			expectedError: `invalid count "0"`,
+		},
+		{
+			name:          "missing argument",
+			command:       "break",
+			expectedError: "expected 1 arguments, got 0",
+		},
+		{
+			name:          "too many arguments",
+			command:       "dis $c000 4 4",
+			expectedError: "expected 0 to 2 arguments, got 3",
+		},
+		{
+			name:          "invalid byte",
+			command:       "set $0300 100",
+			expectedError: `invalid byte "100"`,
+		},
+		{
+			name:          "unknown breakpoint",
+			command:       "delete 1",
+			expectedError: `no breakpoint "1"`,
+		},
+		{
+			name:          "empty history",
+			command:       "!!",
+			expectedError: "no commands in history",
+		},
+		{
+			name:          "quit",
+			command:       "quit",
+			expectedError: ErrQuit.Error(),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, _, _ := newTestDebugger(t)
+
+			assert.EqualError(t, d.Execute(tc.command), tc.expectedError)
+		})
+	}
+}
+
+func TestDebugger_Regs(t *testing.T) {
+	d, _, out := newTestDebugger(t)
+	require.NoError(t, d.Execute("step 3"))
+	out.Reset()
+
+	require.NoError(t, d.Execute("regs"))
+
+	assert.Equal(t, "A=$01 X=$FF Y=$00 SP=$FF PC=$C005 P=$20 [nvUbdizc] cycles=14\n", out.String())
+}
+
+func TestDebugger_Mem(t *testing.T) {
+	d, _, out := newTestDebugger(t)
+
+	require.NoError(t, d.Execute("set $0300 48 69 00 ff"))
+	require.NoError(t, d.Execute("x 0300 20"))
+
+	assert.Equal(t,
+		"$0300: 48 69 00 FF 00 00 00 00 00 00 00 00 00 00 00 00  |Hi..............|\n"+
+			"$0310: 00 00 00 00                                      |....|\n",
+		out.String())
+}
+
+func TestDebugger_Dis(t *testing.T) {
+	d, _, out := newTestDebugger(t)
+	require.NoError(t, d.Execute("until double"))
+	out.Reset()
+
+	require.NoError(t, d.Execute("dis"))
+
+	assert.Equal(t, strings.Join([]string{
+		"   $C005: jsr double",
+		"   $C008: jsr double",
+		"   $C00B: sta $10",
+		"done:",
+		"   $C00D: jam",
+		"double:",
+		"=> $C00E: asl a",
+		"   $C00F: jsr inner",
+		"   $C012: rts",
+		"inner:",
+		"   $C013: nop",
+		"   $C014: rts",
+	}, "\n"), strings.Join(strings.Split(out.String(), "\n")[:12], "\n"))
+}
+
+func TestDebugger_Breakpoints(t *testing.T) {
+	d, _, out := newTestDebugger(t)
+
+	require.NoError(t, d.Execute("b double"))
+	require.NoError(t, d.Execute("b $c013"))
+	require.NoError(t, d.Execute("delete 1"))
+	require.NoError(t, d.Execute("b done"))
+	require.NoError(t, d.Execute("breaks"))
+	assert.EqualError(t, d.Execute("b done"), "breakpoint 3 is already at $C00D <done>")
+
+	assert.Equal(t, strings.Join([]string{
+		"breakpoint 1 at $C00E <double>",
+		"breakpoint 2 at $C013 <inner>",
+		"breakpoint 3 at $C00D <done>",
+		"2: $C013 <inner>",
+		"3: $C00D <done>",
+		"",
+	}, "\n"), out.String())
+
+	out.Reset()
+	require.NoError(t, d.Execute("delete"))
+	require.NoError(t, d.Execute("breaks"))
+	assert.Equal(t, "no breakpoints\n", out.String())
+}
+
+func TestDebugger_History(t *testing.T) {
+	d, n, out := newTestDebugger(t)
+
+	require.NoError(t, d.Execute("step"))
+	require.NoError(t, d.Execute("!!"))
+	require.NoError(t, d.Execute("step 2"))
+	require.NoError(t, d.Execute("!1"))
+	assert.EqualError(t, d.Execute("!9"), `no command "!9" in history`)
+	out.Reset()
+	require.NoError(t, d.Execute("history"))
+
+	assert.Equal(t, uint16(0xc00f), n.CPU().GetProgramCounter())
+	assert.Equal(t, "   1  step\n   2  step\n   3  step 2\n   4  step\n   5  history\n", out.String())
+}
+
+func TestDebugger_Run(t *testing.T) {
+	d, n, out := newTestDebugger(t)
+
+	err := d.Run(strings.NewReader("step\n\nbogus\nquit\nstep\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0xc003), n.CPU().GetProgramCounter())
+	assert.Contains(t, out.String(), "(goNES) error: unknown command \"bogus\", try help\n(goNES) ")
+}
+
+func TestDebugger_Source(t *testing.T) {
+	dir, err := ioutil.TempDir("", "debugger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	script := filepath.Join(dir, "script.txt")
+	require.NoError(t, ioutil.WriteFile(script, []byte("# run to the first call\nuntil double\n\nsource nested.txt\n"), 0644))
+	nested := filepath.Join(dir, "nested.txt")
+	require.NoError(t, ioutil.WriteFile(nested, []byte("finish\n"), 0644))
+
+	d, n, _ := newTestDebugger(t)
+	err = d.Execute("source " + script)
+
+	// a relative script is found from the working directory, not the script
+	assert.EqualError(t, err, script+`:4: open nested.txt: no such file or directory`)
+	assert.Equal(t, uint16(0xc00e), n.CPU().GetProgramCounter())
+
+	require.NoError(t, ioutil.WriteFile(script, []byte("until double\nsource "+nested+"\n"), 0644))
+	d, n, _ = newTestDebugger(t)
+	require.NoError(t, d.Source(script))
+	assert.Equal(t, uint16(0xc008), n.CPU().GetProgramCounter())
+}
@@ -0,0 +1,58 @@
+package ppu
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Palette holds the colours of the 2C02's 64 palette entries, which are what
+// the values in the frame buffer index.
+var Palette = color.Palette{
+	rgb(0x54, 0x54, 0x54), rgb(0x00, 0x1e, 0x74), rgb(0x08, 0x10, 0x90), rgb(0x30, 0x00, 0x88),
+	rgb(0x44, 0x00, 0x64), rgb(0x5c, 0x00, 0x30), rgb(0x54, 0x04, 0x00), rgb(0x3c, 0x18, 0x00),
+	rgb(0x20, 0x2a, 0x00), rgb(0x08, 0x3a, 0x00), rgb(0x00, 0x40, 0x00), rgb(0x00, 0x3c, 0x00),
+	rgb(0x00, 0x32, 0x3c), rgb(0x00, 0x00, 0x00), rgb(0x00, 0x00, 0x00), rgb(0x00, 0x00, 0x00),
+	rgb(0x98, 0x96, 0x98), rgb(0x08, 0x4c, 0xc4), rgb(0x30, 0x32, 0xec), rgb(0x5c, 0x1e, 0xe4),
+	rgb(0x88, 0x14, 0xb0), rgb(0xa0, 0x14, 0x64), rgb(0x98, 0x22, 0x20), rgb(0x78, 0x3c, 0x00),
+	rgb(0x54, 0x5a, 0x00), rgb(0x28, 0x72, 0x00), rgb(0x08, 0x7c, 0x00), rgb(0x00, 0x76, 0x28),
+	rgb(0x00, 0x66, 0x78), rgb(0x00, 0x00, 0x00), rgb(0x00, 0x00, 0x00), rgb(0x00, 0x00, 0x00),
+	rgb(0xec, 0xee, 0xec), rgb(0x4c, 0x9a, 0xec), rgb(0x78, 0x7c, 0xec), rgb(0xb0, 0x62, 0xec),
+	rgb(0xe4, 0x54, 0xec), rgb(0xec, 0x58, 0xb4), rgb(0xec, 0x6a, 0x64), rgb(0xd4, 0x88, 0x20),
+	rgb(0xa0, 0xaa, 0x00), rgb(0x74, 0xc4, 0x00), rgb(0x4c, 0xd0, 0x20), rgb(0x38, 0xcc, 0x6c),
+	rgb(0x38, 0xb4, 0xcc), rgb(0x3c, 0x3c, 0x3c), rgb(0x00, 0x00, 0x00), rgb(0x00, 0x00, 0x00),
+	rgb(0xec, 0xee, 0xec), rgb(0xa8, 0xcc, 0xec), rgb(0xbc, 0xbc, 0xec), rgb(0xd4, 0xb2, 0xec),
+	rgb(0xec, 0xae, 0xec), rgb(0xec, 0xae, 0xd4), rgb(0xec, 0xb4, 0xb0), rgb(0xe4, 0xc4, 0x90),
+	rgb(0xcc, 0xd2, 0x78), rgb(0xb4, 0xde, 0x78), rgb(0xa8, 0xe2, 0x90), rgb(0x98, 0xe2, 0xb4),
+	rgb(0xa0, 0xd6, 0xe4), rgb(0xa0, 0xa2, 0xa0), rgb(0x00, 0x00, 0x00), rgb(0x00, 0x00, 0x00),
+}
+
+// rgb returns an opaque colour.
+func rgb(r uint8, g uint8, b uint8) color.RGBA {
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}
+}
+
+// FrameBuffer returns the picture as palette indices, one byte per pixel in
+// rows of Width pixels. It is the buffer the PPU draws into, so it changes as
+// the next frame is drawn.
+func (p *PPU) FrameBuffer() []uint8 {
+	return p.pixels[:]
+}
+
+// Image returns the picture as an image using Palette. It shares the PPU's
+// frame buffer, so it changes as the next frame is drawn.
+func (p *PPU) Image() *image.Paletted {
+	return &image.Paletted{
+		Pix:     p.pixels[:],
+		Stride:  Width,
+		Rect:    image.Rect(0, 0, Width, Height),
+		Palette: Palette,
+	}
+}
+
+// RGBA returns a copy of the picture converted to RGBA.
+func (p *PPU) RGBA() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), p.Image(), image.Point{}, draw.Src)
+	return img
+}
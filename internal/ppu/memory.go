@@ -0,0 +1,63 @@
+package ppu
+
+import "github.com/Jac0bDeal/goNES/internal/cartridge"
+
+// read reads a byte from the PPU address space. The pattern tables at
+// $0000-$1FFF are on the cartridge, the nametables at $2000-$2FFF are mirrored
+// through $3EFF, and palette RAM at $3F00-$3F1F is mirrored through $3FFF.
+func (p *PPU) read(address uint16) uint8 {
+	address &= 0x3fff
+	switch {
+	case address < 0x2000:
+		return p.cart.PPURead(address)
+	case address < 0x3f00:
+		return p.nametables[p.nametableIndex(address)]
+	default:
+		return p.palette[paletteIndex(address)]
+	}
+}
+
+// write writes a byte to the PPU address space.
+func (p *PPU) write(address uint16, data uint8) {
+	address &= 0x3fff
+	switch {
+	case address < 0x2000:
+		p.cart.PPUWrite(address, data)
+	case address < 0x3f00:
+		p.nametables[p.nametableIndex(address)] = data
+	default:
+		p.palette[paletteIndex(address)] = data & 0x3f
+	}
+}
+
+// nametableIndex maps an address in $2000-$3EFF to an offset into nametable
+// RAM. The console only has 2KB, enough for two nametables, so the four
+// logical nametables are mirrored onto them as the cartridge wires it. Boards
+// with four-screen mirroring supply their own RAM for the other two.
+func (p *PPU) nametableIndex(address uint16) uint16 {
+	offset := address & 0x03ff
+	table := (address >> 10) & 0x03
+	switch p.cart.Mirroring() {
+	case cartridge.Horizontal:
+		table >>= 1
+	case cartridge.Vertical:
+		table &= 0x01
+	case cartridge.SingleScreenLower:
+		table = 0
+	case cartridge.SingleScreenUpper:
+		table = 1
+	}
+	return table<<10 | offset
+}
+
+// paletteIndex maps an address in $3F00-$3FFF to an offset into palette RAM.
+// The palette is mirrored every 32 bytes, and the backdrop entries of the
+// sprite palettes at $3F10/$3F14/$3F18/$3F1C mirror those of the background
+// palettes.
+func paletteIndex(address uint16) uint16 {
+	index := address & 0x1f
+	if index&0x13 == 0x10 {
+		index &^= 0x10
+	}
+	return index
+}
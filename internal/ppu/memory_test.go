@@ -0,0 +1,103 @@
+package ppu
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPPU_NametableMirroring(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mirroring cartridge.Mirroring
+		expected  [4]uint16
+	}{
+		{
+			name:      "horizontal",
+			mirroring: cartridge.Horizontal,
+			expected:  [4]uint16{0x0000, 0x0000, 0x0400, 0x0400},
+		},
+		{
+			name:      "vertical",
+			mirroring: cartridge.Vertical,
+			expected:  [4]uint16{0x0000, 0x0400, 0x0000, 0x0400},
+		},
+		{
+			name:      "single screen lower",
+			mirroring: cartridge.SingleScreenLower,
+			expected:  [4]uint16{0x0000, 0x0000, 0x0000, 0x0000},
+		},
+		{
+			name:      "single screen upper",
+			mirroring: cartridge.SingleScreenUpper,
+			expected:  [4]uint16{0x0400, 0x0400, 0x0400, 0x0400},
+		},
+		{
+			name:      "four screen",
+			mirroring: cartridge.FourScreen,
+			expected:  [4]uint16{0x0000, 0x0400, 0x0800, 0x0c00},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestPPU(tc.mirroring)
+
+			for table, expected := range tc.expected {
+				address := 0x2000 + uint16(table)*0x0400 + 0x0123
+				p.write(address, uint8(table+1))
+
+				assert.Equal(t, expected|0x0123, p.nametableIndex(address), "nametable %d", table)
+				assert.Equal(t, uint8(table+1), p.read(address+0x1000), "nametable %d mirror at $3000", table)
+			}
+		})
+	}
+}
+
+func TestPPU_PaletteMirroring(t *testing.T) {
+	testCases := []struct {
+		name          string
+		address       uint16
+		expectedIndex uint16
+	}{
+		{
+			name:          "background palette",
+			address:       0x3f05,
+			expectedIndex: 0x05,
+		},
+		{
+			name:          "sprite palette",
+			address:       0x3f15,
+			expectedIndex: 0x15,
+		},
+		{
+			name:          "sprite backdrop mirrors background backdrop",
+			address:       0x3f14,
+			expectedIndex: 0x04,
+		},
+		{
+			name:          "universal backdrop mirror",
+			address:       0x3f10,
+			expectedIndex: 0x00,
+		},
+		{
+			name:          "mirrored every 32 bytes",
+			address:       0x3fe5,
+			expectedIndex: 0x05,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIndex, paletteIndex(tc.address))
+		})
+	}
+}
+
+func TestPPU_PaletteWrite(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+
+	p.write(0x3f1c, 0xff)
+
+	assert.Equal(t, uint8(0x3f), p.palette[0x0c])
+	assert.Equal(t, uint8(0x3f), p.read(0x3f0c))
+}
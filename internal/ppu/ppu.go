@@ -0,0 +1,213 @@
+package ppu
+
+import "github.com/Jac0bDeal/goNES/internal/cartridge"
+
+// Frame timing of the NTSC 2C02.
+const (
+	DotsPerScanline   = 341
+	ScanlinesPerFrame = 262
+	VisibleScanlines  = 240
+
+	vblankScanline    = 241
+	preRenderScanline = ScanlinesPerFrame - 1
+)
+
+// Timing is the frame timing of a PPU variant. Every variant draws 240 visible
+// scanlines of 341 dots, but they differ in how long VBlank lasts.
+type Timing struct {
+	// ScanlinesPerFrame is the number of scanlines in a frame, the last of
+	// which is the pre-render scanline.
+	ScanlinesPerFrame int
+	// VBlankScanline is the scanline at which VBlank starts.
+	VBlankScanline int
+	// SkipOddDot is whether the last dot of the pre-render scanline is
+	// skipped on odd frames while rendering.
+	SkipOddDot bool
+}
+
+// Timings of the PPU variants.
+var (
+	// NTSCTiming is the timing of the 2C02 in NTSC consoles.
+	NTSCTiming = Timing{ScanlinesPerFrame: ScanlinesPerFrame, VBlankScanline: vblankScanline, SkipOddDot: true}
+	// PALTiming is the timing of the 2C07 in PAL consoles.
+	PALTiming = Timing{ScanlinesPerFrame: 312, VBlankScanline: 241}
+	// DendyTiming is the timing of the UMC 6538 in Dendy Famiclones, which
+	// holds off VBlank for 50 scanlines after the picture to stay compatible
+	// with NTSC games.
+	DendyTiming = Timing{ScanlinesPerFrame: 312, VBlankScanline: 291}
+)
+
+// PPUCTRL ($2000) bits.
+const (
+	ctrlNametable       uint8 = 0x03 // base nametable address
+	ctrlIncrement       uint8 = 0x04 // VRAM address increment per $2007 access, 1 or 32
+	ctrlSpriteTable     uint8 = 0x08 // 8x8 sprite pattern table address
+	ctrlBackgroundTable uint8 = 0x10 // background pattern table address
+	ctrlSpriteSize      uint8 = 0x20 // 8x8 or 8x16 sprites
+	ctrlNMI             uint8 = 0x80 // NMI at the start of VBlank
+)
+
+// PPUMASK ($2001) bits.
+const (
+	maskGrayscale      uint8 = 0x01
+	maskBackgroundLeft uint8 = 0x02 // show background in the leftmost 8 pixels
+	maskSpritesLeft    uint8 = 0x04 // show sprites in the leftmost 8 pixels
+	maskBackground     uint8 = 0x08
+	maskSprites        uint8 = 0x10
+)
+
+// PPUSTATUS ($2002) bits.
+const (
+	statusOverflow   uint8 = 0x20
+	statusSprite0Hit uint8 = 0x40
+	statusVBlank     uint8 = 0x80
+)
+
+// Cartridge is the part of a cartridge wired to the PPU: the pattern tables at
+// $0000-$1FFF and the mirroring of the nametables.
+type Cartridge interface {
+	PPURead(address uint16) uint8
+	PPUWrite(address uint16, data uint8)
+	Mirroring() cartridge.Mirroring
+}
+
+// PPU represents the Ricoh 2C02 Picture Processing Unit. It is attached to the
+// CPU bus at $2000-$3FFF, where its eight registers are mirrored every 8 bytes,
+// and has its own address space holding the cartridge's pattern tables, the
+// nametables and palette RAM.
+type PPU struct {
+	cart Cartridge
+
+	// Registers
+	ctrl    uint8 // $2000
+	mask    uint8 // $2001
+	status  uint8 // $2002
+	oamAddr uint8 // $2003
+
+	// Internal registers shared by scrolling and $2006/$2007 accesses
+	v uint16 // current VRAM address
+	t uint16 // temporary VRAM address, the address of the top left onscreen tile
+	x uint8  // fine X scroll
+	w bool   // write toggle for $2005 and $2006
+
+	readBuffer uint8 // data from the last $2007 read below the palette
+	latch      uint8 // the PPU's own data bus, read back from write-only registers
+
+	// Background pipeline
+	nextTile      uint8
+	nextAttribute uint8
+	nextPatternLo uint8
+	nextPatternHi uint8
+	patternLo     uint16
+	patternHi     uint16
+	attributeLo   uint16
+	attributeHi   uint16
+
+	// Sprite pipeline
+	secondaryOAM      [spritesPerScanline * 4]uint8
+	spriteCount       int
+	spriteZeroInRange bool
+	sprites           [spritesPerScanline]sprite
+
+	// Memory
+	oam        [256]uint8
+	nametables [4096]uint8
+	palette    [32]uint8
+	pixels     [Width * Height]uint8
+
+	// Timing
+	timing         Timing
+	scanline       int
+	dot            int
+	frame          uint64
+	suppressVBlank bool
+}
+
+// New constructs and returns a pointer to an NTSC PPU wired to a cartridge.
+func New(cart Cartridge) *PPU {
+	return &PPU{cart: cart, timing: NTSCTiming}
+}
+
+// SetTiming sets the frame timing of the PPU, which should be done before it
+// is first clocked.
+func (p *PPU) SetTiming(t Timing) {
+	p.timing = t
+}
+
+// preRenderScanline returns the pre-render scanline, the last of the frame.
+func (p *PPU) preRenderScanline() int {
+	return p.timing.ScanlinesPerFrame - 1
+}
+
+// Clock advances the PPU by one dot.
+func (p *PPU) Clock() {
+	p.render()
+
+	switch {
+	case p.scanline == p.timing.VBlankScanline && p.dot == 1:
+		if !p.suppressVBlank {
+			p.status |= statusVBlank
+		}
+		p.suppressVBlank = false
+	case p.scanline == p.preRenderScanline() && p.dot == 1:
+		p.status &^= statusVBlank | statusSprite0Hit | statusOverflow
+	}
+
+	p.dot++
+	// the NTSC PPU skips the last dot of the pre-render scanline on odd
+	// frames while rendering, so that the picture doesn't jitter
+	if p.timing.SkipOddDot && p.scanline == p.preRenderScanline() && p.dot == DotsPerScanline-1 && p.frame%2 == 1 && p.renderingEnabled() {
+		p.dot++
+	}
+	if p.dot == DotsPerScanline {
+		p.dot = 0
+		p.scanline++
+		if p.scanline == p.timing.ScanlinesPerFrame {
+			p.scanline = 0
+			p.frame++
+		}
+	}
+}
+
+// Reset signals the PPU that the console's reset button was pressed. Unlike a
+// power cycle, it leaves VRAM, OAM, the status register and the VRAM address
+// as they were.
+func (p *PPU) Reset() {
+	p.ctrl = 0
+	p.mask = 0
+	p.w = false
+	p.x = 0
+	p.t = 0
+	p.readBuffer = 0
+	p.scanline = 0
+	p.dot = 0
+	p.frame = 0
+}
+
+// NMI returns whether the PPU is asserting the CPU's NMI line, which it does
+// while in VBlank if NMIs are enabled through PPUCTRL.
+func (p *PPU) NMI() bool {
+	return p.ctrl&ctrlNMI > 0 && p.status&statusVBlank > 0
+}
+
+// Scanline returns the scanline being drawn, where 0-239 are visible and, on
+// NTSC, VBlank starts at 241 and 261 is the pre-render scanline.
+func (p *PPU) Scanline() int {
+	return p.scanline
+}
+
+// Dot returns the dot (PPU cycle) within the current scanline, 0-340.
+func (p *PPU) Dot() int {
+	return p.dot
+}
+
+// Frame returns the number of frames completed since power on.
+func (p *PPU) Frame() uint64 {
+	return p.frame
+}
+
+// renderingEnabled returns whether either the background or sprites are
+// being drawn.
+func (p *PPU) renderingEnabled() bool {
+	return p.mask&(maskBackground|maskSprites) > 0
+}
@@ -0,0 +1,240 @@
+package ppu
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/bus"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCartridge is a Cartridge with 8KB of CHR-RAM and fixed mirroring.
+type testCartridge struct {
+	chr       [0x2000]uint8
+	mirroring cartridge.Mirroring
+}
+
+func (c *testCartridge) PPURead(address uint16) uint8 {
+	return c.chr[address&0x1fff]
+}
+
+func (c *testCartridge) PPUWrite(address uint16, data uint8) {
+	c.chr[address&0x1fff] = data
+}
+
+func (c *testCartridge) Mirroring() cartridge.Mirroring {
+	return c.mirroring
+}
+
+// newTestPPU creates a PPU wired to a testCartridge with the given mirroring.
+func newTestPPU(mirroring cartridge.Mirroring) (*PPU, *testCartridge) {
+	cart := &testCartridge{mirroring: mirroring}
+	return New(cart), cart
+}
+
+// clockTo clocks the PPU until it reaches a scanline and dot.
+func clockTo(p *PPU, scanline int, dot int) {
+	for p.scanline != scanline || p.dot != dot {
+		p.Clock()
+	}
+}
+
+func TestPPU_Clock(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mask             uint8
+		frames           int
+		expectedScanline int
+		expectedDot      int
+		expectedClocks   int
+	}{
+		{
+			name:             "a frame is 341 dots by 262 scanlines",
+			frames:           1,
+			expectedScanline: 0,
+			expectedDot:      0,
+			expectedClocks:   DotsPerScanline * ScanlinesPerFrame,
+		},
+		{
+			name:             "odd frames are a dot shorter while rendering",
+			mask:             maskBackground,
+			frames:           2,
+			expectedScanline: 0,
+			expectedDot:      0,
+			expectedClocks:   2*DotsPerScanline*ScanlinesPerFrame - 1,
+		},
+		{
+			name:             "odd frames are full length while not rendering",
+			frames:           2,
+			expectedScanline: 0,
+			expectedDot:      0,
+			expectedClocks:   2 * DotsPerScanline * ScanlinesPerFrame,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestPPU(cartridge.Horizontal)
+			p.mask = tc.mask
+
+			clocks := 0
+			for p.Frame() < uint64(tc.frames) {
+				p.Clock()
+				clocks++
+			}
+
+			assert.Equal(t, tc.expectedScanline, p.Scanline())
+			assert.Equal(t, tc.expectedDot, p.Dot())
+			assert.Equal(t, tc.expectedClocks, clocks)
+		})
+	}
+}
+
+func TestPPU_VBlank(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+	p.status = statusSprite0Hit | statusOverflow
+
+	clockTo(p, vblankScanline, 1)
+	assert.Equal(t, uint8(0), p.status&statusVBlank)
+
+	p.Clock()
+	assert.Equal(t, statusVBlank, p.status&statusVBlank)
+
+	clockTo(p, preRenderScanline, 2)
+	assert.Equal(t, uint8(0), p.status)
+}
+
+func TestPPU_Timing(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		timing                 Timing
+		expectedVBlankScanline int
+		expectedClocks         int
+	}{
+		{
+			name:                   "NTSC",
+			timing:                 NTSCTiming,
+			expectedVBlankScanline: 241,
+			expectedClocks:         2*DotsPerScanline*262 - 1,
+		},
+		{
+			name:                   "PAL",
+			timing:                 PALTiming,
+			expectedVBlankScanline: 241,
+			expectedClocks:         2 * DotsPerScanline * 312,
+		},
+		{
+			name:                   "Dendy",
+			timing:                 DendyTiming,
+			expectedVBlankScanline: 291,
+			expectedClocks:         2 * DotsPerScanline * 312,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestPPU(cartridge.Horizontal)
+			p.SetTiming(tc.timing)
+			p.mask = maskBackground
+
+			clocks := 0
+			vblankScanline := -1
+			for p.Frame() < 2 {
+				p.Clock()
+				clocks++
+				if vblankScanline < 0 && p.status&statusVBlank > 0 {
+					vblankScanline = p.Scanline()
+				}
+			}
+
+			assert.Equal(t, tc.expectedVBlankScanline, vblankScanline)
+			assert.Equal(t, tc.expectedClocks, clocks)
+		})
+	}
+}
+
+func TestPPU_NMI(t *testing.T) {
+	t.Run("asserted during VBlank when enabled", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.Write(PPUCTRL, ctrlNMI)
+
+		clockTo(p, vblankScanline, 1)
+		assert.False(t, p.NMI())
+		p.Clock()
+		assert.True(t, p.NMI())
+
+		clockTo(p, preRenderScanline, 2)
+		assert.False(t, p.NMI())
+	})
+
+	t.Run("not asserted when disabled", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+
+		clockTo(p, vblankScanline, 2)
+		assert.False(t, p.NMI())
+	})
+
+	t.Run("asserted when enabled during VBlank", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+
+		clockTo(p, vblankScanline+1, 0)
+		p.Write(PPUCTRL, ctrlNMI)
+		assert.True(t, p.NMI())
+	})
+
+	t.Run("released by reading PPUSTATUS", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.Write(PPUCTRL, ctrlNMI)
+
+		clockTo(p, vblankScanline+1, 0)
+		p.Read(PPUSTATUS)
+		assert.False(t, p.NMI())
+	})
+
+	t.Run("suppressed by reading PPUSTATUS just before VBlank", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.Write(PPUCTRL, ctrlNMI)
+
+		clockTo(p, vblankScanline, 1)
+		assert.Equal(t, uint8(0), p.Read(PPUSTATUS)&statusVBlank)
+		p.Clock()
+		assert.False(t, p.NMI())
+		assert.Equal(t, uint8(0), p.Read(PPUSTATUS)&statusVBlank)
+	})
+}
+
+func TestPPU_Reset(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+	p.Write(PPUCTRL, 0xff)
+	p.Write(PPUADDR, 0x21)
+	p.Write(PPUADDR, 0x08)
+	p.Write(PPUDATA, 0x42)
+	p.Write(PPUMASK, 0xff)
+	p.Write(PPUSCROLL, 0x07)
+	p.status = statusVBlank
+
+	p.Reset()
+
+	assert.Equal(t, uint8(0), p.ctrl)
+	assert.Equal(t, uint8(0), p.mask)
+	assert.False(t, p.w)
+	assert.Equal(t, uint8(0), p.x)
+	assert.Equal(t, statusVBlank, p.status)
+	assert.Equal(t, uint16(0x2128), p.v)
+	assert.Equal(t, uint8(0x42), p.read(0x2108))
+}
+
+func TestPPU_AttachedToBus(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+	b := bus.NewNESBus()
+	require.NoError(t, b.Attach(p, bus.PPUStart, bus.PPUEnd))
+
+	b.Write(0x2006, 0x24)
+	b.Write(0x3ffe, 0x00)
+	b.Write(0x2007, 0x42)
+	p.status = statusVBlank
+
+	assert.Equal(t, uint8(0x42), p.read(0x2000))
+	assert.Equal(t, statusVBlank, b.Peek(0x3ffa)&0xe0)
+	assert.Equal(t, statusVBlank, b.Read(0x200a)&0xe0)
+	assert.Equal(t, uint8(0), b.Read(0x2002)&0xe0)
+}
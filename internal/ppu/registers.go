@@ -0,0 +1,135 @@
+package ppu
+
+// Register addresses, mirrored every 8 bytes through $3FFF.
+const (
+	PPUCTRL   uint16 = 0x2000
+	PPUMASK   uint16 = 0x2001
+	PPUSTATUS uint16 = 0x2002
+	OAMADDR   uint16 = 0x2003
+	OAMDATA   uint16 = 0x2004
+	PPUSCROLL uint16 = 0x2005
+	PPUADDR   uint16 = 0x2006
+	PPUDATA   uint16 = 0x2007
+)
+
+// Read reads one of the PPU's registers. Reading PPUSTATUS clears the VBlank
+// flag and the write toggle, and reading PPUDATA advances the VRAM address.
+// Write-only registers read back the value last on the PPU's data bus.
+func (p *PPU) Read(address uint16) uint8 {
+	switch 0x2000 | address&0x0007 {
+	case PPUSTATUS:
+		p.latch = p.status | p.latch&0x1f
+		p.status &^= statusVBlank
+		p.w = false
+		// reading just before VBlank starts means the flag is never seen, and
+		// no NMI occurs that frame
+		if p.scanline == p.timing.VBlankScanline && p.dot == 1 {
+			p.suppressVBlank = true
+		}
+	case OAMDATA:
+		p.latch = p.readOAM()
+	case PPUDATA:
+		p.latch = p.readData()
+		p.incrementAddress()
+	}
+	return p.latch
+}
+
+// Peek reads one of the PPU's registers without side effects.
+func (p *PPU) Peek(address uint16) uint8 {
+	switch 0x2000 | address&0x0007 {
+	case PPUSTATUS:
+		return p.status | p.latch&0x1f
+	case OAMDATA:
+		return p.readOAM()
+	case PPUDATA:
+		if p.v&0x3fff >= 0x3f00 {
+			return p.peekPalette()
+		}
+		return p.readBuffer
+	default:
+		return p.latch
+	}
+}
+
+// Write writes one of the PPU's registers.
+func (p *PPU) Write(address uint16, data uint8) {
+	p.latch = data
+	switch 0x2000 | address&0x0007 {
+	case PPUCTRL:
+		p.ctrl = data
+		p.t = p.t&^0x0c00 | uint16(data&ctrlNametable)<<10
+	case PPUMASK:
+		p.mask = data
+	case OAMADDR:
+		p.oamAddr = data
+	case OAMDATA:
+		p.oam[p.oamAddr] = data
+		p.oamAddr++
+	case PPUSCROLL:
+		if !p.w {
+			p.t = p.t&^0x001f | uint16(data>>3)
+			p.x = data & 0x07
+		} else {
+			p.t = p.t&^0x73e0 | uint16(data&0x07)<<12 | uint16(data>>3)<<5
+		}
+		p.w = !p.w
+	case PPUADDR:
+		if !p.w {
+			p.t = p.t&0x00ff | uint16(data&0x3f)<<8
+		} else {
+			p.t = p.t&0xff00 | uint16(data)
+			p.v = p.t
+		}
+		p.w = !p.w
+	case PPUDATA:
+		p.write(p.v, data)
+		p.incrementAddress()
+	}
+}
+
+// readOAM reads the sprite attribute byte selected by OAMADDR. Bits 2-4 of
+// sprite attributes don't exist and read back as 0.
+func (p *PPU) readOAM() uint8 {
+	data := p.oam[p.oamAddr]
+	if p.oamAddr&0x03 == 0x02 {
+		data &= 0xe3
+	}
+	return data
+}
+
+// readData handles a PPUDATA read. Reads below the palette are delayed through
+// the read buffer, returning the result of the previous read. Palette reads are
+// immediate, but still fill the buffer with the nametable byte underneath.
+func (p *PPU) readData() uint8 {
+	address := p.v & 0x3fff
+	if address >= 0x3f00 {
+		p.readBuffer = p.read(address - 0x1000)
+		return p.peekPalette()
+	}
+	data := p.readBuffer
+	p.readBuffer = p.read(address)
+	return data
+}
+
+// peekPalette returns what a PPUDATA read of palette RAM would, which only
+// drives the lower 6 bits.
+func (p *PPU) peekPalette() uint8 {
+	return p.read(p.v&0x3fff) | p.latch&0xc0
+}
+
+// incrementAddress advances the VRAM address after a PPUDATA access. While
+// rendering, the access instead bumps both the coarse X and Y scroll.
+func (p *PPU) incrementAddress() {
+	if p.renderingEnabled() && (p.scanline < VisibleScanlines || p.scanline == p.preRenderScanline()) {
+		p.incrementScrollX()
+		p.incrementScrollY()
+		return
+	}
+	if p.ctrl&ctrlIncrement > 0 {
+		p.v += 32
+	} else {
+		p.v++
+	}
+	p.v &= 0x7fff
+}
@@ -0,0 +1,233 @@
+package ppu
+
+import (
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/stretchr/testify/assert"
+)
+
+// setAddress points the VRAM address at an address through PPUADDR.
+func setAddress(p *PPU, address uint16) {
+	p.Write(PPUADDR, uint8(address>>8))
+	p.Write(PPUADDR, uint8(address))
+}
+
+func TestPPU_ScrollRegisters(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+
+	p.Write(PPUCTRL, 0x00)
+	assert.Equal(t, uint16(0x0000), p.t)
+
+	p.Read(PPUSTATUS)
+	assert.False(t, p.w)
+
+	p.Write(PPUSCROLL, 0x7d)
+	assert.Equal(t, uint16(0x000f), p.t)
+	assert.Equal(t, uint8(0x05), p.x)
+	assert.True(t, p.w)
+
+	p.Write(PPUSCROLL, 0x5e)
+	assert.Equal(t, uint16(0x616f), p.t)
+	assert.False(t, p.w)
+
+	p.Write(PPUADDR, 0x3d)
+	assert.Equal(t, uint16(0x3d6f), p.t)
+	assert.True(t, p.w)
+
+	p.Write(PPUADDR, 0xf0)
+	assert.Equal(t, uint16(0x3df0), p.t)
+	assert.Equal(t, uint16(0x3df0), p.v)
+	assert.False(t, p.w)
+
+	p.Write(PPUCTRL, 0x00)
+	assert.Equal(t, uint16(0x31f0), p.t)
+	assert.Equal(t, uint8(0x05), p.x)
+}
+
+func TestPPU_PPUADDR(t *testing.T) {
+	testCases := []struct {
+		name       string
+		writes     []uint8
+		expectedV  uint16
+		expectedT  uint16
+		expectedW  bool
+		readStatus bool
+	}{
+		{
+			name:      "two writes set the high then low byte",
+			writes:    []uint8{0x21, 0x08},
+			expectedV: 0x2108,
+			expectedT: 0x2108,
+		},
+		{
+			name:      "bit 14 of the high byte is cleared",
+			writes:    []uint8{0xff, 0x08},
+			expectedV: 0x3f08,
+			expectedT: 0x3f08,
+		},
+		{
+			name:      "one write only updates t",
+			writes:    []uint8{0x21},
+			expectedT: 0x2100,
+			expectedW: true,
+		},
+		{
+			name:       "reading PPUSTATUS resets the write toggle",
+			writes:     []uint8{0x21},
+			readStatus: true,
+			expectedT:  0x2100,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newTestPPU(cartridge.Horizontal)
+			for _, data := range tc.writes {
+				p.Write(PPUADDR, data)
+			}
+			if tc.readStatus {
+				p.Read(PPUSTATUS)
+			}
+
+			assert.Equal(t, tc.expectedV, p.v)
+			assert.Equal(t, tc.expectedT, p.t)
+			assert.Equal(t, tc.expectedW, p.w)
+		})
+	}
+}
+
+func TestPPU_PPUDATA(t *testing.T) {
+	t.Run("reads below the palette are buffered", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.nametables[0x0000] = 0x11
+		p.nametables[0x0001] = 0x22
+
+		setAddress(p, 0x2000)
+		p.readBuffer = 0x99
+		assert.Equal(t, uint8(0x99), p.Read(PPUDATA))
+		assert.Equal(t, uint8(0x11), p.Read(PPUDATA))
+		assert.Equal(t, uint8(0x22), p.Read(PPUDATA))
+		assert.Equal(t, uint16(0x2003), p.v)
+	})
+
+	t.Run("reads the pattern tables from the cartridge", func(t *testing.T) {
+		p, cart := newTestPPU(cartridge.Horizontal)
+		cart.chr[0x1234] = 0x42
+
+		setAddress(p, 0x1234)
+		p.Read(PPUDATA)
+		assert.Equal(t, uint8(0x42), p.Read(PPUDATA))
+	})
+
+	t.Run("palette reads are immediate and buffer the nametable underneath", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.palette[0x01] = 0x2a
+		p.nametables[0x0701] = 0x55
+
+		setAddress(p, 0x3f01)
+		assert.Equal(t, uint8(0x2a), p.Read(PPUDATA))
+		assert.Equal(t, uint8(0x55), p.readBuffer)
+	})
+
+	t.Run("palette reads return open bus in the upper 2 bits", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.palette[0x01] = 0x2a
+
+		p.Write(PPUADDR, 0xff)
+		p.Write(PPUADDR, 0xc1)
+		assert.Equal(t, uint8(0xea), p.Read(PPUDATA))
+	})
+
+	t.Run("writes go to VRAM and advance the address", func(t *testing.T) {
+		p, cart := newTestPPU(cartridge.Horizontal)
+
+		setAddress(p, 0x1fff)
+		p.Write(PPUDATA, 0x11)
+		p.Write(PPUDATA, 0x22)
+
+		assert.Equal(t, uint8(0x11), cart.chr[0x1fff])
+		assert.Equal(t, uint8(0x22), p.nametables[0x0000])
+		assert.Equal(t, uint16(0x2001), p.v)
+	})
+
+	t.Run("address increments by 32 when selected through PPUCTRL", func(t *testing.T) {
+		p, _ := newTestPPU(cartridge.Horizontal)
+		p.Write(PPUCTRL, ctrlIncrement)
+
+		setAddress(p, 0x2000)
+		p.Write(PPUDATA, 0x11)
+		p.Write(PPUDATA, 0x22)
+
+		assert.Equal(t, uint8(0x11), p.nametables[0x0000])
+		assert.Equal(t, uint8(0x22), p.nametables[0x0020])
+		assert.Equal(t, uint16(0x2040), p.v)
+	})
+}
+
+func TestPPU_PPUSTATUS(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+	p.status = statusVBlank | statusSprite0Hit
+	p.Write(PPUSCROLL, 0x00)
+	p.Write(PPUMASK, 0x1f)
+
+	assert.Equal(t, uint8(0xdf), p.Read(PPUSTATUS))
+	assert.Equal(t, statusSprite0Hit, p.status)
+	assert.False(t, p.w)
+	assert.Equal(t, uint8(0x5f), p.Read(PPUSTATUS))
+}
+
+func TestPPU_OAM(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+
+	p.Write(OAMADDR, 0xfd)
+	p.Write(OAMDATA, 0x11)
+	p.Write(OAMDATA, 0xff)
+	p.Write(OAMDATA, 0x22)
+	p.Write(OAMDATA, 0x33)
+	assert.Equal(t, uint8(0x01), p.oamAddr, "writes advance and wrap OAMADDR")
+
+	p.Write(OAMADDR, 0xfd)
+	assert.Equal(t, uint8(0x11), p.Read(OAMDATA))
+	assert.Equal(t, uint8(0xfd), p.oamAddr, "reads don't advance OAMADDR")
+
+	p.Write(OAMADDR, 0xfe)
+	assert.Equal(t, uint8(0xe3), p.Read(OAMDATA), "unimplemented attribute bits read as 0")
+
+	p.Write(OAMADDR, 0x00)
+	assert.Equal(t, uint8(0x33), p.Read(OAMDATA))
+}
+
+func TestPPU_WriteOnlyRegisters(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+
+	for _, address := range []uint16{PPUCTRL, PPUMASK, OAMADDR, PPUSCROLL, PPUADDR} {
+		p.Write(OAMDATA, 0x5a)
+
+		assert.Equal(t, uint8(0x5a), p.Read(address), "register $%04X", address)
+	}
+}
+
+func TestPPU_RegisterMirroring(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+
+	p.Write(0x3456, 0x21)
+	p.Write(0x2006, 0x08)
+
+	assert.Equal(t, uint16(0x2108), p.v)
+}
+
+func TestPPU_Peek(t *testing.T) {
+	p, _ := newTestPPU(cartridge.Horizontal)
+	p.status = statusVBlank
+	p.nametables[0x0000] = 0x11
+	setAddress(p, 0x2000)
+	p.Write(PPUSCROLL, 0x00)
+
+	assert.Equal(t, statusVBlank, p.Peek(PPUSTATUS)&0xe0)
+	assert.Equal(t, uint8(0x00), p.Peek(PPUDATA))
+
+	assert.Equal(t, statusVBlank, p.status)
+	assert.True(t, p.w)
+	assert.Equal(t, uint16(0x2000), p.v)
+	assert.Equal(t, uint8(0x00), p.readBuffer)
+}
@@ -0,0 +1,348 @@
+package ppu
+
+import "math/bits"
+
+// Dimensions of the picture.
+const (
+	Width  = 256
+	Height = VisibleScanlines
+)
+
+// spritesPerScanline is the number of sprites the PPU can draw on one scanline.
+const spritesPerScanline = 8
+
+// sprite is a sprite fetched for drawing on the current scanline.
+type sprite struct {
+	x          uint8
+	attributes uint8
+	patternLo  uint8
+	patternHi  uint8
+}
+
+// Sprite attribute bits.
+const (
+	attributePalette  uint8 = 0x03
+	attributePriority uint8 = 0x20 // behind the background
+	attributeFlipH    uint8 = 0x40
+	attributeFlipV    uint8 = 0x80
+)
+
+// render performs the work of the current dot on the visible and pre-render
+// scanlines: fetching background tiles and sprites for the following pixels
+// and drawing the current one.
+func (p *PPU) render() {
+	visible := p.scanline < VisibleScanlines
+	if !visible && p.scanline != p.preRenderScanline() {
+		return
+	}
+
+	if p.renderingEnabled() {
+		p.renderBackground()
+		p.renderSprites(visible)
+	}
+	if visible && p.dot >= 1 && p.dot <= Width {
+		p.drawPixel()
+	}
+}
+
+// renderBackground fetches background tiles into the shift registers, eight
+// dots per tile, and updates the scroll position in v as it moves across and
+// down the nametables.
+func (p *PPU) renderBackground() {
+	if (p.dot >= 2 && p.dot < 258) || (p.dot >= 321 && p.dot < 338) {
+		p.shiftBackground()
+
+		switch (p.dot - 1) % 8 {
+		case 0:
+			p.loadBackground()
+			p.nextTile = p.read(0x2000 | p.v&0x0fff)
+		case 2:
+			attribute := p.read(0x23c0 | p.v&0x0c00 | (p.v>>4)&0x38 | (p.v>>2)&0x07)
+			if p.v&0x0040 > 0 {
+				attribute >>= 4
+			}
+			if p.v&0x0002 > 0 {
+				attribute >>= 2
+			}
+			p.nextAttribute = attribute & 0x03
+		case 4:
+			p.nextPatternLo = p.read(p.backgroundPatternAddress())
+		case 6:
+			p.nextPatternHi = p.read(p.backgroundPatternAddress() + 8)
+		case 7:
+			p.incrementScrollX()
+		}
+	}
+
+	switch {
+	case p.dot == 256:
+		p.incrementScrollY()
+	case p.dot == 257:
+		p.loadBackground()
+		p.v = p.v&^0x041f | p.t&0x041f
+	case p.dot == 338 || p.dot == 340:
+		// unused nametable fetches, which some mappers watch for
+		p.nextTile = p.read(0x2000 | p.v&0x0fff)
+	case p.scanline == p.preRenderScanline() && p.dot >= 280 && p.dot <= 304:
+		p.v = p.v&^0x7be0 | p.t&0x7be0
+	}
+}
+
+// backgroundPatternAddress returns the address of the low bitplane of the row
+// of the next background tile at the fine Y scroll.
+func (p *PPU) backgroundPatternAddress() uint16 {
+	var table uint16
+	if p.ctrl&ctrlBackgroundTable > 0 {
+		table = 0x1000
+	}
+	return table | uint16(p.nextTile)<<4 | (p.v>>12)&0x07
+}
+
+// loadBackground loads the next tile into the low bytes of the background
+// shift registers.
+func (p *PPU) loadBackground() {
+	p.patternLo = p.patternLo&0xff00 | uint16(p.nextPatternLo)
+	p.patternHi = p.patternHi&0xff00 | uint16(p.nextPatternHi)
+
+	p.attributeLo &= 0xff00
+	if p.nextAttribute&0x01 > 0 {
+		p.attributeLo |= 0x00ff
+	}
+	p.attributeHi &= 0xff00
+	if p.nextAttribute&0x02 > 0 {
+		p.attributeHi |= 0x00ff
+	}
+}
+
+// shiftBackground shifts the background shift registers by one pixel.
+func (p *PPU) shiftBackground() {
+	p.patternLo <<= 1
+	p.patternHi <<= 1
+	p.attributeLo <<= 1
+	p.attributeHi <<= 1
+}
+
+// incrementScrollX moves v to the next tile across, wrapping into the
+// horizontally adjacent nametable.
+func (p *PPU) incrementScrollX() {
+	if p.v&0x001f == 31 {
+		p.v &^= 0x001f
+		p.v ^= 0x0400
+	} else {
+		p.v++
+	}
+}
+
+// incrementScrollY moves v to the next pixel row down, wrapping into the
+// vertically adjacent nametable after row 29. Coarse Y values of 30 and 31,
+// which point into the attribute table, wrap within the same nametable.
+func (p *PPU) incrementScrollY() {
+	if p.v&0x7000 != 0x7000 {
+		p.v += 0x1000
+		return
+	}
+	p.v &^= 0x7000
+
+	coarseY := (p.v & 0x03e0) >> 5
+	switch coarseY {
+	case 29:
+		coarseY = 0
+		p.v ^= 0x0800
+	case 31:
+		coarseY = 0
+	default:
+		coarseY++
+	}
+	p.v = p.v&^0x03e0 | coarseY<<5
+}
+
+// renderSprites evaluates which sprites are on the next scanline at the end of
+// the current one, then fetches their patterns during dots 257-320.
+func (p *PPU) renderSprites(visible bool) {
+	if p.dot == 257 {
+		if visible {
+			p.evaluateSprites()
+		} else {
+			// no sprites are drawn on the first scanline
+			p.spriteCount = 0
+			p.spriteZeroInRange = false
+		}
+	}
+	if p.dot < 257 || p.dot > 320 {
+		return
+	}
+
+	p.oamAddr = 0
+	slot := (p.dot - 257) / 8
+	switch (p.dot - 257) % 8 {
+	case 4:
+		p.sprites[slot].patternLo = p.read(p.spritePatternAddress(slot))
+	case 6:
+		p.sprites[slot].patternHi = p.read(p.spritePatternAddress(slot) + 8)
+	case 7:
+		p.loadSprite(slot)
+	}
+}
+
+// evaluateSprites copies the sprites in range of the current scanline into
+// secondary OAM, to be drawn on the next. Once eight sprites are found the
+// PPU keeps scanning to set the overflow flag, but a hardware bug increments
+// the byte offset within each sprite along with the sprite number, so it
+// compares the wrong bytes against the scanline.
+func (p *PPU) evaluateSprites() {
+	height := p.spriteHeight()
+	inRange := func(y uint8) bool {
+		row := p.scanline - int(y)
+		return row >= 0 && row < height
+	}
+
+	for i := range p.secondaryOAM {
+		p.secondaryOAM[i] = 0xff
+	}
+	p.spriteCount = 0
+	p.spriteZeroInRange = false
+
+	n := 0
+	for ; n < 64 && p.spriteCount < spritesPerScanline; n++ {
+		if !inRange(p.oam[n*4]) {
+			continue
+		}
+		if n == 0 {
+			p.spriteZeroInRange = true
+		}
+		copy(p.secondaryOAM[p.spriteCount*4:], p.oam[n*4:n*4+4])
+		p.spriteCount++
+	}
+
+	m := 0
+	for ; n < 64; n++ {
+		if inRange(p.oam[n*4+m]) {
+			p.status |= statusOverflow
+			break
+		}
+		m = (m + 1) & 0x03
+	}
+}
+
+// spritePatternAddress returns the address of the low bitplane of the row of
+// the sprite in a slot of secondary OAM that is drawn on the next scanline.
+// Empty slots fetch tile $FF.
+func (p *PPU) spritePatternAddress(slot int) uint16 {
+	y := p.secondaryOAM[slot*4]
+	tile := uint16(p.secondaryOAM[slot*4+1])
+	attributes := p.secondaryOAM[slot*4+2]
+
+	row := uint16(p.scanline - int(y))
+	if slot >= p.spriteCount {
+		row = 0
+	}
+
+	if p.ctrl&ctrlSpriteSize == 0 {
+		var table uint16
+		if p.ctrl&ctrlSpriteTable > 0 {
+			table = 0x1000
+		}
+		if attributes&attributeFlipV > 0 {
+			row = 7 - row
+		}
+		return table | tile<<4 | row&0x07
+	}
+
+	// 8x16 sprites select their pattern table with bit 0 of the tile number,
+	// and are made of an even numbered tile above the following odd one
+	table := (tile & 0x01) * 0x1000
+	tile &= 0xfe
+	if attributes&attributeFlipV > 0 {
+		row = 15 - row
+	}
+	if row&0x08 > 0 {
+		tile++
+	}
+	return table | tile<<4 | row&0x07
+}
+
+// loadSprite finishes loading the sprite in a slot of secondary OAM for
+// drawing on the next scanline. Empty slots are made transparent.
+func (p *PPU) loadSprite(slot int) {
+	s := &p.sprites[slot]
+	if slot >= p.spriteCount {
+		*s = sprite{x: 0xff}
+		return
+	}
+
+	s.attributes = p.secondaryOAM[slot*4+2]
+	s.x = p.secondaryOAM[slot*4+3]
+	if s.attributes&attributeFlipH > 0 {
+		s.patternLo = bits.Reverse8(s.patternLo)
+		s.patternHi = bits.Reverse8(s.patternHi)
+	}
+}
+
+// spriteHeight returns the height of sprites in pixels, 8 or 16.
+func (p *PPU) spriteHeight() int {
+	if p.ctrl&ctrlSpriteSize > 0 {
+		return 16
+	}
+	return 8
+}
+
+// drawPixel combines the background and sprite pixels at the current dot and
+// writes the resulting colour to the frame buffer.
+func (p *PPU) drawPixel() {
+	x := p.dot - 1
+
+	var backgroundPixel, backgroundPalette uint8
+	if p.mask&maskBackground > 0 && (x >= 8 || p.mask&maskBackgroundLeft > 0) {
+		bit := uint16(0x8000) >> p.x
+		if p.patternLo&bit > 0 {
+			backgroundPixel |= 0x01
+		}
+		if p.patternHi&bit > 0 {
+			backgroundPixel |= 0x02
+		}
+		if p.attributeLo&bit > 0 {
+			backgroundPalette |= 0x01
+		}
+		if p.attributeHi&bit > 0 {
+			backgroundPalette |= 0x02
+		}
+	}
+
+	var spritePixel, spritePalette uint8
+	spriteBehind, spriteZero := false, false
+	if p.mask&maskSprites > 0 && (x >= 8 || p.mask&maskSpritesLeft > 0) {
+		for i := 0; i < p.spriteCount; i++ {
+			s := &p.sprites[i]
+			offset := x - int(s.x)
+			if offset < 0 || offset >= 8 {
+				continue
+			}
+			pixel := (s.patternLo>>(7-offset))&0x01 | ((s.patternHi>>(7-offset))&0x01)<<1
+			if pixel == 0 {
+				continue
+			}
+			spritePixel = pixel
+			spritePalette = s.attributes&attributePalette + 4
+			spriteBehind = s.attributes&attributePriority > 0
+			spriteZero = i == 0 && p.spriteZeroInRange
+			break
+		}
+	}
+
+	if spriteZero && backgroundPixel > 0 && x != Width-1 {
+		p.status |= statusSprite0Hit
+	}
+
+	var address uint16
+	switch {
+	case spritePixel > 0 && (backgroundPixel == 0 || !spriteBehind):
+		address = uint16(spritePalette)<<2 | uint16(spritePixel)
+	case backgroundPixel > 0:
+		address = uint16(backgroundPalette)<<2 | uint16(backgroundPixel)
+	}
+	colour := p.read(0x3f00 | address)
+	if p.mask&maskGrayscale > 0 {
+		colour &= 0x30
+	}
+	p.pixels[p.scanline*Width+x] = colour
+}
@@ -0,0 +1,729 @@
+package ppu
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// backdrop is the colour of the backdrop in PPUs created by newRenderTestPPU.
+const backdrop uint8 = 0x3f
+
+// Tiles in the pattern tables of PPUs created by newRenderTestPPU.
+const (
+	tileSolid1 uint8 = 0x01
+	tileSolid2 uint8 = 0x02
+	tileSolid3 uint8 = 0x03
+	tileArrow  uint8 = 0x04 // asymmetric, to tell flips apart
+	tileDot    uint8 = 0x05 // a single opaque pixel at (0, 0)
+)
+
+// arrow is the pattern of tileArrow.
+var arrow = [8]string{
+	"11110000",
+	"11000000",
+	"10100000",
+	"10010000",
+	"00001000",
+	"00000100",
+	"00000010",
+	"00000001",
+}
+
+// setTile writes a tile to the pattern tables at an address, drawn as 8 rows of
+// 8 pixel values 0-3.
+func setTile(cart *testCartridge, address uint16, rows [8]string) {
+	for y, row := range rows {
+		var lo, hi uint8
+		for x, c := range row {
+			pixel := uint8(c - '0')
+			lo |= (pixel & 0x01) << (7 - x)
+			hi |= (pixel >> 1) << (7 - x)
+		}
+		cart.chr[address+uint16(y)] = lo
+		cart.chr[address+uint16(y)+8] = hi
+	}
+}
+
+// solid returns the rows of a tile filled with one pixel value.
+func solid(pixel string) [8]string {
+	var rows [8]string
+	for i := range rows {
+		rows[i] = pixel + pixel + pixel + pixel + pixel + pixel + pixel + pixel
+	}
+	return rows
+}
+
+// newRenderTestPPU creates a PPU with the test tiles in both pattern tables,
+// four separate blank nametables, all sprites offscreen and a palette where each entry
+// holds its own index, so a pixel's colour is the palette entry it used.
+func newRenderTestPPU() (*PPU, *testCartridge) {
+	p, cart := newTestPPU(cartridge.FourScreen)
+	for _, table := range []uint16{0x0000, 0x1000} {
+		setTile(cart, table|uint16(tileSolid1)<<4, solid("1"))
+		setTile(cart, table|uint16(tileSolid2)<<4, solid("2"))
+		setTile(cart, table|uint16(tileSolid3)<<4, solid("3"))
+		setTile(cart, table|uint16(tileArrow)<<4, arrow)
+		setTile(cart, table|uint16(tileDot)<<4, [8]string{"10000000"})
+	}
+	for i := uint16(1); i < 32; i++ {
+		p.write(0x3f00+i, uint8(i))
+	}
+	p.write(0x3f00, backdrop)
+	for i := range p.oam {
+		p.oam[i] = 0xff
+	}
+	return p, cart
+}
+
+// setSprite sets the Y, tile, attributes and X of a sprite in OAM.
+func setSprite(p *PPU, n int, y uint8, tile uint8, attributes uint8, x uint8) {
+	copy(p.oam[n*4:], []uint8{y, tile, attributes, x})
+}
+
+// renderFrame clocks the PPU through the pre-render scanline and a whole
+// frame, so the frame buffer holds a complete picture.
+func renderFrame(p *PPU) {
+	clockTo(p, preRenderScanline, 0)
+	clockTo(p, VisibleScanlines, 0)
+}
+
+// pixelAt returns the colour of a pixel in the frame buffer.
+func pixelAt(p *PPU, x int, y int) uint8 {
+	return p.FrameBuffer()[y*Width+x]
+}
+
+// rowAt returns the colours of 8 pixels of the frame buffer starting at a pixel.
+func rowAt(p *PPU, x int, y int) []uint8 {
+	return p.FrameBuffer()[y*Width+x : y*Width+x+8]
+}
+
+// repeat returns a row of 8 pixels of one colour.
+func repeat(colour uint8) []uint8 {
+	return bytes.Repeat([]uint8{colour}, 8)
+}
+
+func TestPPU_RenderBackground(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(p *PPU)
+		mask     uint8
+		x        int
+		y        int
+		expected []uint8
+	}{
+		{
+			name: "tile drawn at its nametable position",
+			setup: func(p *PPU) {
+				p.write(0x2000+3*32+2, tileSolid1)
+			},
+			mask:     maskBackground,
+			x:        16,
+			y:        24,
+			expected: repeat(0x01),
+		},
+		{
+			name: "last row of a tile",
+			setup: func(p *PPU) {
+				p.write(0x2000+3*32+2, tileSolid1)
+			},
+			mask:     maskBackground,
+			x:        16,
+			y:        31,
+			expected: repeat(0x01),
+		},
+		{
+			name: "pixel values select colours within the palette",
+			setup: func(p *PPU) {
+				p.write(0x2000, tileSolid3)
+			},
+			mask:     maskBackground | maskBackgroundLeft,
+			x:        0,
+			y:        0,
+			expected: repeat(0x03),
+		},
+		{
+			name: "transparent pixels show the backdrop",
+			setup: func(p *PPU) {
+				p.write(0x2000+3*32+2, tileArrow)
+			},
+			mask:     maskBackground,
+			x:        16,
+			y:        25,
+			expected: []uint8{0x01, 0x01, backdrop, backdrop, backdrop, backdrop, backdrop, backdrop},
+		},
+		{
+			name: "attributes select the palette of each 16x16 quadrant",
+			setup: func(p *PPU) {
+				for i := uint16(0); i < 4; i++ {
+					p.write(0x2000+i, tileSolid1)
+					p.write(0x2000+2*32+i, tileSolid1)
+				}
+				p.write(0x23c0, 0b11100100)
+			},
+			mask:     maskBackground | maskBackgroundLeft,
+			x:        8,
+			y:        16,
+			expected: repeat(0x09),
+		},
+		{
+			name: "attribute of the top right quadrant",
+			setup: func(p *PPU) {
+				p.write(0x2000+2, tileSolid1)
+				p.write(0x23c0, 0b11100100)
+			},
+			mask:     maskBackground,
+			x:        16,
+			y:        0,
+			expected: repeat(0x05),
+		},
+		{
+			name: "pattern table selected through PPUCTRL",
+			setup: func(p *PPU) {
+				p.cart.PPUWrite(0x1000|uint16(tileSolid1)<<4+8, 0xff)
+				p.write(0x2000+2, tileSolid1)
+				p.Write(PPUCTRL, ctrlBackgroundTable)
+			},
+			mask:     maskBackground,
+			x:        16,
+			y:        0,
+			expected: repeat(0x03),
+		},
+		{
+			name: "fine X scroll",
+			setup: func(p *PPU) {
+				p.write(0x2000+2, tileSolid1)
+				p.Write(PPUSCROLL, 3)
+				p.Write(PPUSCROLL, 0)
+			},
+			mask:     maskBackground | maskBackgroundLeft,
+			x:        10,
+			y:        0,
+			expected: []uint8{backdrop, backdrop, backdrop, 0x01, 0x01, 0x01, 0x01, 0x01},
+		},
+		{
+			name: "coarse X scroll wraps into the next nametable",
+			setup: func(p *PPU) {
+				p.write(0x2400, tileSolid2)
+				p.Write(PPUSCROLL, 8)
+				p.Write(PPUSCROLL, 0)
+			},
+			mask:     maskBackground,
+			x:        248,
+			y:        0,
+			expected: repeat(0x02),
+		},
+		{
+			name: "Y scroll",
+			setup: func(p *PPU) {
+				p.write(0x2000+32, tileSolid1)
+				p.Write(PPUSCROLL, 0)
+				p.Write(PPUSCROLL, 5)
+			},
+			mask:     maskBackground | maskBackgroundLeft,
+			x:        0,
+			y:        3,
+			expected: repeat(0x01),
+		},
+		{
+			name: "Y scroll wraps into the next nametable after row 29",
+			setup: func(p *PPU) {
+				p.write(0x2800, tileSolid2)
+				p.Write(PPUSCROLL, 0)
+				p.Write(PPUSCROLL, 16)
+			},
+			mask:     maskBackground | maskBackgroundLeft,
+			x:        0,
+			y:        224,
+			expected: repeat(0x02),
+		},
+		{
+			name: "leftmost 8 pixels masked",
+			setup: func(p *PPU) {
+				p.write(0x2000, tileSolid1)
+				p.write(0x2001, tileSolid1)
+			},
+			mask:     maskBackground,
+			x:        4,
+			y:        0,
+			expected: []uint8{backdrop, backdrop, backdrop, backdrop, 0x01, 0x01, 0x01, 0x01},
+		},
+		{
+			name: "grayscale",
+			setup: func(p *PPU) {
+				p.write(0x2000, tileSolid3)
+				p.write(0x3f03, 0x27)
+			},
+			mask:     maskBackground | maskBackgroundLeft | maskGrayscale,
+			x:        0,
+			y:        0,
+			expected: repeat(0x20),
+		},
+		{
+			name: "backdrop while rendering is disabled",
+			setup: func(p *PPU) {
+				p.write(0x2000, tileSolid3)
+			},
+			x:        0,
+			y:        0,
+			expected: repeat(backdrop),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newRenderTestPPU()
+			tc.setup(p)
+			p.Write(PPUMASK, tc.mask)
+			renderFrame(p)
+
+			assert.Equal(t, tc.expected, rowAt(p, tc.x, tc.y))
+		})
+	}
+}
+
+func TestPPU_RenderSprites(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ctrl       uint8
+		mask       uint8
+		background uint8
+		sprites    [][4]uint8
+		x          int
+		y          int
+		expected   []uint8
+	}{
+		{
+			name:     "sprite drawn a scanline below its Y",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid1, 0x00, 40}},
+			x:        40,
+			y:        20,
+			expected: repeat(0x11),
+		},
+		{
+			name:     "nothing drawn on the sprite's Y",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid1, 0x00, 40}},
+			x:        40,
+			y:        19,
+			expected: repeat(backdrop),
+		},
+		{
+			name:     "attributes select the sprite palette",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid2, 0x03, 40}},
+			x:        40,
+			y:        20,
+			expected: repeat(0x1e),
+		},
+		{
+			name:     "sprite partially offscreen to the right",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid1, 0x00, 252}},
+			x:        248,
+			y:        20,
+			expected: []uint8{backdrop, backdrop, backdrop, backdrop, 0x11, 0x11, 0x11, 0x11},
+		},
+		{
+			name:     "unflipped",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileArrow, 0x00, 40}},
+			x:        40,
+			y:        21,
+			expected: []uint8{0x11, 0x11, backdrop, backdrop, backdrop, backdrop, backdrop, backdrop},
+		},
+		{
+			name:     "flipped horizontally",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileArrow, attributeFlipH, 40}},
+			x:        40,
+			y:        21,
+			expected: []uint8{backdrop, backdrop, backdrop, backdrop, backdrop, backdrop, 0x11, 0x11},
+		},
+		{
+			name:     "flipped vertically",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileArrow, attributeFlipV, 40}},
+			x:        40,
+			y:        27,
+			expected: []uint8{0x11, 0x11, 0x11, 0x11, backdrop, backdrop, backdrop, backdrop},
+		},
+		{
+			name:     "8x8 pattern table selected through PPUCTRL",
+			ctrl:     ctrlSpriteTable,
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, 0x00, 0x00, 40}},
+			x:        40,
+			y:        20,
+			expected: repeat(backdrop),
+		},
+		{
+			name:     "8x16 sprite top tile",
+			ctrl:     ctrlSpriteSize,
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid2, 0x00, 40}},
+			x:        40,
+			y:        27,
+			expected: repeat(0x12),
+		},
+		{
+			name:     "8x16 sprite bottom tile",
+			ctrl:     ctrlSpriteSize,
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid2, 0x00, 40}},
+			x:        40,
+			y:        28,
+			expected: repeat(0x13),
+		},
+		{
+			name:     "8x16 sprite flipped vertically swaps tiles",
+			ctrl:     ctrlSpriteSize,
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid2, attributeFlipV, 40}},
+			x:        40,
+			y:        20,
+			expected: repeat(0x13),
+		},
+		{
+			name:     "8x16 sprite pattern table selected by tile bit 0",
+			ctrl:     ctrlSpriteSize,
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, 0x03, 0x00, 40}},
+			x:        40,
+			y:        20,
+			expected: repeat(0x12),
+		},
+		{
+			name:       "sprite in front of the background",
+			mask:       maskSprites | maskBackground,
+			background: tileSolid1,
+			sprites:    [][4]uint8{{19, tileArrow, 0x00, 40}},
+			x:          40,
+			y:          21,
+			expected:   []uint8{0x11, 0x11, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01},
+		},
+		{
+			name:       "sprite behind the background",
+			mask:       maskSprites | maskBackground,
+			background: tileSolid1,
+			sprites:    [][4]uint8{{19, tileSolid2, attributePriority, 40}},
+			x:          40,
+			y:          20,
+			expected:   repeat(0x01),
+		},
+		{
+			name:     "sprite behind the background shows through the backdrop",
+			mask:     maskSprites | maskBackground,
+			sprites:  [][4]uint8{{19, tileSolid2, attributePriority, 40}},
+			x:        40,
+			y:        20,
+			expected: repeat(0x12),
+		},
+		{
+			name: "lower numbered sprite drawn over higher",
+			mask: maskSprites,
+			sprites: [][4]uint8{
+				{19, tileArrow, 0x00, 40},
+				{19, tileSolid2, 0x01, 40},
+			},
+			x:        40,
+			y:        21,
+			expected: []uint8{0x11, 0x11, 0x16, 0x16, 0x16, 0x16, 0x16, 0x16},
+		},
+		{
+			name:       "lower numbered sprite behind the background hides higher",
+			mask:       maskSprites | maskBackground,
+			background: tileSolid1,
+			sprites: [][4]uint8{
+				{19, tileSolid1, attributePriority, 40},
+				{19, tileSolid2, 0x00, 40},
+			},
+			x:        40,
+			y:        20,
+			expected: repeat(0x01),
+		},
+		{
+			name:     "leftmost 8 pixels masked",
+			mask:     maskSprites,
+			sprites:  [][4]uint8{{19, tileSolid1, 0x00, 4}},
+			x:        4,
+			y:        20,
+			expected: []uint8{backdrop, backdrop, backdrop, backdrop, 0x11, 0x11, 0x11, 0x11},
+		},
+		{
+			name:     "leftmost 8 pixels shown",
+			mask:     maskSprites | maskSpritesLeft,
+			sprites:  [][4]uint8{{19, tileSolid1, 0x00, 4}},
+			x:        4,
+			y:        20,
+			expected: repeat(0x11),
+		},
+		{
+			name: "only eight sprites drawn on a scanline",
+			mask: maskSprites,
+			sprites: [][4]uint8{
+				{19, tileDot, 0x00, 100},
+				{19, tileDot, 0x00, 101},
+				{19, tileDot, 0x00, 102},
+				{19, tileDot, 0x00, 103},
+				{19, tileDot, 0x00, 104},
+				{19, tileDot, 0x00, 105},
+				{19, tileDot, 0x00, 106},
+				{19, tileDot, 0x00, 107},
+				{19, tileSolid2, 0x00, 100},
+			},
+			x:        100,
+			y:        20,
+			expected: repeat(0x11),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newRenderTestPPU()
+			for i := uint16(0); i < 32*30; i++ {
+				p.write(0x2000+i, tc.background)
+			}
+			for n, s := range tc.sprites {
+				setSprite(p, n, s[0], s[1], s[2], s[3])
+			}
+			p.Write(PPUCTRL, tc.ctrl)
+			p.Write(PPUMASK, tc.mask|maskBackgroundLeft)
+			renderFrame(p)
+
+			assert.Equal(t, tc.expected, rowAt(p, tc.x, tc.y))
+		})
+	}
+}
+
+func TestPPU_Sprite0Hit(t *testing.T) {
+	testCases := []struct {
+		name             string
+		mask             uint8
+		sprite           [4]uint8
+		backgroundX      uint16
+		expectedHit      bool
+		expectedScanline int
+	}{
+		{
+			name:             "opaque sprite 0 pixel over opaque background",
+			mask:             maskBackground | maskSprites,
+			sprite:           [4]uint8{99, tileDot, 0x00, 50},
+			backgroundX:      6,
+			expectedHit:      true,
+			expectedScanline: 100,
+		},
+		{
+			name:             "sprite 0 behind the background",
+			mask:             maskBackground | maskSprites,
+			sprite:           [4]uint8{99, tileDot, attributePriority, 50},
+			backgroundX:      6,
+			expectedHit:      true,
+			expectedScanline: 100,
+		},
+		{
+			name:        "sprite 0 over transparent background",
+			mask:        maskBackground | maskSprites,
+			sprite:      [4]uint8{99, tileDot, 0x00, 60},
+			backgroundX: 6,
+		},
+		{
+			name:        "sprites disabled",
+			mask:        maskBackground,
+			sprite:      [4]uint8{99, tileDot, 0x00, 50},
+			backgroundX: 6,
+		},
+		{
+			name:        "background disabled",
+			mask:        maskSprites,
+			sprite:      [4]uint8{99, tileDot, 0x00, 50},
+			backgroundX: 6,
+		},
+		{
+			name:        "leftmost 8 pixels masked",
+			mask:        maskBackground | maskSprites | maskBackgroundLeft,
+			sprite:      [4]uint8{99, tileDot, 0x00, 2},
+			backgroundX: 0,
+		},
+		{
+			name:             "leftmost 8 pixels shown",
+			mask:             maskBackground | maskSprites | maskBackgroundLeft | maskSpritesLeft,
+			sprite:           [4]uint8{99, tileDot, 0x00, 2},
+			backgroundX:      0,
+			expectedHit:      true,
+			expectedScanline: 100,
+		},
+		{
+			name:        "never at X=255",
+			mask:        maskBackground | maskSprites,
+			sprite:      [4]uint8{99, tileDot, 0x00, 255},
+			backgroundX: 31,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newRenderTestPPU()
+			p.write(0x2000+12*32+tc.backgroundX, tileSolid1)
+			setSprite(p, 0, tc.sprite[0], tc.sprite[1], tc.sprite[2], tc.sprite[3])
+			p.Write(PPUMASK, tc.mask)
+
+			scanline := -1
+			for p.Frame() == 0 || p.scanline < VisibleScanlines {
+				p.Clock()
+				if scanline < 0 && p.status&statusSprite0Hit > 0 {
+					scanline = p.scanline
+				}
+			}
+
+			assert.Equal(t, tc.expectedHit, p.status&statusSprite0Hit > 0)
+			if tc.expectedHit {
+				assert.Equal(t, tc.expectedScanline, scanline)
+			}
+		})
+	}
+}
+
+func TestPPU_SpriteOverflow(t *testing.T) {
+	testCases := []struct {
+		name             string
+		sprites          [][4]uint8
+		expectedOverflow bool
+	}{
+		{
+			name:    "eight sprites on a scanline",
+			sprites: inRangeSprites(8),
+		},
+		{
+			name:             "nine sprites on a scanline",
+			sprites:          inRangeSprites(9),
+			expectedOverflow: true,
+		},
+		{
+			name: "false positive from comparing a tile number",
+			sprites: append(inRangeSprites(8),
+				[4]uint8{200, 0x00, 0x00, 0x00}, // out of range, so the next compares byte 1
+				[4]uint8{200, 100, 0x00, 0x00},  // tile number in range of scanline 100
+			),
+			expectedOverflow: true,
+		},
+		{
+			name: "false negative from comparing a tile number",
+			sprites: append(inRangeSprites(8),
+				[4]uint8{200, 0x00, 0x00, 0x00}, // out of range, so the next compares byte 1
+				[4]uint8{100, 0xee, 0x00, 0x00}, // in range, but its tile number is not
+			),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newRenderTestPPU()
+			for n, s := range tc.sprites {
+				setSprite(p, n, s[0], s[1], s[2], s[3])
+			}
+			p.Write(PPUMASK, maskSprites)
+			renderFrame(p)
+
+			assert.Equal(t, tc.expectedOverflow, p.status&statusOverflow > 0)
+		})
+	}
+}
+
+// inRangeSprites returns n sprites on scanline 100.
+func inRangeSprites(n int) [][4]uint8 {
+	sprites := make([][4]uint8, n)
+	for i := range sprites {
+		sprites[i] = [4]uint8{100, tileDot, 0x00, uint8(i * 8)}
+	}
+	return sprites
+}
+
+func TestPPU_Image(t *testing.T) {
+	p, _ := newRenderTestPPU()
+	p.write(0x2000, tileSolid1)
+	renderFrame(p)
+	p.pixels[0] = 0x16
+
+	img := p.Image()
+	assert.Equal(t, image.Rect(0, 0, Width, Height), img.Bounds())
+	assert.Equal(t, Palette[0x16], img.At(0, 0))
+	assert.Equal(t, Palette[backdrop], img.At(Width-1, Height-1))
+
+	rgba := p.RGBA()
+	assert.Equal(t, Palette[0x16], rgba.At(0, 0))
+	p.pixels[0] = 0x00
+	assert.Equal(t, Palette[0x16], rgba.At(0, 0), "RGBA copies the frame buffer")
+}
+
+func TestPPU_Golden(t *testing.T) {
+	testCases := []struct {
+		name  string
+		setup func(p *PPU, cart *testCartridge)
+	}{
+		{
+			name: "scrolled_background",
+			setup: func(p *PPU, cart *testCartridge) {
+				for i := uint16(0); i < 32*30; i++ {
+					p.write(0x2000+i, uint8(1+(i+i/32)%4))
+					p.write(0x2400+i, uint8(1+i%3))
+				}
+				for i := uint16(0); i < 64; i++ {
+					p.write(0x23c0+i, uint8(i*0x1b))
+				}
+				p.Write(PPUSCROLL, 100)
+				p.Write(PPUSCROLL, 20)
+				p.Write(PPUMASK, maskBackground)
+			},
+		},
+		{
+			name: "sprites",
+			setup: func(p *PPU, cart *testCartridge) {
+				for i := uint16(0); i < 32*30; i++ {
+					if (i/32)%4 == 0 {
+						p.write(0x2000+i, tileSolid1)
+					}
+				}
+				for n := 0; n < 64; n++ {
+					setSprite(p, n, uint8(n*3+16), tileArrow, uint8(n%4)|uint8(n/4%8)<<5, uint8(n*4))
+				}
+				p.Write(PPUMASK, maskBackground|maskSprites|maskBackgroundLeft|maskSpritesLeft)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, cart := newRenderTestPPU()
+			tc.setup(p, cart)
+			renderFrame(p)
+
+			var actual bytes.Buffer
+			require.NoError(t, png.Encode(&actual, p.Image()))
+
+			golden := filepath.Join("testdata", tc.name+".png")
+			if *update {
+				require.NoError(t, ioutil.WriteFile(golden, actual.Bytes(), 0644))
+			}
+			expected, err := ioutil.ReadFile(golden)
+			require.NoError(t, err)
+			expectedImage, err := png.Decode(bytes.NewReader(expected))
+			require.NoError(t, err)
+
+			rgba := p.RGBA()
+			for y := 0; y < Height; y++ {
+				for x := 0; x < Width; x++ {
+					if !assert.Equal(t, expectedImage.At(x, y), rgba.At(x, y), "pixel (%d, %d)", x, y) {
+						return
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package symbols
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+)
+
+// dbgRecord is a line of a ca65/ld65 debug file, such as
+//
+//	sym	id=0,name="reset",addrsize=absolute,scope=0,def=0,val=0x8000,seg=0,type=lab
+//
+// split into its keyword and attributes.
+type dbgRecord struct {
+	keyword    string
+	attributes map[string]string
+}
+
+// number returns a numeric attribute, written in decimal or with a 0x prefix.
+func (r dbgRecord) number(name string) (int, bool) {
+	value, ok := r.attributes[name]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 0, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// parseDBGRecord splits a line of a debug file into a dbgRecord. Quoted
+// attribute values may contain commas.
+func parseDBGRecord(line string) (dbgRecord, error) {
+	fields := strings.SplitN(line, "\t", 2)
+	r := dbgRecord{keyword: fields[0], attributes: make(map[string]string)}
+	if len(fields) < 2 {
+		return r, nil
+	}
+
+	rest := fields[1]
+	for rest != "" {
+		equals := strings.IndexByte(rest, '=')
+		if equals < 0 {
+			return r, fmt.Errorf("attribute %q has no value", rest)
+		}
+		name := rest[:equals]
+		rest = rest[equals+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return r, fmt.Errorf("attribute %q has an unterminated string", name)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		r.attributes[name] = value
+		rest = strings.TrimPrefix(rest, ",")
+	}
+	return r, nil
+}
+
+// ReadDBG reads the labels of a debug file written by ld65's --dbgfile option
+// into the table. Labels in segments written to a .nes file become PRG-ROM
+// symbols, at their offset into the file less the iNES header, and the rest,
+// such as those in zero page and BSS segments, become CPU symbols. Labels in a
+// scope are named after it, e.g. "main::loop", and cheap local labels after
+// the label they follow, e.g. "main@loop". Equates are left out, as they are
+// as often constants as addresses.
+func (t *Table) ReadDBG(r io.Reader) error {
+	records := make(map[string]map[string]dbgRecord)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		record, err := parseDBGRecord(line)
+		if err != nil {
+			return fmt.Errorf("symbols: line %d: %v", n, err)
+		}
+		if records[record.keyword] == nil {
+			records[record.keyword] = make(map[string]dbgRecord)
+		}
+		records[record.keyword][record.attributes["id"]] = record
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for _, sym := range records["sym"] {
+		if sym.attributes["type"] != "lab" {
+			continue
+		}
+		value, ok := sym.number("val")
+		if !ok {
+			continue
+		}
+		size, _ := sym.number("size")
+		s := Symbol{
+			Name:    dbgName(records, sym),
+			Space:   CPU,
+			Address: value,
+			Size:    size,
+		}
+
+		seg, ok := records["seg"][sym.attributes["seg"]]
+		start, hasStart := seg.number("start")
+		fileOffset, hasOffset := seg.number("ooffs")
+		if ok && hasStart && hasOffset && strings.HasSuffix(strings.ToLower(seg.attributes["oname"]), ".nes") {
+			s.Space = PRGROM
+			s.Address = fileOffset - cartridge.HeaderSize + value - start
+		}
+		t.Add(s)
+	}
+	return nil
+}
+
+// dbgName returns the name of a symbol qualified by its scopes, or by the
+// label a cheap local label follows.
+func dbgName(records map[string]map[string]dbgRecord, sym dbgRecord) string {
+	name := sym.attributes["name"]
+	if parent, ok := records["sym"][sym.attributes["parent"]]; ok {
+		return dbgName(records, parent) + name
+	}
+	for scope, ok := records["scope"][sym.attributes["scope"]]; ok; scope, ok = records["scope"][scope.attributes["parent"]] {
+		if scope.attributes["name"] != "" {
+			name = scope.attributes["name"] + "::" + name
+		}
+	}
+	return name
+}
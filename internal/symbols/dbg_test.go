@@ -0,0 +1,64 @@
+package symbols
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_ReadDBG(t *testing.T) {
+	dbg := strings.Join([]string{
+		`version	major=2,minor=0`,
+		`file	id=0,name="main.s",size=1024,mtime=0x5F000000,mod=0`,
+		`seg	id=0,name="ZEROPAGE",start=0x000000,size=0x0004,addrsize=zeropage,type=rw`,
+		`seg	id=1,name="BANK0",start=0x008000,size=0x4000,addrsize=absolute,type=ro,oname="game.nes",ooffs=16`,
+		`seg	id=2,name="FIXED",start=0x00C000,size=0x4000,addrsize=absolute,type=ro,oname="game.nes",ooffs=16400`,
+		`scope	id=0,name="",mod=0,size=0`,
+		`scope	id=1,name="main",mod=0,type=scope,size=8,parent=0`,
+		`sym	id=0,name="frame",addrsize=zeropage,size=2,scope=0,def=1,val=0x2,seg=0,type=lab`,
+		`sym	id=1,name="title",addrsize=absolute,scope=0,def=2,val=0x8010,seg=1,type=lab`,
+		`sym	id=2,name="reset",addrsize=absolute,scope=0,def=3,val=0xC000,seg=2,type=lab`,
+		`sym	id=3,name="loop",addrsize=absolute,scope=1,def=4,val=0xC004,seg=2,type=lab`,
+		`sym	id=4,name="@wait",addrsize=absolute,scope=0,def=5,val=0xC008,seg=2,parent=2,type=lab`,
+		`sym	id=5,name="PPUCTRL",addrsize=absolute,scope=0,def=6,val=0x2000,type=equ`,
+	}, "\n")
+	table := NewTable()
+
+	require.NoError(t, table.ReadDBG(strings.NewReader(dbg)))
+
+	assert.Equal(t, []Symbol{
+		{Name: "frame", Space: CPU, Address: 0x0002, Size: 2},
+		{Name: "title", Space: PRGROM, Address: 0x0010, Size: 1},
+		{Name: "reset", Space: PRGROM, Address: 0x4000, Size: 1},
+		{Name: "main::loop", Space: PRGROM, Address: 0x4004, Size: 1},
+		{Name: "reset@wait", Space: PRGROM, Address: 0x4008, Size: 1},
+	}, table.Symbols())
+}
+
+func TestTable_ReadDBG_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		dbg           string
+		expectedError string
+	}{
+		{
+			name:          "attribute without value",
+			dbg:           "sym\tid=0,name",
+			expectedError: `symbols: line 1: attribute "name" has no value`,
+		},
+		{
+			name:          "unterminated string",
+			dbg:           "version\tmajor=2\nsym\tid=0,name=\"reset",
+			expectedError: `symbols: line 2: attribute "name" has an unterminated string`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewTable().ReadDBG(strings.NewReader(tc.dbg))
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
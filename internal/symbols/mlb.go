@@ -0,0 +1,77 @@
+package symbols
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// mlbSpaces maps the memory types of Mesen label files, in both the one letter
+// form of Mesen and the names of Mesen 2, to the space their addresses become
+// and the CPU address their offsets start at.
+var mlbSpaces = map[string]struct {
+	space Space
+	base  int
+}{
+	"P":              {PRGROM, 0},
+	"NesPrgRom":      {PRGROM, 0},
+	"R":              {CPU, 0x0000},
+	"NesInternalRam": {CPU, 0x0000},
+	"G":              {CPU, 0x0000},
+	"NesMemory":      {CPU, 0x0000},
+	"W":              {CPU, 0x6000},
+	"NesWorkRam":     {CPU, 0x6000},
+	"S":              {CPU, 0x6000},
+	"NesSaveRam":     {CPU, 0x6000},
+}
+
+// ReadMLB reads a Mesen label file into the table. Each line gives a memory
+// type, an address or range of addresses and a label, optionally followed by
+// a comment, as "P:0010:reset:Entry point" or "R:0300-030F:buffer". Labels in
+// PRG-ROM become PRG-ROM symbols and the rest become CPU symbols, with work
+// and save RAM offsets mapped to $6000. Lines for other memory, such as
+// CHR-ROM, are skipped.
+func (t *Table) ReadMLB(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, ":", 4)
+		if len(fields) < 3 {
+			return fmt.Errorf("symbols: line %d: expected type:address:label, got %q", n, line)
+		}
+		space, ok := mlbSpaces[fields[0]]
+		if !ok {
+			continue
+		}
+
+		first, last := fields[1], fields[1]
+		if dash := strings.IndexByte(first, '-'); dash >= 0 {
+			first, last = first[:dash], first[dash+1:]
+		}
+		start, err := strconv.ParseUint(first, 16, 32)
+		if err != nil {
+			return fmt.Errorf("symbols: line %d: invalid address %q", n, first)
+		}
+		end, err := strconv.ParseUint(last, 16, 32)
+		if err != nil || end < start {
+			return fmt.Errorf("symbols: line %d: invalid address %q", n, last)
+		}
+
+		s := Symbol{
+			Name:    fields[2],
+			Space:   space.space,
+			Address: space.base + int(start),
+			Size:    int(end-start) + 1,
+		}
+		if len(fields) == 4 {
+			s.Comment = strings.ReplaceAll(fields[3], `\n`, "\n")
+		}
+		t.Add(s)
+	}
+	return scanner.Err()
+}
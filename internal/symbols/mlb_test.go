@@ -0,0 +1,64 @@
+package symbols
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_ReadMLB(t *testing.T) {
+	mlb := strings.Join([]string{
+		`P:0000:reset:Entry point\nClears RAM`,
+		`P:4010::Comment only: no label`,
+		`R:0300-030F:buffer`,
+		`G:2000:PPUCTRL`,
+		`W:0000:save_slot`,
+		`NesPrgRom:4000:level_loader`,
+		`C:0000:tiles`,
+	}, "\r\n")
+	table := NewTable()
+
+	require.NoError(t, table.ReadMLB(strings.NewReader(mlb)))
+
+	assert.Equal(t, []Symbol{
+		{Name: "buffer", Space: CPU, Address: 0x0300, Size: 0x10},
+		{Name: "PPUCTRL", Space: CPU, Address: 0x2000, Size: 1},
+		{Name: "save_slot", Space: CPU, Address: 0x6000, Size: 1},
+		{Name: "reset", Space: PRGROM, Address: 0x0000, Size: 1, Comment: "Entry point\nClears RAM"},
+		{Name: "level_loader", Space: PRGROM, Address: 0x4000, Size: 1},
+		{Name: "", Space: PRGROM, Address: 0x4010, Size: 1, Comment: "Comment only: no label"},
+	}, table.Symbols())
+}
+
+func TestTable_ReadMLB_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		mlb           string
+		expectedError string
+	}{
+		{
+			name:          "missing label",
+			mlb:           "P:0000",
+			expectedError: `symbols: line 1: expected type:address:label, got "P:0000"`,
+		},
+		{
+			name:          "invalid address",
+			mlb:           "R:0000:frame\nP:XYZ:reset",
+			expectedError: `symbols: line 2: invalid address "XYZ"`,
+		},
+		{
+			name:          "reversed range",
+			mlb:           "R:0310-0300:buffer",
+			expectedError: `symbols: line 1: invalid address "0300"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewTable().ReadMLB(strings.NewReader(tc.mlb))
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package symbols
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// nlBankSize is the size of the PRG-ROM banks FCEUX writes a name list for.
+const nlBankSize = 0x4000
+
+// RAMBank is the bank of an FCEUX name list for RAM, rather than a bank of
+// PRG-ROM.
+const RAMBank = -1
+
+// nlBank returns the bank of an FCEUX name list from its file name, which is
+// the ROM's file name followed by ".ram.nl" or the bank number in hexadecimal,
+// e.g. "game.nes.1F.nl".
+func nlBank(path string) (int, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	suffix := strings.ToLower(name[strings.LastIndexByte(name, '.')+1:])
+	if suffix == "ram" {
+		return RAMBank, nil
+	}
+	bank, err := strconv.ParseUint(suffix, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("symbols: name list %q is not named for RAM or a bank, expected e.g. game.nes.ram.nl or game.nes.0.nl", filepath.Base(path))
+	}
+	return int(bank), nil
+}
+
+// ReadNL reads an FCEUX name list into the table. Each line names an address
+// and may give it a comment, as "$C000#Reset#Entry point", or names an array
+// with its size in hexadecimal, as "$0300/10#Buffer#". Addresses in a list for
+// a 16KB bank of PRG-ROM become PRG-ROM symbols in that bank, and those in the
+// list for RAM, bank RAMBank, become CPU symbols.
+func (t *Table) ReadNL(r io.Reader, bank int) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "#", 3)
+		if len(fields) < 2 || !strings.HasPrefix(fields[0], "$") {
+			return fmt.Errorf("symbols: line %d: expected $address#name#comment, got %q", n, line)
+		}
+
+		address, size := fields[0][1:], "1"
+		if slash := strings.IndexByte(address, '/'); slash >= 0 {
+			address, size = address[:slash], address[slash+1:]
+		}
+		a, err := strconv.ParseUint(address, 16, 16)
+		if err != nil {
+			return fmt.Errorf("symbols: line %d: invalid address %q", n, address)
+		}
+		sz, err := strconv.ParseUint(size, 16, 16)
+		if err != nil {
+			return fmt.Errorf("symbols: line %d: invalid size %q", n, size)
+		}
+
+		s := Symbol{Name: fields[1], Space: CPU, Address: int(a), Size: int(sz)}
+		if len(fields) == 3 {
+			s.Comment = fields[2]
+		}
+		if bank != RAMBank {
+			s.Space = PRGROM
+			s.Address = bank*nlBankSize + int(a)%nlBankSize
+		}
+		t.Add(s)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,105 @@
+package symbols
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_ReadNL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nl       string
+		bank     int
+		expected []Symbol
+	}{
+		{
+			name: "RAM",
+			nl:   "$0010#frame#frame counter\n$0300/10#buffer#\n",
+			bank: RAMBank,
+			expected: []Symbol{
+				{Name: "frame", Space: CPU, Address: 0x0010, Size: 1, Comment: "frame counter"},
+				{Name: "buffer", Space: CPU, Address: 0x0300, Size: 0x10},
+			},
+		},
+		{
+			name: "PRG-ROM bank",
+			nl:   "$8123#level_loader#\r\n\r\n$C000#reset\r\n",
+			bank: 2,
+			expected: []Symbol{
+				{Name: "reset", Space: PRGROM, Address: 0x8000, Size: 1},
+				{Name: "level_loader", Space: PRGROM, Address: 0x8123, Size: 1},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := NewTable()
+
+			require.NoError(t, table.ReadNL(strings.NewReader(tc.nl), tc.bank))
+
+			assert.Equal(t, tc.expected, table.Symbols())
+		})
+	}
+}
+
+func TestTable_ReadNL_Errors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nl            string
+		expectedError string
+	}{
+		{
+			name:          "missing name",
+			nl:            "$C000\n",
+			expectedError: `symbols: line 1: expected $address#name#comment, got "$C000"`,
+		},
+		{
+			name:          "invalid address",
+			nl:            "$0010#frame#\n$G000#reset#\n",
+			expectedError: `symbols: line 2: invalid address "G000"`,
+		},
+		{
+			name:          "invalid size",
+			nl:            "$0300/x#buffer#\n",
+			expectedError: `symbols: line 1: invalid size "x"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewTable().ReadNL(strings.NewReader(tc.nl), RAMBank)
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestNLBank(t *testing.T) {
+	testCases := []struct {
+		path          string
+		expectedBank  int
+		expectedError string
+	}{
+		{path: "roms/game.nes.ram.nl", expectedBank: RAMBank},
+		{path: "roms/game.nes.0.nl", expectedBank: 0},
+		{path: "roms/game.nes.1F.nl", expectedBank: 0x1f},
+		{
+			path:          "roms/game.nl",
+			expectedError: `symbols: name list "game.nl" is not named for RAM or a bank, expected e.g. game.nes.ram.nl or game.nes.0.nl`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			bank, err := nlBank(tc.path)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBank, bank)
+		})
+	}
+}
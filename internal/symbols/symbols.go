@@ -0,0 +1,239 @@
+package symbols
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Space is the memory a symbol's address is in.
+type Space uint8
+
+// Address spaces of symbols.
+const (
+	// CPU addresses are in the CPU address space, for RAM, registers and code
+	// that is not banked.
+	CPU Space = iota
+	// PRGROM addresses are offsets into PRG-ROM, so that code and data in a
+	// switchable bank are only named while that bank is mapped.
+	PRGROM
+)
+
+// String returns the name of the address space.
+func (s Space) String() string {
+	switch s {
+	case CPU:
+		return "CPU"
+	case PRGROM:
+		return "PRG-ROM"
+	default:
+		return fmt.Sprintf("Space(%d)", uint8(s))
+	}
+}
+
+// Symbol names an address, or a range of addresses starting at it, and may
+// carry a comment.
+type Symbol struct {
+	Name    string
+	Space   Space
+	Address int // Address is the CPU address or PRG-ROM offset of the first byte.
+	Size    int // Size is the number of bytes named, at least 1.
+	Comment string
+}
+
+// key is an address in a Space.
+type key struct {
+	space   Space
+	address int
+}
+
+// Table holds symbols loaded from debug files, by address.
+type Table struct {
+	symbols map[key]Symbol
+	covered map[key]key // covered maps each address inside a symbol to its first address.
+	names   map[string]key
+}
+
+// NewTable constructs and returns a pointer to an empty Table.
+func NewTable() *Table {
+	return &Table{
+		symbols: make(map[key]Symbol),
+		covered: make(map[key]key),
+		names:   make(map[string]key),
+	}
+}
+
+// Add adds a symbol to the table. A symbol already at the same address keeps
+// its name or comment where the added symbol has none, so that labels and
+// comments from different files combine.
+func (t *Table) Add(s Symbol) {
+	if s.Size < 1 {
+		s.Size = 1
+	}
+	start := key{s.Space, s.Address}
+	if current, ok := t.symbols[start]; ok {
+		if s.Name == "" {
+			s.Name = current.Name
+		}
+		if s.Comment == "" {
+			s.Comment = current.Comment
+		}
+	}
+	t.symbols[start] = s
+	if s.Name != "" {
+		t.names[s.Name] = start
+	}
+	for i := 1; i < s.Size; i++ {
+		address := key{s.Space, s.Address + i}
+		if _, ok := t.covered[address]; !ok {
+			t.covered[address] = start
+		}
+	}
+}
+
+// Len returns the number of symbols in the table.
+func (t *Table) Len() int {
+	return len(t.symbols)
+}
+
+// Symbols returns the symbols in the table, ordered by space then address.
+func (t *Table) Symbols() []Symbol {
+	symbols := make([]Symbol, 0, len(t.symbols))
+	for _, s := range t.symbols {
+		symbols = append(symbols, s)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].Space != symbols[j].Space {
+			return symbols[i].Space < symbols[j].Space
+		}
+		return symbols[i].Address < symbols[j].Address
+	})
+	return symbols
+}
+
+// Lookup returns the symbol starting at or covering an address, and the offset
+// of the address from the start of the symbol.
+func (t *Table) Lookup(space Space, address int) (Symbol, int, bool) {
+	if s, ok := t.symbols[key{space, address}]; ok {
+		return s, 0, true
+	}
+	if start, ok := t.covered[key{space, address}]; ok {
+		return t.symbols[start], address - start.address, true
+	}
+	return Symbol{}, 0, false
+}
+
+// Find returns the named symbol.
+func (t *Table) Find(name string) (Symbol, bool) {
+	start, ok := t.names[name]
+	if !ok {
+		return Symbol{}, false
+	}
+	return t.symbols[start], true
+}
+
+// LoadFile reads the symbols of a ca65/ld65 debug file (.dbg), an FCEUX name
+// list (.nl) or a Mesen label file (.mlb) into the table, choosing the format
+// from the file's extension.
+func (t *Table) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".dbg":
+		err = t.ReadDBG(f)
+	case ".nl":
+		var bank int
+		bank, err = nlBank(path)
+		if err == nil {
+			err = t.ReadNL(f, bank)
+		}
+	case ".mlb":
+		err = t.ReadMLB(f)
+	default:
+		return fmt.Errorf("symbols: unknown symbol file type %q, expected .dbg, .nl or .mlb", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// PRGMapper maps CPU addresses to offsets into PRG-ROM, as a cartridge's
+// mapper does.
+type PRGMapper interface {
+	PRGOffset(address uint16) (int, bool)
+}
+
+// Resolver names CPU addresses from a Table, following the PRG-ROM banks
+// mapped at the time of each lookup.
+type Resolver struct {
+	table *Table
+	prg   PRGMapper
+}
+
+// Resolver returns a Resolver naming CPU addresses, which finds the PRG-ROM
+// symbols of an address through prg. prg may be nil, in which case only CPU
+// symbols are found.
+func (t *Table) Resolver(prg PRGMapper) *Resolver {
+	return &Resolver{table: t, prg: prg}
+}
+
+// lookup returns the symbol at or covering a CPU address, preferring a symbol
+// for the PRG-ROM byte mapped there.
+func (r *Resolver) lookup(address uint16) (Symbol, int, bool) {
+	if r.prg != nil {
+		if offset, ok := r.prg.PRGOffset(address); ok {
+			if s, o, ok := r.table.Lookup(PRGROM, offset); ok {
+				return s, o, true
+			}
+		}
+	}
+	return r.table.Lookup(CPU, int(address))
+}
+
+// Label returns the name of the symbol at or covering a CPU address, and the
+// offset of the address into it.
+func (r *Resolver) Label(address uint16) (string, int, bool) {
+	s, offset, ok := r.lookup(address)
+	if !ok || s.Name == "" {
+		return "", 0, false
+	}
+	return s.Name, offset, true
+}
+
+// Comment returns the comment on a CPU address.
+func (r *Resolver) Comment(address uint16) (string, bool) {
+	s, offset, ok := r.lookup(address)
+	if !ok || offset != 0 || s.Comment == "" {
+		return "", false
+	}
+	return s.Comment, true
+}
+
+// Address returns the CPU address of a named symbol. PRG-ROM symbols only
+// have an address while their bank is mapped, which is searched for in
+// $8000-$FFFF.
+func (r *Resolver) Address(name string) (uint16, bool) {
+	s, ok := r.table.Find(name)
+	if !ok {
+		return 0, false
+	}
+	if s.Space == CPU {
+		return uint16(s.Address), true
+	}
+	if r.prg == nil {
+		return 0, false
+	}
+	for address := 0x8000; address <= 0xffff; address++ {
+		if offset, ok := r.prg.PRGOffset(uint16(address)); ok && offset == s.Address {
+			return uint16(address), true
+		}
+	}
+	return 0, false
+}
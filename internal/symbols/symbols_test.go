@@ -0,0 +1,208 @@
+package symbols
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bankedPRG maps $8000-$BFFF to a switchable 16KB bank of PRG-ROM and
+// $C000-$FFFF to the last of four banks, as UxROM does.
+type bankedPRG struct {
+	bank int
+}
+
+func (p *bankedPRG) PRGOffset(address uint16) (int, bool) {
+	switch {
+	case address >= 0xc000:
+		return 3*0x4000 + int(address-0xc000), true
+	case address >= 0x8000:
+		return p.bank*0x4000 + int(address-0x8000), true
+	default:
+		return 0, false
+	}
+}
+
+func TestTable_Add(t *testing.T) {
+	table := NewTable()
+	table.Add(Symbol{Name: "reset", Space: PRGROM, Address: 0x10})
+	table.Add(Symbol{Space: PRGROM, Address: 0x10, Comment: "entry point"})
+	table.Add(Symbol{Name: "buffer", Space: CPU, Address: 0x300, Size: 0x10})
+
+	assert.Equal(t, []Symbol{
+		{Name: "buffer", Space: CPU, Address: 0x300, Size: 0x10},
+		{Name: "reset", Space: PRGROM, Address: 0x10, Size: 1, Comment: "entry point"},
+	}, table.Symbols())
+}
+
+func TestTable_Lookup(t *testing.T) {
+	table := NewTable()
+	table.Add(Symbol{Name: "buffer", Space: CPU, Address: 0x300, Size: 0x10})
+
+	testCases := []struct {
+		name           string
+		space          Space
+		address        int
+		expectedName   string
+		expectedOffset int
+		expectedFound  bool
+	}{
+		{
+			name:          "start of symbol",
+			space:         CPU,
+			address:       0x300,
+			expectedName:  "buffer",
+			expectedFound: true,
+		},
+		{
+			name:           "inside symbol",
+			space:          CPU,
+			address:        0x30f,
+			expectedName:   "buffer",
+			expectedOffset: 0x0f,
+			expectedFound:  true,
+		},
+		{
+			name:          "past symbol",
+			space:         CPU,
+			address:       0x310,
+			expectedFound: false,
+		},
+		{
+			name:          "other space",
+			space:         PRGROM,
+			address:       0x300,
+			expectedFound: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, offset, found := table.Lookup(tc.space, tc.address)
+
+			assert.Equal(t, tc.expectedFound, found, "incorrect found")
+			assert.Equal(t, tc.expectedName, s.Name, "incorrect name")
+			assert.Equal(t, tc.expectedOffset, offset, "incorrect offset")
+		})
+	}
+}
+
+func TestResolver(t *testing.T) {
+	table := NewTable()
+	table.Add(Symbol{Name: "frame", Space: CPU, Address: 0x0010})
+	table.Add(Symbol{Name: "PPUCTRL", Space: CPU, Address: 0x2000})
+	table.Add(Symbol{Name: "title_screen", Space: PRGROM, Address: 0x0000, Comment: "bank 0"})
+	table.Add(Symbol{Name: "level_loader", Space: PRGROM, Address: 0x4000, Comment: "bank 1"})
+	table.Add(Symbol{Name: "reset", Space: PRGROM, Address: 0xc000})
+	prg := &bankedPRG{}
+	r := table.Resolver(prg)
+
+	testCases := []struct {
+		name            string
+		bank            int
+		address         uint16
+		expectedLabel   string
+		expectedComment string
+	}{
+		{
+			name:          "RAM",
+			address:       0x0010,
+			expectedLabel: "frame",
+		},
+		{
+			name:          "register",
+			address:       0x2000,
+			expectedLabel: "PPUCTRL",
+		},
+		{
+			name:            "switchable bank 0",
+			bank:            0,
+			address:         0x8000,
+			expectedLabel:   "title_screen",
+			expectedComment: "bank 0",
+		},
+		{
+			name:            "switchable bank 1",
+			bank:            1,
+			address:         0x8000,
+			expectedLabel:   "level_loader",
+			expectedComment: "bank 1",
+		},
+		{
+			name:          "fixed bank",
+			bank:          1,
+			address:       0xc000,
+			expectedLabel: "reset",
+		},
+		{
+			name:    "unnamed",
+			bank:    2,
+			address: 0x8000,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prg.bank = tc.bank
+
+			label, _, _ := r.Label(tc.address)
+			comment, _ := r.Comment(tc.address)
+
+			assert.Equal(t, tc.expectedLabel, label, "incorrect label")
+			assert.Equal(t, tc.expectedComment, comment, "incorrect comment")
+		})
+	}
+}
+
+func TestResolver_Address(t *testing.T) {
+	table := NewTable()
+	table.Add(Symbol{Name: "frame", Space: CPU, Address: 0x0010})
+	table.Add(Symbol{Name: "level_loader", Space: PRGROM, Address: 0x4123})
+	prg := &bankedPRG{}
+	r := table.Resolver(prg)
+
+	address, ok := r.Address("frame")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x0010), address)
+
+	_, ok = r.Address("level_loader")
+	assert.False(t, ok, "symbol found in unmapped bank")
+
+	prg.bank = 1
+	address, ok = r.Address("level_loader")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x8123), address)
+}
+
+func TestTable_LoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "symbols")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"game.nes.ram.nl": "$0010#frame#\n",
+		"game.nes.1.nl":   "$8123#level_loader#\n",
+		"game.mlb":        "P:C000:reset\n",
+		"game.dbg": "seg\tid=0,name=\"CODE\",start=0x8000,size=0x10,addrsize=absolute,type=ro,oname=\"game.nes\",ooffs=16\n" +
+			"sym\tid=0,name=\"nmi\",addrsize=absolute,scope=0,def=1,val=0x8004,seg=0,type=lab\n",
+	}
+	table := NewTable()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		require.NoError(t, table.LoadFile(path), name)
+	}
+
+	assert.Equal(t, []Symbol{
+		{Name: "frame", Space: CPU, Address: 0x0010, Size: 1},
+		{Name: "nmi", Space: PRGROM, Address: 0x0004, Size: 1},
+		{Name: "level_loader", Space: PRGROM, Address: 0x4123, Size: 1},
+		{Name: "reset", Space: PRGROM, Address: 0xc000, Size: 1},
+	}, table.Symbols())
+
+	path := filepath.Join(dir, "game.sym")
+	require.NoError(t, ioutil.WriteFile(path, nil, 0644))
+	assert.EqualError(t, table.LoadFile(path), `symbols: unknown symbol file type ".sym", expected .dbg, .nl or .mlb`)
+}
@@ -0,0 +1,8 @@
+// Package nes is the public API of the goNES emulator. It loads ROMs into a
+// Console, which is stepped a frame or an instruction at a time, takes input
+// for its controllers, and produces pictures and audio.
+//
+// A Console is not safe for concurrent use. Frontends running the emulator on
+// its own goroutine should pass input to it and take frames from it on that
+// goroutine.
+package nes
@@ -0,0 +1,143 @@
+package nes_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/Jac0bDeal/goNES/pkg/nes"
+)
+
+func Example() {
+	console, err := nes.New(exampleROM(), nes.Auto)
+	if err != nil {
+		log.Fatal(err)
+	}
+	console.EnableAudio(44100)
+
+	// run for a second, holding Start for the first half of it
+	console.SetButtons(nes.Player1, nes.ButtonStart)
+	for i := 0; i < 60; i++ {
+		if i == 30 {
+			console.SetButtons(nes.Player1, 0)
+		}
+		console.StepFrame()
+	}
+
+	fmt.Println(console.Region(), "frames:", console.PPU().Frame)
+	fmt.Println("samples:", len(console.Audio()))
+	// Output:
+	// NTSC frames: 60
+	// samples: 44027
+}
+
+func ExampleConsole_SetButtons() {
+	console, err := nes.New(exampleROM(), nes.NTSC)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	console.SetButtons(nes.Player1, nes.ButtonA|nes.ButtonRight)
+	console.StepFrame()
+
+	// the example ROM reads player 1's controller into $0010-$0017 every frame
+	for address := uint16(0x0010); address < 0x0018; address++ {
+		fmt.Print(console.Peek(address)&0x01, " ")
+	}
+	fmt.Println()
+	// Output:
+	// 1 0 0 0 0 0 0 1
+}
+
+func ExampleConsole_Frame() {
+	console, err := nes.New(exampleROM(), nes.NTSC)
+	if err != nil {
+		log.Fatal(err)
+	}
+	console.StepFrame()
+	console.StepFrame()
+
+	frame := console.Frame()
+	fmt.Println(frame.Bounds())
+	fmt.Println(frame.At(nes.Width/2, nes.Height/2))
+	// Output:
+	// (0,0)-(256,240)
+	// {76 154 236 255}
+}
+
+func ExampleConsole_CPU() {
+	console, err := nes.New(exampleROM(), nes.PAL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		console.StepInstruction()
+		s := console.CPU()
+		fmt.Printf("PC=$%04X A=$%02X cycles=%d\n", s.PC, s.A, s.Cycles)
+	}
+	// Output:
+	// PC=$C000 A=$00 cycles=8
+	// PC=$C001 A=$00 cycles=10
+	// PC=$C004 A=$3F cycles=14
+}
+
+func ExampleWriteWAV() {
+	console, err := nes.New(exampleROM(), nes.NTSC)
+	if err != nil {
+		log.Fatal(err)
+	}
+	console.EnableAudio(48000)
+	for i := 0; i < 6; i++ {
+		console.StepFrame()
+	}
+
+	var wav bytes.Buffer
+	samples := console.Audio()
+	if err := nes.WriteWAV(&wav, 48000, samples); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(len(samples), "samples,", wav.Len(), "bytes")
+	// Output:
+	// 4792 samples, 9628 bytes
+}
+
+// exampleROM returns an NROM image whose program sets the backdrop colour,
+// plays a 440Hz tone, and reads player 1's controller into $0010-$0017 each
+// frame.
+func exampleROM() []byte {
+	prg := make([]byte, 0x4000)
+	data := []byte{0x3f, 0x00, 0x21, 0x80, 0x01, 0xbf, 0xfd}
+	copy(prg[0x1000:], data) // $D000
+
+	lda := func(i int) []byte { return []byte{0xad, byte(i), 0xd0} }
+	sta := func(address uint16) []byte { return []byte{0x8d, byte(address), byte(address >> 8)} }
+	var reset []byte
+	for _, op := range [][]byte{
+		{0x78},                                   // SEI
+		lda(0), sta(0x2006), lda(1), sta(0x2006), // PPUADDR = $3F00
+		lda(2), sta(0x2007), // backdrop = $21
+		lda(1), sta(0x2006), lda(1), sta(0x2006), // PPUADDR = $0000
+		lda(4), sta(0x4015), lda(5), sta(0x4000), lda(6), sta(0x4002), lda(1), sta(0x4003), // pulse 1
+		lda(3), sta(0x2000), // NMI on
+	} {
+		reset = append(reset, op...)
+	}
+	loop := 0xc000 + len(reset)
+	reset = append(reset, 0x4c, byte(loop), byte(loop>>8)) // JMP loop
+	copy(prg, reset)
+
+	nmi := append(lda(4), sta(0x4016)...)
+	nmi = append(nmi, append(lda(1), sta(0x4016)...)...)
+	for i := uint16(0); i < 8; i++ {
+		nmi = append(nmi, 0xad, 0x16, 0x40) // LDA $4016
+		nmi = append(nmi, sta(0x0010+i)...)
+	}
+	nmi = append(nmi, 0x40) // RTI
+	copy(prg[0x0100:], nmi) // $C100
+
+	copy(prg[0x3ffa:], []byte{0x00, 0xc1, 0x00, 0xc0, 0x00, 0xc1})
+
+	rom := append([]byte{'N', 'E', 'S', 0x1a, 0x01, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, prg...)
+	return append(rom, make([]byte, 0x2000)...)
+}
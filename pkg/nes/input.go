@@ -0,0 +1,51 @@
+package nes
+
+import "github.com/Jac0bDeal/goNES/internal/controller"
+
+// Buttons is the state of a standard controller's buttons, with a bit set for
+// each button held down.
+type Buttons uint8
+
+// Standard controller buttons.
+const (
+	ButtonA      = Buttons(controller.A)
+	ButtonB      = Buttons(controller.B)
+	ButtonSelect = Buttons(controller.Select)
+	ButtonStart  = Buttons(controller.Start)
+	ButtonUp     = Buttons(controller.Up)
+	ButtonDown   = Buttons(controller.Down)
+	ButtonLeft   = Buttons(controller.Left)
+	ButtonRight  = Buttons(controller.Right)
+)
+
+// Player identifies the controller of one of the two players.
+type Player int
+
+// Players.
+const (
+	Player1 Player = iota
+	Player2
+)
+
+// valid returns whether p is a known Player.
+func (p Player) valid() bool {
+	return p == Player1 || p == Player2
+}
+
+// SetButtons holds down a player's buttons, releasing the rest, until it is
+// next called. Games see the change the next time they read the controller.
+// It does nothing for an unknown Player.
+func (c *Console) SetButtons(p Player, b Buttons) {
+	if p.valid() {
+		c.buttons[p] = b
+	}
+}
+
+// Buttons returns the buttons a player is holding down, which are none for an
+// unknown Player.
+func (c *Console) Buttons(p Player) Buttons {
+	if !p.valid() {
+		return 0
+	}
+	return c.buttons[p]
+}
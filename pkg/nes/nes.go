@@ -0,0 +1,143 @@
+package nes
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/Jac0bDeal/goNES/internal/audio"
+	"github.com/Jac0bDeal/goNES/internal/cartridge"
+	"github.com/Jac0bDeal/goNES/internal/console"
+	"github.com/Jac0bDeal/goNES/internal/controller"
+)
+
+// Region is the television standard of a Console.
+type Region int
+
+// Regions.
+const (
+	Auto  Region = iota // Auto picks the region the ROM was made for.
+	NTSC                // NTSC consoles sold in North America and Japan.
+	PAL                 // PAL consoles sold in Europe and Australia.
+	Dendy               // Dendy is the PAL Famiclone sold in Russia.
+)
+
+// String returns the name of the Region.
+func (r Region) String() string {
+	switch r {
+	case Auto:
+		return "auto"
+	case NTSC:
+		return "NTSC"
+	case PAL:
+		return "PAL"
+	case Dendy:
+		return "Dendy"
+	default:
+		return fmt.Sprintf("Region(%d)", int(r))
+	}
+}
+
+// regions maps Regions to the console's, other than Auto.
+var regions = map[Region]console.Region{
+	NTSC:  console.NTSC,
+	PAL:   console.PAL,
+	Dendy: console.Dendy,
+}
+
+// regionOf returns the Region of one of the console's.
+func regionOf(r console.Region) Region {
+	for region, cr := range regions {
+		if cr == r {
+			return region
+		}
+	}
+	return Auto
+}
+
+// Console is a NES with a ROM inserted and a standard controller plugged into
+// each port. It is powered on when it is created.
+type Console struct {
+	nes     *console.NES
+	buttons [2]Buttons
+
+	resampler *audio.Resampler
+}
+
+// New creates a Console for a Region running an iNES or NES 2.0 ROM image.
+func New(rom []byte, region Region) (*Console, error) {
+	cart, err := cartridge.New(rom)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, ok := regions[region]
+	if region == Auto {
+		cr, ok = console.RegionOf(cart.Header.Timing), true
+	}
+	if !ok {
+		return nil, fmt.Errorf("nes: unknown region %s", region)
+	}
+	n, err := console.New(cart, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Console{nes: n}
+	for i := range c.buttons {
+		player := &c.buttons[i]
+		n.Ports().Connect(controller.Port(i), controller.NewJoypad(controller.InputFunc(func() controller.Buttons {
+			return controller.Buttons(*player)
+		})))
+	}
+	return c, nil
+}
+
+// Load creates a Console for a Region running the ROM image read from r.
+func Load(r io.Reader, region Region) (*Console, error) {
+	rom, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(rom, region)
+}
+
+// LoadFile creates a Console for a Region running the ROM image at path.
+func LoadFile(path string, region Region) (*Console, error) {
+	rom, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(rom, region)
+}
+
+// Region returns the Console's region, which is never Auto.
+func (c *Console) Region() Region {
+	return regionOf(c.nes.Region())
+}
+
+// FrameRate returns the number of frames the Console draws per second.
+func (c *Console) FrameRate() float64 {
+	return c.nes.Region().FrameRate()
+}
+
+// StepFrame runs the Console until it has drawn the next frame.
+func (c *Console) StepFrame() {
+	c.nes.StepFrame()
+}
+
+// StepInstruction runs the Console until the CPU has executed the next
+// instruction or started handling an interrupt.
+func (c *Console) StepInstruction() {
+	c.nes.StepInstruction()
+}
+
+// Reset presses the Console's reset button.
+func (c *Console) Reset() {
+	c.nes.Reset()
+}
+
+// PowerCycle switches the Console off and on again.
+func (c *Console) PowerCycle() {
+	c.nes.PowerCycle()
+}
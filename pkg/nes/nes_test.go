@@ -0,0 +1,117 @@
+package nes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testROM returns an NROM image whose program loops at $C000, with byte 9 of
+// its header set.
+func testROM(flags9 byte) []byte {
+	prg := make([]byte, 0x4000)
+	copy(prg, []byte{0x4c, 0x00, 0xc0}) // JMP $C000
+	copy(prg[0x3ffa:], []byte{0x00, 0xc0, 0x00, 0xc0, 0x00, 0xc0})
+	rom := append([]byte{'N', 'E', 'S', 0x1a, 0x01, 0x01, 0, 0, 0, flags9, 0, 0, 0, 0, 0, 0}, prg...)
+	return append(rom, make([]byte, 0x2000)...)
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rom            []byte
+		region         Region
+		expectedRegion Region
+		expectedError  string
+	}{
+		{
+			name:           "auto picks NTSC",
+			rom:            testROM(0x00),
+			region:         Auto,
+			expectedRegion: NTSC,
+		},
+		{
+			name:           "auto picks PAL",
+			rom:            testROM(0x01),
+			region:         Auto,
+			expectedRegion: PAL,
+		},
+		{
+			name:           "region overrides the ROM",
+			rom:            testROM(0x01),
+			region:         Dendy,
+			expectedRegion: Dendy,
+		},
+		{
+			name:          "unknown region",
+			rom:           testROM(0x00),
+			region:        Region(7),
+			expectedError: "nes: unknown region Region(7)",
+		},
+		{
+			name:          "invalid ROM",
+			rom:           []byte("not a ROM"),
+			region:        Auto,
+			expectedError: "cartridge: header truncated: expected 16 bytes, got 9",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := New(tc.rom, tc.region)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRegion, c.Region())
+		})
+	}
+}
+
+func TestConsole_Audio(t *testing.T) {
+	c, err := New(testROM(0x00), NTSC)
+	require.NoError(t, err)
+
+	c.StepFrame()
+	assert.Nil(t, c.Audio(), "audio not enabled")
+
+	c.EnableAudio(44100)
+	c.StepFrame()
+	assert.InDelta(t, 44100/c.FrameRate(), len(c.Audio()), 1)
+
+	c.DisableAudio()
+	c.StepFrame()
+	assert.Nil(t, c.Audio())
+}
+
+func TestConsole_CPU(t *testing.T) {
+	c, err := New(testROM(0x00), NTSC)
+	require.NoError(t, err)
+	c.StepInstruction()
+
+	assert.Equal(t, CPUState{S: 0xfd, P: 0x20, PC: 0xc000, Cycles: 8}, c.CPU())
+}
+
+func TestConsole_Buttons(t *testing.T) {
+	c, err := New(testROM(0x00), NTSC)
+	require.NoError(t, err)
+
+	c.SetButtons(Player2, ButtonB|ButtonDown)
+
+	assert.Equal(t, Buttons(0), c.Buttons(Player1))
+	assert.Equal(t, ButtonB|ButtonDown, c.Buttons(Player2))
+}
+
+func TestConsole_Buttons_UnknownPlayer(t *testing.T) {
+	c, err := New(testROM(0x00), NTSC)
+	require.NoError(t, err)
+
+	for _, p := range []Player{-1, 2} {
+		assert.NotPanics(t, func() { c.SetButtons(p, ButtonA) })
+		assert.Equal(t, Buttons(0), c.Buttons(p))
+	}
+	assert.Equal(t, Buttons(0), c.Buttons(Player1))
+	assert.Equal(t, Buttons(0), c.Buttons(Player2))
+}
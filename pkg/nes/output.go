@@ -0,0 +1,50 @@
+package nes
+
+import (
+	"image"
+	"io"
+
+	"github.com/Jac0bDeal/goNES/internal/audio"
+	"github.com/Jac0bDeal/goNES/internal/ppu"
+)
+
+// Dimensions of the frames the Console draws.
+const (
+	Width  = ppu.Width
+	Height = ppu.Height
+)
+
+// Frame returns a copy of the picture the Console has drawn. Between calls to
+// StepFrame it is the last complete frame.
+func (c *Console) Frame() *image.RGBA {
+	return c.nes.PPU().RGBA()
+}
+
+// EnableAudio starts collecting the Console's audio at a sample rate in Hz,
+// such as 44100 or 48000, to be taken with Audio.
+func (c *Console) EnableAudio(sampleRate int) {
+	c.resampler = audio.NewResampler(c.nes.Region().CPUClockRate(), float64(sampleRate))
+	c.nes.SetAudioOutput(c.resampler.Add)
+}
+
+// DisableAudio stops collecting the Console's audio, discarding any not yet
+// taken.
+func (c *Console) DisableAudio() {
+	c.resampler = nil
+	c.nes.SetAudioOutput(nil)
+}
+
+// Audio returns the mono samples produced since it was last called, at the
+// rate passed to EnableAudio and ranging from -1 to 1. It returns nil if audio
+// is not enabled.
+func (c *Console) Audio() []float32 {
+	if c.resampler == nil {
+		return nil
+	}
+	return c.resampler.Samples()
+}
+
+// WriteWAV writes mono samples at a sample rate to w as a 16-bit PCM WAV file.
+func WriteWAV(w io.Writer, sampleRate int, samples []float32) error {
+	return audio.WriteWAV(w, sampleRate, samples)
+}
@@ -0,0 +1,65 @@
+package nes
+
+import "github.com/Jac0bDeal/goNES/internal/cpu"
+
+// CPUState is a snapshot of the CPU's registers.
+type CPUState struct {
+	A  uint8  // accumulator
+	X  uint8  // X index register
+	Y  uint8  // Y index register
+	S  uint8  // stack pointer
+	P  uint8  // status register, NV-BDIZC from bit 7 to bit 0
+	PC uint16 // program counter
+
+	// Cycles is the number of CPU cycles run since the Console was powered
+	// on.
+	Cycles uint64
+	// Jammed is whether the CPU has executed one of the KIL opcodes, which
+	// stop it until the Console is reset.
+	Jammed bool
+}
+
+// PPUState is a snapshot of the PPU's progress through the picture.
+type PPUState struct {
+	// Frame is the number of frames drawn since the Console was powered on.
+	Frame uint64
+	// Scanline is the scanline being drawn, where 0-239 are visible.
+	Scanline int
+	// Dot is the dot within the scanline being drawn, 0-340.
+	Dot int
+}
+
+// CPU returns a snapshot of the CPU's registers.
+func (c *Console) CPU() CPUState {
+	p := c.nes.CPU()
+	var status uint8
+	for bit := uint(0); bit < 8; bit++ {
+		status |= p.GetStatusFlag(cpu.Flag(1<<bit)) << bit
+	}
+	return CPUState{
+		A:      p.GetAccumulator(),
+		X:      p.GetX(),
+		Y:      p.GetY(),
+		S:      p.GetStackPointer(),
+		P:      status,
+		PC:     p.GetProgramCounter(),
+		Cycles: c.nes.Cycles(),
+		Jammed: p.Jammed(),
+	}
+}
+
+// PPU returns a snapshot of the PPU's progress through the picture.
+func (c *Console) PPU() PPUState {
+	p := c.nes.PPU()
+	return PPUState{
+		Frame:    p.Frame(),
+		Scanline: p.Scanline(),
+		Dot:      p.Dot(),
+	}
+}
+
+// Peek reads a byte from the CPU's address space without the side effects
+// reading it would have, so that memory-mapped registers can be inspected.
+func (c *Console) Peek(address uint16) uint8 {
+	return c.nes.Bus().Peek(address)
+}